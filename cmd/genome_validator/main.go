@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"log"
+	"os"
+)
+
+// The genome validator command line tool. It reads genomes from provided files in plain text or YAML encoding,
+// validates them and prints all found issues. The exit status is non-zero if any of the genomes is invalid. The genomes
+// with custom activation functions should be read with the execution context configuration file of the run, which
+// declares them.
+func main() {
+	var format = flag.String("format", "text", "The output format of the validation report. [text, json]")
+	var severity = flag.String("severity", "info", "The minimal severity of issues to report. [info, warning, error]")
+	var contextPath = flag.String("context", "", "The execution context configuration file of the run to load custom activation functions from.")
+
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] genome_file...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	minSeverity, err := genetics.ValidationSeverityByName(*severity)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *format != "text" && *format != "json" {
+		log.Fatalf("Unsupported output format: %s", *format)
+	}
+
+	// load the activators factory of the run if provided
+	var activators *math.NodeActivatorsFactory
+	if *contextPath != "" {
+		opts, err := neat.ReadNeatOptionsFromFile(*contextPath)
+		if err != nil {
+			log.Fatalf("Failed to load NEAT options, reason: '%s'", err)
+		}
+		activators = opts.Activators()
+	}
+
+	// suppress informational messages of the genome reader to keep report output clean
+	neat.LogLevel = neat.LogLevelWarning
+
+	valid := true
+	for _, genomePath := range flag.Args() {
+		reader, err := genetics.NewGenomeReaderFromFileWithActivators(genomePath, activators)
+		if err != nil {
+			log.Fatalf("Failed to open genome file, reason: '%s'", err)
+		}
+		genome, err := reader.Read()
+		if err != nil {
+			log.Fatalf("Failed to read genome from file: [%s], reason: '%s'", genomePath, err)
+		}
+
+		report := genome.Validate()
+		valid = valid && report.IsValid()
+		if *format == "json" {
+			filtered := genetics.ValidationReport{GenomeId: report.GenomeId, Issues: report.IssuesWithSeverity(minSeverity)}
+			err = filtered.WriteJSON(os.Stdout)
+		} else {
+			fmt.Printf(">>> %s\n", genomePath)
+			err = report.WriteReport(os.Stdout, minSeverity)
+		}
+		if err != nil {
+			log.Fatalf("Failed to write validation report, reason: '%s'", err)
+		}
+	}
+
+	if !valid {
+		os.Exit(1)
+	}
+}
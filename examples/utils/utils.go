@@ -35,9 +35,14 @@ func LoadOptionsAndGenome(contextPath, genomePath string) (*neat.Options, *genet
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to open genome file")
 	}
-	startGenome, err := genetics.ReadGenome(genomeFile, 1)
+	reader, err := genetics.NewGenomeReaderWithActivators(genomeFile, genetics.PlainGenomeEncoding, context.NodeActivatorsFactory)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create genome reader")
+	}
+	startGenome, err := reader.Read()
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to read start genome")
 	}
+	startGenome.Id = 1
 	return context, startGenome, nil
 }
@@ -41,7 +41,7 @@ func main() {
 
 	// Load Genome
 	log.Printf("Loading start genome for %s experiment from file '%s'\n", *experimentName, *genomePath)
-	reader, err := genetics.NewGenomeReaderFromFile(*genomePath)
+	reader, err := genetics.NewGenomeReaderFromFileWithActivators(*genomePath, neatOptions.NodeActivatorsFactory)
 	if err != nil {
 		log.Fatalf("Failed to open genome file, reason: '%s'", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/sbinet/npyio/npz"
 	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
 	"gonum.org/v1/gonum/mat"
 	"io"
 	"math"
@@ -363,17 +364,39 @@ func (e *Experiment) Encode(enc *gob.Encoder) error {
 			return err
 		}
 	}
-	return nil
+
+	// encode the operators statistics of all generations last, the data of older versions ends before them
+	stats := make([][][]genetics.OperatorStatistics, len(e.Trials))
+	for i, t := range e.Trials {
+		stats[i] = make([][]genetics.OperatorStatistics, len(t.Generations))
+		for j, g := range t.Generations {
+			stats[i][j] = g.OperatorStats
+		}
+	}
+	return enc.Encode(stats)
 }
 
 // Read is to read experiment data from provided reader and decodes it
 func (e *Experiment) Read(r io.Reader) error {
+	return e.ReadWithActivators(r, nil)
+}
+
+// ReadWithActivators is to read experiment data from provided reader and decodes it resolving the activation functions
+// of the champions' genomes using provided factory, e.g., the neat.Options.Activators() of the experiment with
+// custom activation functions. If activators is nil the default math.NodeActivators is used.
+func (e *Experiment) ReadWithActivators(r io.Reader, activators *neatmath.NodeActivatorsFactory) error {
 	dec := gob.NewDecoder(r)
-	return e.Decode(dec)
+	return e.DecodeWithActivators(dec, activators)
 }
 
 // Decode Decodes experiment data
 func (e *Experiment) Decode(dec *gob.Decoder) error {
+	return e.DecodeWithActivators(dec, nil)
+}
+
+// DecodeWithActivators Decodes experiment data resolving the activation functions of the champions' genomes using
+// provided factory. If activators is nil the default math.NodeActivators is used.
+func (e *Experiment) DecodeWithActivators(dec *gob.Decoder, activators *neatmath.NodeActivatorsFactory) error {
 	if err := dec.Decode(&e.Id); err != nil {
 		return err
 	}
@@ -390,11 +413,32 @@ func (e *Experiment) Decode(dec *gob.Decoder) error {
 	e.Trials = make([]Trial, tNum)
 	for i := 0; i < tNum; i++ {
 		trial := Trial{}
-		if err := trial.Decode(dec); err != nil {
+		if err := trial.DecodeWithActivators(dec, activators); err != nil {
 			return err
 		}
 		e.Trials[i] = trial
 	}
+
+	// decode the operators statistics of all generations, which are absent in the data of older versions
+	var stats [][][]genetics.OperatorStatistics
+	if err := dec.Decode(&stats); err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if len(stats) != len(e.Trials) {
+		return fmt.Errorf("the operators statistics found for %d trials, but experiment has %d trials",
+			len(stats), len(e.Trials))
+	}
+	for i := range e.Trials {
+		if len(stats[i]) != len(e.Trials[i].Generations) {
+			return fmt.Errorf("the operators statistics found for %d generations, but trial %d has %d generations",
+				len(stats[i]), i, len(e.Trials[i].Generations))
+		}
+		for j := range e.Trials[i].Generations {
+			e.Trials[i].Generations[j].OperatorStats = stats[i][j]
+		}
+	}
 	return nil
 }
 
@@ -407,6 +451,12 @@ func (e *Experiment) Decode(dec *gob.Decoder) error {
 // - trial_[0...n]_epoch_best_fitnesses - the best fitness scores per epoch per trial
 // the same for AGE and COMPLEXITY per epoch per trial
 // - trial_[0...n]_epoch_diversity - the number of species per epoch per trial
+// - trial_[0...n]_epoch_operators_offspring - the number of offspring produced by each reproduction operator per epoch
+// per trial, where columns are ordered as genetics.ReproductionOperators
+// - trial_[0...n]_epoch_operators_improvement_rate - the fraction of offspring that outperformed their parents
+// per reproduction operator per epoch per trial
+// - trial_[0...n]_epoch_operators_mean_fitness_delta - the mean difference between fitness of offspring and
+// the best fitness of their parents per reproduction operator per epoch per trial
 func (e *Experiment) WriteNPZ(w io.Writer) error {
 	// write general statistics
 	trialsFitness, trialsAges, trialsComplexity := e.fitnessAgeComplexityMat()
@@ -448,6 +498,17 @@ func (e *Experiment) WriteNPZ(w io.Writer) error {
 		if err := out.Write(fmt.Sprintf("trial_%d_epoch_diversity", i), t.Diversity()); err != nil {
 			return err
 		}
+		if offspring, improvementRate, fitnessDelta := t.OperatorsStatistics(); offspring != nil {
+			if err := out.Write(fmt.Sprintf("trial_%d_epoch_operators_offspring", i), offspring); err != nil {
+				return err
+			}
+			if err := out.Write(fmt.Sprintf("trial_%d_epoch_operators_improvement_rate", i), improvementRate); err != nil {
+				return err
+			}
+			if err := out.Write(fmt.Sprintf("trial_%d_epoch_operators_mean_fitness_delta", i), fitnessDelta); err != nil {
+				return err
+			}
+		}
 	}
 	return out.Close()
 }
@@ -2,11 +2,13 @@ package experiment
 
 import (
 	"bytes"
+	"encoding/gob"
 	"fmt"
 	"github.com/sbinet/npyio/npz"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
 	"gonum.org/v1/gonum/mat"
 	"math"
 	"testing"
@@ -40,6 +42,67 @@ func TestExperiment_Write_Read(t *testing.T) {
 	}
 }
 
+func TestExperiment_ReadWithActivators(t *testing.T) {
+	activators := neatmath.NodeActivators.Clone()
+	cube, err := activators.RegisterExpression("CubeActivation", "x^3")
+	require.NoError(t, err)
+
+	ex := Experiment{Id: 1, Name: "Test Custom Activators", Trials: make(Trials, 2)}
+	for i := 0; i < len(ex.Trials); i++ {
+		ex.Trials[i] = *buildTestTrial(i+1, 3)
+		for _, gen := range ex.Trials[i].Generations {
+			gen.Champion.Genotype.Nodes[3].ActivationType = cube
+			gen.Champion.Genotype.SetActivators(activators)
+		}
+	}
+
+	var buff bytes.Buffer
+	err = ex.Write(&buff)
+	require.NoError(t, err, "Failed to write experiment")
+	data := buff.Bytes()
+
+	newEx := Experiment{}
+	err = newEx.ReadWithActivators(bytes.NewBuffer(data), activators)
+	require.NoError(t, err, "failed to read experiment")
+	require.Len(t, newEx.Trials, len(ex.Trials))
+	for i := 0; i < len(ex.Trials); i++ {
+		assert.EqualValues(t, ex.Trials[i], newEx.Trials[i])
+	}
+
+	// the default activators factory has no custom activation function
+	err = (&Experiment{}).Read(bytes.NewBuffer(data))
+	assert.EqualError(t, err, "unsupported activation type name: CubeActivation")
+}
+
+func TestExperiment_Read_noOperatorStats(t *testing.T) {
+	ex := Experiment{Id: 1, Name: "Test Encode Decode", Trials: make(Trials, 2)}
+	for i := 0; i < len(ex.Trials); i++ {
+		ex.Trials[i] = *buildTestTrial(i+1, 3)
+	}
+
+	// the data of older versions without operators statistics
+	var buff bytes.Buffer
+	enc := gob.NewEncoder(&buff)
+	require.NoError(t, enc.Encode(ex.Id))
+	require.NoError(t, enc.Encode(ex.Name))
+	require.NoError(t, enc.Encode(len(ex.Trials)))
+	for _, trial := range ex.Trials {
+		require.NoError(t, trial.Encode(enc))
+	}
+
+	newEx := Experiment{}
+	err := newEx.Read(bytes.NewBuffer(buff.Bytes()))
+	require.NoError(t, err, "failed to read experiment")
+	require.Len(t, newEx.Trials, len(ex.Trials))
+	for _, trial := range newEx.Trials {
+		require.Len(t, trial.Generations, 3)
+		for _, gen := range trial.Generations {
+			assert.Nil(t, gen.OperatorStats)
+			assert.NotNil(t, gen.Champion)
+		}
+	}
+}
+
 func TestExperiment_Write_writeError(t *testing.T) {
 	ex := Experiment{Id: 1, Name: "Test Encode Decode", Trials: make(Trials, 3)}
 	for i := 0; i < len(ex.Trials); i++ {
@@ -147,6 +210,25 @@ func TestExperiment_WriteNPZ(t *testing.T) {
 		err = r.Read(key, &diversity)
 		assert.NoError(t, err)
 		assert.EqualValues(t, expectedDiversity, diversity)
+
+		expectedOffspring, expectedImprovementRate, expectedFitnessDelta := tr.OperatorsStatistics()
+		key = fmt.Sprintf("trial_%d_epoch_operators_offspring", i)
+		offspring := &mat.Dense{}
+		err = r.Read(key, offspring)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expectedOffspring, offspring)
+
+		key = fmt.Sprintf("trial_%d_epoch_operators_improvement_rate", i)
+		improvementRate := &mat.Dense{}
+		err = r.Read(key, improvementRate)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expectedImprovementRate, improvementRate)
+
+		key = fmt.Sprintf("trial_%d_epoch_operators_mean_fitness_delta", i)
+		fitnessDelta := &mat.Dense{}
+		err = r.Read(key, fitnessDelta)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expectedFitnessDelta, fitnessDelta)
 	}
 	err = r.Close()
 	assert.NoError(t, err, "failed to close reader")
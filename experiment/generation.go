@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"github.com/pkg/errors"
 	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
 	"math"
 	"reflect"
 	"sort"
@@ -43,6 +44,11 @@ type Generation struct {
 
 	// The ID of Trial this Generation was evaluated in
 	TrialId int
+
+	// The statistics of reproduction operators applied to produce organisms evaluated in this epoch. The statistics
+	// are stored per operator in order of genetics.ReproductionOperators list. It is encoded at the end of
+	// the experiment data (see Experiment.Encode) to keep the data saved by the older versions readable.
+	OperatorStats []genetics.OperatorStatistics
 }
 
 // FillPopulationStatistics Collects statistics about given population
@@ -67,6 +73,52 @@ func (g *Generation) FillPopulationStatistics(pop *genetics.Population) {
 			}
 		}
 	}
+	g.OperatorStats = genetics.CollectOperatorStatistics(pop.Organisms)
+}
+
+// OperatorsOffspring returns the number of offspring produced by each reproduction operator in this epoch
+// in order of genetics.ReproductionOperators list
+func (g *Generation) OperatorsOffspring() Floats {
+	res := make(Floats, len(genetics.ReproductionOperators))
+	for i, op := range genetics.ReproductionOperators {
+		if s := g.operatorStatistics(op); s != nil {
+			res[i] = float64(s.Offspring)
+		}
+	}
+	return res
+}
+
+// OperatorsImprovementRate returns the fraction of offspring with fitness exceeding the best fitness of their parents
+// per each reproduction operator in this epoch in order of genetics.ReproductionOperators list
+func (g *Generation) OperatorsImprovementRate() Floats {
+	res := make(Floats, len(genetics.ReproductionOperators))
+	for i, op := range genetics.ReproductionOperators {
+		if s := g.operatorStatistics(op); s != nil {
+			res[i] = s.ImprovementRate()
+		}
+	}
+	return res
+}
+
+// OperatorsMeanFitnessDelta returns the mean difference between fitness of offspring and the best fitness of their
+// parents per each reproduction operator in this epoch in order of genetics.ReproductionOperators list
+func (g *Generation) OperatorsMeanFitnessDelta() Floats {
+	res := make(Floats, len(genetics.ReproductionOperators))
+	for i, op := range genetics.ReproductionOperators {
+		if s := g.operatorStatistics(op); s != nil {
+			res[i] = s.MeanFitnessDelta()
+		}
+	}
+	return res
+}
+
+func (g *Generation) operatorStatistics(op genetics.ReproductionOperator) *genetics.OperatorStatistics {
+	for i := range g.OperatorStats {
+		if g.OperatorStats[i].Operator == op {
+			return &g.OperatorStats[i]
+		}
+	}
+	return nil
 }
 
 // Average the average fitness, age, and complexity among the best organisms of each species in the population
@@ -160,7 +212,14 @@ func encodeOrganism(enc *gob.Encoder, org *genetics.Organism) error {
 	return nil
 }
 
+// Decode is to decode the generation with provided GOB decoder
 func (g *Generation) Decode(dec *gob.Decoder) error {
+	return g.DecodeWithActivators(dec, nil)
+}
+
+// DecodeWithActivators is to decode the generation with provided GOB decoder resolving the activation functions of
+// the champion's genome using provided factory. If activators is nil the default math.NodeActivators is used.
+func (g *Generation) DecodeWithActivators(dec *gob.Decoder, activators *neatmath.NodeActivatorsFactory) error {
 	if err := dec.Decode(&g.Id); err != nil {
 		return errors.Wrap(err, "failed to decode Id")
 	}
@@ -199,7 +258,7 @@ func (g *Generation) Decode(dec *gob.Decoder) error {
 	}
 
 	// decode organism
-	if org, err := decodeOrganism(dec); err != nil {
+	if org, err := decodeOrganism(dec, activators); err != nil {
 		return err
 	} else {
 		g.Champion = org
@@ -207,7 +266,7 @@ func (g *Generation) Decode(dec *gob.Decoder) error {
 	return nil
 }
 
-func decodeOrganism(dec *gob.Decoder) (*genetics.Organism, error) {
+func decodeOrganism(dec *gob.Decoder, activators *neatmath.NodeActivatorsFactory) (*genetics.Organism, error) {
 	org := genetics.Organism{}
 	if err := dec.Decode(&org.Fitness); err != nil {
 		return nil, errors.Wrap(err, "failed to decode Fitness")
@@ -234,7 +293,7 @@ func decodeOrganism(dec *gob.Decoder) (*genetics.Organism, error) {
 	if err := dec.Decode(&data); err != nil {
 		return nil, errors.Wrap(err, "failed to decode organism's data")
 	}
-	if gen, err := genetics.ReadGenome(bytes.NewBuffer(data), genId); err != nil {
+	if gen, err := genetics.ReadGenomeWithActivators(bytes.NewBuffer(data), genId, activators); err != nil {
 		return nil, err
 	} else {
 		org.Genotype = gen
@@ -67,6 +67,36 @@ func TestGeneration_FillPopulationStatistics(t *testing.T) {
 	assert.EqualValues(t, Floats{11, 25, 36, 32, 35}, gen.Complexity)
 	assert.NotNil(t, gen.Champion)
 	assert.Equal(t, maxFitness, gen.Champion.Fitness)
+	// initial population has only spawned organisms
+	assert.Len(t, gen.OperatorStats, len(genetics.ReproductionOperators))
+	assert.EqualValues(t, make(Floats, len(genetics.ReproductionOperators)), gen.OperatorsOffspring())
+}
+
+func TestGeneration_OperatorsStatistics(t *testing.T) {
+	gen := buildTestGeneration(1, 10.0)
+
+	offspring := gen.OperatorsOffspring()
+	improvementRate := gen.OperatorsImprovementRate()
+	fitnessDelta := gen.OperatorsMeanFitnessDelta()
+	require.Len(t, offspring, len(genetics.ReproductionOperators))
+	require.Len(t, improvementRate, len(genetics.ReproductionOperators))
+	require.Len(t, fitnessDelta, len(genetics.ReproductionOperators))
+	for i, op := range genetics.ReproductionOperators {
+		switch op {
+		case genetics.MutateAddNodeOperator:
+			assert.Equal(t, 4.0, offspring[i])
+			assert.Equal(t, 0.25, improvementRate[i])
+			assert.Equal(t, 0.5, fitnessDelta[i])
+		case genetics.MateMultipointOperator:
+			assert.Equal(t, 2.0, offspring[i])
+			assert.Equal(t, 1.0, improvementRate[i])
+			assert.Equal(t, 1.5, fitnessDelta[i])
+		default:
+			assert.Zero(t, offspring[i])
+			assert.Zero(t, improvementRate[i])
+			assert.Zero(t, fitnessDelta[i])
+		}
+	}
 }
 
 func createGenerationWith(fitness Floats, ages Floats, complexities Floats) *Generation {
@@ -97,8 +127,34 @@ func TestGeneration_Encode_Decode(t *testing.T) {
 	err = dgen.Decode(dec)
 	require.NoError(t, err, "failed to decode generation")
 
-	//  and test fields
+	//  and test fields, the operators statistics are encoded with experiment
+	gen.OperatorStats = nil
+	assert.EqualValues(t, gen, dgen)
+}
+
+func TestGeneration_DecodeWithActivators(t *testing.T) {
+	activators := math.NodeActivators.Clone()
+	cube, err := activators.RegisterExpression("CubeActivation", "x^3")
+	require.NoError(t, err)
+
+	gen := buildTestGeneration(1, 23.0)
+	gen.Champion.Genotype.Nodes[3].ActivationType = cube
+	gen.Champion.Genotype.SetActivators(activators)
+
+	var buff bytes.Buffer
+	err = gen.Encode(gob.NewEncoder(&buff))
+	require.NoError(t, err, "failed to encode generation")
+	data := buff.Bytes()
+
+	dgen := &Generation{}
+	err = dgen.DecodeWithActivators(gob.NewDecoder(bytes.NewBuffer(data)), activators)
+	require.NoError(t, err, "failed to decode generation")
+	gen.OperatorStats = nil
 	assert.EqualValues(t, gen, dgen)
+
+	// the default activators factory has no custom activation function
+	err = (&Generation{}).Decode(gob.NewDecoder(bytes.NewBuffer(data)))
+	assert.EqualError(t, err, "unsupported activation type name: CubeActivation")
 }
 
 const (
@@ -112,6 +168,11 @@ var (
 	testAge        = Floats{1.0, 3.0, 4.0, 10.0}
 	testComplexity = Floats{34.0, 21.0, 56.0, 15.0}
 	testFitness    = Floats{10.0, 30.0, 40.0}
+
+	testOperatorStats = []genetics.OperatorStatistics{
+		{Operator: genetics.MutateAddNodeOperator, Offspring: 4, Improved: 1, Degraded: 2, FitnessDeltaSum: 2.0},
+		{Operator: genetics.MateMultipointOperator, Offspring: 2, Improved: 2, FitnessDeltaSum: 3.0},
+	}
 )
 
 func buildTestGeneration(genId int, fitness float64) *Generation {
@@ -132,6 +193,7 @@ func buildTestGenerationWithDuration(genId int, fitness float64, duration time.D
 	epoch.WinnerNodes = testWinnerNodes
 	epoch.WinnerGenes = testWinnerGenes
 	epoch.Duration = duration
+	epoch.OperatorStats = testOperatorStats
 
 	genome := buildTestGenome(genId)
 	org := genetics.Organism{Fitness: fitness, Genotype: genome, Generation: genId, IsWinner: true}
@@ -3,6 +3,8 @@ package experiment
 import (
 	"encoding/gob"
 	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
+	"gonum.org/v1/gonum/mat"
 	"sort"
 	"time"
 )
@@ -116,6 +118,25 @@ func (t *Trial) Diversity() Floats {
 	return x
 }
 
+// OperatorsStatistics returns statistics of reproduction operators for each epoch in this trial. The rows of each
+// returned matrix correspond to the epochs and columns correspond to the operators in order of
+// genetics.ReproductionOperators list. Returns nil matrices if trial has no epochs.
+func (t *Trial) OperatorsStatistics() (offspring, improvementRate, meanFitnessDelta *mat.Dense) {
+	if len(t.Generations) == 0 {
+		return nil, nil, nil
+	}
+	cols := len(genetics.ReproductionOperators)
+	offspring = mat.NewDense(len(t.Generations), cols, nil)
+	improvementRate = mat.NewDense(len(t.Generations), cols, nil)
+	meanFitnessDelta = mat.NewDense(len(t.Generations), cols, nil)
+	for i := range t.Generations {
+		offspring.SetRow(i, t.Generations[i].OperatorsOffspring())
+		improvementRate.SetRow(i, t.Generations[i].OperatorsImprovementRate())
+		meanFitnessDelta.SetRow(i, t.Generations[i].OperatorsMeanFitnessDelta())
+	}
+	return offspring, improvementRate, meanFitnessDelta
+}
+
 // Average the average fitness, age, and complexity of the best organisms per species for each epoch in this trial
 func (t *Trial) Average() (fitness, age, complexity Floats) {
 	fitness = make(Floats, len(t.Generations))
@@ -171,6 +192,12 @@ func (t *Trial) Encode(enc *gob.Encoder) error {
 
 // Decode Decodes trial data
 func (t *Trial) Decode(dec *gob.Decoder) error {
+	return t.DecodeWithActivators(dec, nil)
+}
+
+// DecodeWithActivators Decodes trial data resolving the activation functions of the champions' genomes using
+// provided factory. If activators is nil the default math.NodeActivators is used.
+func (t *Trial) DecodeWithActivators(dec *gob.Decoder, activators *neatmath.NodeActivatorsFactory) error {
 	if err := dec.Decode(&t.Id); err != nil {
 		return err
 	}
@@ -181,7 +208,7 @@ func (t *Trial) Decode(dec *gob.Decoder) error {
 	t.Generations = make([]Generation, ngen)
 	for i := 0; i < ngen; i++ {
 		gen := Generation{}
-		if err := gen.Decode(dec); err != nil {
+		if err := gen.DecodeWithActivators(dec, activators); err != nil {
 			return err
 		}
 		t.Generations[i] = gen
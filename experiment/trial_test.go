@@ -141,6 +141,32 @@ func TestTrial_Diversity_emptyEpochs(t *testing.T) {
 	assert.Equal(t, 0, len(div))
 }
 
+func TestTrial_OperatorsStatistics(t *testing.T) {
+	numGen := 4
+	trial := buildTestTrial(1, numGen)
+	offspring, improvementRate, fitnessDelta := trial.OperatorsStatistics()
+	require.NotNil(t, offspring)
+	require.NotNil(t, improvementRate)
+	require.NotNil(t, fitnessDelta)
+
+	rows, cols := offspring.Dims()
+	assert.Equal(t, numGen, rows)
+	assert.Equal(t, len(genetics.ReproductionOperators), cols)
+	for i := 0; i < numGen; i++ {
+		assert.EqualValues(t, trial.Generations[i].OperatorsOffspring(), offspring.RawRowView(i))
+		assert.EqualValues(t, trial.Generations[i].OperatorsImprovementRate(), improvementRate.RawRowView(i))
+		assert.EqualValues(t, trial.Generations[i].OperatorsMeanFitnessDelta(), fitnessDelta.RawRowView(i))
+	}
+}
+
+func TestTrial_OperatorsStatistics_emptyEpochs(t *testing.T) {
+	trial := Trial{Id: 1, Generations: make([]Generation, 0)}
+	offspring, improvementRate, fitnessDelta := trial.OperatorsStatistics()
+	assert.Nil(t, offspring)
+	assert.Nil(t, improvementRate)
+	assert.Nil(t, fitnessDelta)
+}
+
 func TestTrial_Average(t *testing.T) {
 	numGen := 4
 	trial := buildTestTrial(1, numGen)
@@ -211,7 +237,10 @@ func TestTrial_Encode_Decode(t *testing.T) {
 	err = decTrial.Decode(dec)
 	require.NoError(t, err, "failed to decode trial")
 
-	// do deep compare of Trail fields
+	// do deep compare of Trail fields, the operators statistics are encoded with experiment
+	for i := range trial.Generations {
+		trial.Generations[i].OperatorStats = nil
+	}
 	assert.EqualValues(t, *trial, decTrial)
 }
 
@@ -40,6 +40,12 @@ const (
 	YAMLGenomeEncoding
 )
 
+// The keys of the optional node genes in the plain text genome encoding, e.g. "bias=0.5 response=1.5"
+const (
+	plainNodeBiasKey     = "bias="
+	plainNodeResponseKey = "response="
+)
+
 var (
 	ErrUnsupportedGenomeEncoding = errors.New("unsupported genome encoding")
 )
@@ -39,8 +39,9 @@ func NewGeneWithTrait(trait *neat.Trait, weight float64, inNode, outNode *networ
 
 // NewGeneCopy Construct a gene off of another gene as a duplicate
 func NewGeneCopy(g *Gene, trait *neat.Trait, inNode, outNode *network.NNode) *Gene {
-	return NewConnectionGene(network.NewLinkWithTrait(trait, g.Link.ConnectionWeight, inNode, outNode, g.Link.IsRecurrent),
-		g.InnovationNum, g.MutationNum, true)
+	link := network.NewLinkWithTrait(trait, g.Link.ConnectionWeight, inNode, outNode, g.Link.IsRecurrent)
+	link.Delay = g.Link.Delay
+	return NewConnectionGene(link, g.InnovationNum, g.MutationNum, true)
 }
 
 // NewConnectionGene is to create new connection gene with provided link
@@ -62,6 +63,9 @@ func (g *Gene) String() string {
 	if g.Link.IsRecurrent {
 		recurrentStr = " -RECUR-"
 	}
+	if g.Link.Delay > 0 {
+		recurrentStr += fmt.Sprintf(" -DELAY %d-", g.Link.Delay)
+	}
 	traitStr := ""
 	if g.Link.Trait != nil {
 		traitStr = fmt.Sprintf(" Link's trait_id: %d", g.Link.Trait.Id)
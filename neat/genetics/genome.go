@@ -40,6 +40,9 @@ type Genome struct {
 
 	// Allows Genome to be matched with its Network
 	Phenotype *network.Network `yaml:""`
+
+	// The factory of activation functions of this genome, if nil the default math.NodeActivators is used
+	activators *math.NodeActivatorsFactory
 }
 
 // NewGenome Constructor which takes full genome specs and puts them into the new one
@@ -188,9 +191,15 @@ func newGenomeRand(newId, in, out, n, maxHidden int, recurrent bool, linkProb fl
 
 // ReadGenome reads Genome from reader
 func ReadGenome(ir io.Reader, id int) (*Genome, error) {
+	return ReadGenomeWithActivators(ir, id, nil)
+}
+
+// ReadGenomeWithActivators reads Genome from reader resolving the activation functions of the nodes using provided
+// factory (see NewGenomeReaderWithActivators). If activators is nil the default math.NodeActivators is used.
+func ReadGenomeWithActivators(ir io.Reader, id int, activators *math.NodeActivatorsFactory) (*Genome, error) {
 	// stub for backward compatibility
 	// the new implementations should use GenomeReader to decode genome data in variety of formats
-	r, err := NewGenomeReader(ir, PlainGenomeEncoding)
+	r, err := NewGenomeReaderWithActivators(ir, PlainGenomeEncoding, activators)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +237,10 @@ func (g *Genome) String() string {
 			nodeType = "B"
 		case network.HiddenNeuron:
 			nodeType = "H"
+		case network.MemoryNeuron:
+			nodeType = "M"
+		case network.ModulatoryNeuron:
+			nodeType = "N"
 		}
 		str += fmt.Sprintf("\t%s%s \n", nodeType, n)
 	}
@@ -417,6 +430,7 @@ func (g *Genome) Genesis(netId int) (*network.Network, error) {
 			// NOTE: This line could be run through a recurrence check if desired
 			// (no need to in the current implementation of NEAT)
 			newLink = network.NewLinkWithTrait(curLink.Trait, curLink.ConnectionWeight, inNode, outNode, curLink.IsRecurrent)
+			newLink.Delay = curLink.Delay
 
 			// Add link to the connected nodes
 			outNode.Incoming = append(outNode.Incoming, newLink)
@@ -461,6 +475,9 @@ func (g *Genome) Genesis(netId int) (*network.Network, error) {
 		newNet = network.NewModularNetwork(inList, outList, allList, cNodes, netId)
 	}
 
+	// the network uses the same activation functions as its genome
+	newNet.SetActivators(g.activators)
+
 	// Attach genotype and phenotype together:
 	// genotype points to owner phenotype (new_net)
 	g.Phenotype = newNet
@@ -514,7 +531,9 @@ func (g *Genome) duplicate(newId int) (*Genome, error) {
 
 	if len(g.ControlGenes) == 0 {
 		// If no MIMO control genes return plain genome
-		return NewGenome(newId, traitsDup, nodesDup, genesDup), nil
+		dup := NewGenome(newId, traitsDup, nodesDup, genesDup)
+		dup.activators = g.activators
+		return dup, nil
 	} else {
 		// Duplicate MIMO Control Genes and build modular genome
 		controlGenesDup := make([]*MIMOControlGene, len(g.ControlGenes))
@@ -553,8 +572,24 @@ func (g *Genome) duplicate(newId int) (*Genome, error) {
 			controlGenesDup[i] = NewMIMOGeneCopy(cg, nodeCopy)
 		}
 
-		return NewModularGenome(newId, traitsDup, nodesDup, genesDup, controlGenesDup), nil
+		dup := NewModularGenome(newId, traitsDup, nodesDup, genesDup, controlGenesDup)
+		dup.activators = g.activators
+		return dup, nil
+	}
+}
+
+// SetActivators Sets the factory of activation functions used by this genome to encode its nodes and to activate the
+// networks created by Genesis. Use nil to reset to the default math.NodeActivators.
+func (g *Genome) SetActivators(activators *math.NodeActivatorsFactory) {
+	g.activators = activators
+}
+
+// Activators Returns the factory of activation functions of this genome
+func (g *Genome) Activators() *math.NodeActivatorsFactory {
+	if g.activators != nil {
+		return g.activators
 	}
+	return math.NodeActivators
 }
 
 // For debugging: A number of tests can be run on a genome to check its integrity.
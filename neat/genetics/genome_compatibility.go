@@ -2,6 +2,7 @@ package genetics
 
 import (
 	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/network"
 	"math"
 )
 
@@ -14,6 +15,9 @@ import (
 // The three coefficients are global system parameters.
 // The bigger returned value the less compatible the genomes.
 //
+// If node_diff_coeff is set, the average difference of the bias and response genes of matching nodes (mdmn) is added,
+// i.e. node_diff_coeff * mdmn.
+//
 // Fully compatible genomes has 0.0 returned.
 func (g *Genome) compatibility(og *Genome, opts *neat.Options) float64 {
 	if opts.GenCompatMethod == neat.GenomeCompatibilityMethodLinear {
@@ -73,6 +77,9 @@ func (g *Genome) compatLinear(og *Genome, opts *neat.Options) float64 {
 	// Genes in the Genome. Look at disjointedness and excess in the absolute (ignoring size)
 	comp := opts.DisjointCoeff*numDisjoint + opts.ExcessCoeff*numExcess +
 		opts.MutdiffCoeff*(mutDiffTotal/numMatching)
+	if opts.NodeDiffCoeff != 0 {
+		comp += opts.NodeDiffCoeff * g.nodeGenesDifference(og)
+	}
 
 	return comp
 }
@@ -187,5 +194,33 @@ func (g *Genome) compatFast(og *Genome, opts *neat.Options) float64 {
 	if numMatching > 0 {
 		compatibility += mutDiff * opts.MutdiffCoeff / float64(numMatching)
 	}
+	if opts.NodeDiffCoeff != 0 {
+		compatibility += opts.NodeDiffCoeff * g.nodeGenesDifference(og)
+	}
 	return compatibility
 }
+
+// nodeGenesDifference Returns the average difference of the bias and response genes among the nodes with the same ID
+// present in both genomes. Returns zero if genomes have no matching nodes.
+func (g *Genome) nodeGenesDifference(og *Genome) float64 {
+	nodes := make(map[int]*network.NNode, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.Id] = n
+	}
+	diffTotal, numMatching := 0.0, 0
+	for _, on := range og.Nodes {
+		if n, ok := nodes[on.Id]; ok {
+			diffTotal += nodeGenesDistance(n, on)
+			numMatching++
+		}
+	}
+	if numMatching == 0 {
+		return 0
+	}
+	return diffTotal / float64(numMatching)
+}
+
+// nodeGenesDistance Returns the sum of absolute differences of the bias and response genes of two nodes
+func nodeGenesDistance(n1, n2 *network.NNode) float64 {
+	return math.Abs(n1.Bias-n2.Bias) + math.Abs(n1.ActivationResponse()-n2.ActivationResponse())
+}
@@ -74,6 +74,30 @@ func TestGenome_Compatibility_Fast(t *testing.T) {
 	assert.Equal(t, 2.0, comp)
 }
 
+func TestGenome_Compatibility_NodeGenes(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	gnome2 := buildTestGenome(2)
+	gnome2.Nodes[3].Bias = 1.0
+	gnome2.Nodes[3].SetResponse(3.0)
+
+	for _, method := range []neat.GenomeCompatibilityMethod{
+		neat.GenomeCompatibilityMethodLinear, neat.GenomeCompatibilityMethodFast} {
+		conf := neat.Options{
+			DisjointCoeff:   0.5,
+			ExcessCoeff:     0.5,
+			MutdiffCoeff:    0.5,
+			GenCompatMethod: method,
+		}
+		// node genes are ignored without coefficient
+		assert.Equal(t, 0.0, gnome1.compatibility(gnome2, &conf), "method: %s", method)
+
+		// (|1 - 0| + |3 - 1|) / 4 matching nodes
+		conf.NodeDiffCoeff = 2.0
+		assert.Equal(t, 1.5, gnome1.compatibility(gnome2, &conf), "method: %s", method)
+		assert.Equal(t, 1.5, DiffGenomes(gnome1, gnome2).Compatibility(&conf), "method: %s", method)
+	}
+}
+
 func TestGenome_Compatibility_Duplicate(t *testing.T) {
 	//rand.Seed(42)
 	gnome1 := buildTestGenome(1)
@@ -0,0 +1,500 @@
+package genetics
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"io"
+	gomath "math"
+	"sort"
+	"strings"
+)
+
+// GeneAlignmentKind defines how particular gene is aligned between two compared genomes
+type GeneAlignmentKind string
+
+const (
+	// GeneMatching the gene with the same innovation number is present in both genomes
+	GeneMatching GeneAlignmentKind = "matching"
+	// GeneDisjoint the gene is present only in one genome and its innovation number is within the innovation range
+	// of the other genome
+	GeneDisjoint GeneAlignmentKind = "disjoint"
+	// GeneExcess the gene is present only in one genome and its innovation number is beyond the innovation range of
+	// the other genome
+	GeneExcess GeneAlignmentKind = "excess"
+)
+
+// GeneSummary is the flat description of the connection gene used in the genomes difference report.
+type GeneSummary struct {
+	InNodeId    int     `json:"in_node_id"`
+	OutNodeId   int     `json:"out_node_id"`
+	Weight      float64 `json:"weight"`
+	MutationNum float64 `json:"mutation_num"`
+	Enabled     bool    `json:"enabled"`
+	Recurrent   bool    `json:"recurrent"`
+	Delay       int     `json:"delay,omitempty"`
+	TraitId     int     `json:"trait_id"`
+}
+
+// GeneDiff holds alignment of the connection gene with particular innovation number between two genomes.
+type GeneDiff struct {
+	// The innovation number of aligned gene
+	InnovationNum int64 `json:"innovation_num"`
+	// The kind of alignment
+	Kind GeneAlignmentKind `json:"kind"`
+	// The gene from the first genome or nil if absent
+	First *GeneSummary `json:"first,omitempty"`
+	// The gene from the second genome or nil if absent
+	Second *GeneSummary `json:"second,omitempty"`
+	// The difference of connection weights (second - first) for matching genes
+	WeightDelta float64 `json:"weight_delta"`
+	// The absolute difference of mutation numbers for matching genes, as used by compatibility formula
+	MutationNumDelta float64 `json:"mutation_num_delta"`
+}
+
+// NodeSummary is the flat description of the network node used in the genomes difference report.
+type NodeSummary struct {
+	NeuronType     string  `json:"neuron_type"`
+	ActivationType string  `json:"activation_type"`
+	TraitId        int     `json:"trait_id"`
+	Bias           float64 `json:"bias"`
+	Response       float64 `json:"response"`
+}
+
+// NodeDiff holds differences of the network node with particular ID between two genomes.
+type NodeDiff struct {
+	// The ID of the node
+	Id int `json:"id"`
+	// The node from the first genome or nil if absent
+	First *NodeSummary `json:"first,omitempty"`
+	// The node from the second genome or nil if absent
+	Second *NodeSummary `json:"second,omitempty"`
+}
+
+// TraitDiff holds differences of the trait with particular ID between two genomes.
+type TraitDiff struct {
+	// The ID of the trait
+	Id int `json:"id"`
+	// The parameters of the trait from the first genome or nil if absent
+	First []float64 `json:"first,omitempty"`
+	// The parameters of the trait from the second genome or nil if absent
+	Second []float64 `json:"second,omitempty"`
+	// The per parameter difference (second - first) if trait present in both genomes
+	ParamsDelta []float64 `json:"params_delta,omitempty"`
+}
+
+// ModuleSummary is the flat description of the MIMO control gene used in the genomes difference report.
+type ModuleSummary struct {
+	ControlNodeId  int     `json:"control_node_id"`
+	ActivationType string  `json:"activation_type"`
+	InputNodeIds   []int   `json:"input_node_ids"`
+	OutputNodeIds  []int   `json:"output_node_ids"`
+	MutationNum    float64 `json:"mutation_num"`
+	Enabled        bool    `json:"enabled"`
+}
+
+// ModuleDiff holds alignment of the MIMO control gene with particular innovation number between two genomes.
+type ModuleDiff struct {
+	// The innovation number of aligned control gene
+	InnovationNum int64 `json:"innovation_num"`
+	// The kind of alignment
+	Kind GeneAlignmentKind `json:"kind"`
+	// The control gene from the first genome or nil if absent
+	First *ModuleSummary `json:"first,omitempty"`
+	// The control gene from the second genome or nil if absent
+	Second *ModuleSummary `json:"second,omitempty"`
+}
+
+// GenomeDiff is the report about alignment of two genomes. It holds all matching, disjoint and excess genes ordered
+// by innovation number as well as differences in nodes, traits and MIMO modules. It can be used to understand why two
+// organisms were or were not placed into the same species.
+type GenomeDiff struct {
+	// The ID of the first genome
+	FirstId int `json:"first_id"`
+	// The ID of the second genome
+	SecondId int `json:"second_id"`
+
+	// The aligned connection genes ordered by innovation number
+	Genes []*GeneDiff `json:"genes"`
+	// The nodes which are absent in one of genomes or differ
+	Nodes []*NodeDiff `json:"nodes"`
+	// The traits which are absent in one of genomes or differ
+	Traits []*TraitDiff `json:"traits"`
+	// The aligned MIMO control genes ordered by innovation number
+	Modules []*ModuleDiff `json:"modules"`
+
+	// The number of matching connection genes
+	NumMatching int `json:"num_matching"`
+	// The number of disjoint connection genes
+	NumDisjoint int `json:"num_disjoint"`
+	// The number of excess connection genes
+	NumExcess int `json:"num_excess"`
+	// The total mutation number difference among matching connection genes
+	MutationDiffTotal float64 `json:"mutation_diff_total"`
+	// The number of nodes present in both genomes
+	NumMatchingNodes int `json:"num_matching_nodes"`
+	// The total difference of the bias and response genes among the nodes present in both genomes
+	NodeDiffTotal float64 `json:"node_diff_total"`
+}
+
+// DiffGenomes aligns connection genes of provided genomes by innovation numbers and collects differences between
+// them including nodes, traits and MIMO control genes. The alignment of connection genes is the same as used by
+// the genomes compatibility methods.
+func DiffGenomes(a, b *Genome) *GenomeDiff {
+	diff := &GenomeDiff{
+		FirstId:  a.Id,
+		SecondId: b.Id,
+		Genes:    make([]*GeneDiff, 0),
+		Nodes:    make([]*NodeDiff, 0),
+		Traits:   make([]*TraitDiff, 0),
+		Modules:  make([]*ModuleDiff, 0),
+	}
+	diff.alignGenes(a.Genes, b.Genes)
+	diff.compareNodes(a.Nodes, b.Nodes, a.Activators(), b.Activators())
+	diff.compareTraits(a.Traits, b.Traits)
+	diff.alignModules(a.ControlGenes, b.ControlGenes, a.Activators(), b.Activators())
+	return diff
+}
+
+// Compatibility returns compatibility distance between compared genomes calculated from this alignment with provided
+// coefficients. The formula is the same as the one used by the genome compatibility methods:
+// disjoint_coeff * pdg + excess_coeff * peg + mutdiff_coeff * mdmg + node_diff_coeff * mdmn
+func (d *GenomeDiff) Compatibility(opts *neat.Options) float64 {
+	comp := opts.DisjointCoeff*float64(d.NumDisjoint) + opts.ExcessCoeff*float64(d.NumExcess)
+	if d.NumMatching > 0 {
+		comp += opts.MutdiffCoeff * d.MutationDiffTotal / float64(d.NumMatching)
+	}
+	if d.NumMatchingNodes > 0 {
+		comp += opts.NodeDiffCoeff * d.NodeDiffTotal / float64(d.NumMatchingNodes)
+	}
+	return comp
+}
+
+// IsCompatible returns true if compared genomes are compatible enough to be placed in the same species according to
+// the compatibility threshold of provided options.
+func (d *GenomeDiff) IsCompatible(opts *neat.Options) bool {
+	return d.Compatibility(opts) < opts.CompatThreshold
+}
+
+// WriteJSON writes machine-readable report of this difference encoded as JSON into provided writer.
+func (d *GenomeDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// WriteReport writes human-readable report of this difference into provided writer. If opts is not nil, the
+// compatibility distance will be included into report.
+func (d *GenomeDiff) WriteReport(w io.Writer, opts *neat.Options) error {
+	_, err := fmt.Fprint(w, d.report(opts))
+	return err
+}
+
+// The stringer
+func (d *GenomeDiff) String() string {
+	return d.report(nil)
+}
+
+func (d *GenomeDiff) report(opts *neat.Options) string {
+	b := strings.Builder{}
+	_, _ = fmt.Fprintf(&b, "GENOMES DIFF %d <-> %d\n", d.FirstId, d.SecondId)
+	_, _ = fmt.Fprintf(&b, "Genes: matching: %d, disjoint: %d, excess: %d, mutation diff total: %.3f\n",
+		d.NumMatching, d.NumDisjoint, d.NumExcess, d.MutationDiffTotal)
+	_, _ = fmt.Fprintf(&b, "Nodes: matching: %d, bias and response diff total: %.3f\n",
+		d.NumMatchingNodes, d.NodeDiffTotal)
+	if opts != nil {
+		_, _ = fmt.Fprintf(&b, "Compatibility: %.3f, threshold: %.3f, compatible: %t\n",
+			d.Compatibility(opts), opts.CompatThreshold, d.IsCompatible(opts))
+	}
+	for _, gd := range d.Genes {
+		_, _ = fmt.Fprintf(&b, "\t%-8s INNOV %4d %s | %s", gd.Kind, gd.InnovationNum,
+			gd.First.String(), gd.Second.String())
+		if gd.Kind == GeneMatching {
+			_, _ = fmt.Fprintf(&b, " weight delta: %.3f, mutation delta: %.3f", gd.WeightDelta, gd.MutationNumDelta)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("Nodes:\n")
+	for _, nd := range d.Nodes {
+		_, _ = fmt.Fprintf(&b, "\tNODE %4d %s | %s\n", nd.Id, nd.First.String(), nd.Second.String())
+	}
+	b.WriteString("Traits:\n")
+	for _, td := range d.Traits {
+		_, _ = fmt.Fprintf(&b, "\tTRAIT %4d %v | %v delta: %v\n", td.Id, td.First, td.Second, td.ParamsDelta)
+	}
+	b.WriteString("Modules:\n")
+	for _, md := range d.Modules {
+		_, _ = fmt.Fprintf(&b, "\t%-8s INNOV %4d %s | %s\n", md.Kind, md.InnovationNum,
+			md.First.String(), md.Second.String())
+	}
+	b.WriteString("GENOMES DIFF END\n")
+	return b.String()
+}
+
+func (s *GeneSummary) String() string {
+	if s == nil {
+		return "[ absent ]"
+	}
+	enabledStr := ""
+	if !s.Enabled {
+		enabledStr = " -DISABLED-"
+	}
+	recurrentStr := ""
+	if s.Recurrent {
+		recurrentStr = " -RECUR-"
+	}
+	delayStr := ""
+	if s.Delay > 0 {
+		delayStr = fmt.Sprintf(" -DELAY %d-", s.Delay)
+	}
+	return fmt.Sprintf("[%4d ->%4d weight: %.3f mut: %.3f%s%s%s]",
+		s.InNodeId, s.OutNodeId, s.Weight, s.MutationNum, enabledStr, recurrentStr, delayStr)
+}
+
+func (s *NodeSummary) String() string {
+	if s == nil {
+		return "[ absent ]"
+	}
+	return fmt.Sprintf("[%s %s trait: %d bias: %.3f response: %.3f]", s.NeuronType, s.ActivationType, s.TraitId,
+		s.Bias, s.Response)
+}
+
+func (s *ModuleSummary) String() string {
+	if s == nil {
+		return "[ absent ]"
+	}
+	enabledStr := ""
+	if !s.Enabled {
+		enabledStr = " -DISABLED-"
+	}
+	return fmt.Sprintf("[control node: %d %s inputs: %v outputs: %v mut: %.3f%s]",
+		s.ControlNodeId, s.ActivationType, s.InputNodeIds, s.OutputNodeIds, s.MutationNum, enabledStr)
+}
+
+// Aligns connection genes of two genomes assuming that both lists are ordered by innovation number ascending.
+func (d *GenomeDiff) alignGenes(genes1, genes2 []*Gene) {
+	size1, size2 := len(genes1), len(genes2)
+	for i1, i2 := 0, 0; i1 < size1 || i2 < size2; {
+		var gd *GeneDiff
+		if i1 >= size1 {
+			gd = &GeneDiff{InnovationNum: genes2[i2].InnovationNum, Kind: GeneExcess, Second: geneSummary(genes2[i2])}
+			i2++
+		} else if i2 >= size2 {
+			gd = &GeneDiff{InnovationNum: genes1[i1].InnovationNum, Kind: GeneExcess, First: geneSummary(genes1[i1])}
+			i1++
+		} else {
+			gene1, gene2 := genes1[i1], genes2[i2]
+			if gene1.InnovationNum == gene2.InnovationNum {
+				gd = &GeneDiff{
+					InnovationNum:    gene1.InnovationNum,
+					Kind:             GeneMatching,
+					First:            geneSummary(gene1),
+					Second:           geneSummary(gene2),
+					WeightDelta:      gene2.Link.ConnectionWeight - gene1.Link.ConnectionWeight,
+					MutationNumDelta: gomath.Abs(gene1.MutationNum - gene2.MutationNum),
+				}
+				i1++
+				i2++
+			} else if gene1.InnovationNum < gene2.InnovationNum {
+				gd = &GeneDiff{InnovationNum: gene1.InnovationNum, Kind: GeneDisjoint, First: geneSummary(gene1)}
+				i1++
+			} else {
+				gd = &GeneDiff{InnovationNum: gene2.InnovationNum, Kind: GeneDisjoint, Second: geneSummary(gene2)}
+				i2++
+			}
+		}
+		switch gd.Kind {
+		case GeneMatching:
+			d.NumMatching++
+			d.MutationDiffTotal += gd.MutationNumDelta
+		case GeneDisjoint:
+			d.NumDisjoint++
+		case GeneExcess:
+			d.NumExcess++
+		}
+		d.Genes = append(d.Genes, gd)
+	}
+}
+
+// Collects nodes which are present only in one of genomes or differ by type, activation or trait.
+func (d *GenomeDiff) compareNodes(nodes1, nodes2 []*network.NNode, activators1, activators2 *math.NodeActivatorsFactory) {
+	byId1, byId2 := make(map[int]*network.NNode), make(map[int]*network.NNode)
+	ids1, ids2 := make([]int, len(nodes1)), make([]int, len(nodes2))
+	for i, n := range nodes1 {
+		byId1[n.Id] = n
+		ids1[i] = n.Id
+	}
+	for i, n := range nodes2 {
+		byId2[n.Id] = n
+		ids2[i] = n.Id
+	}
+	for _, id := range sortedIdsUnion(ids1, ids2) {
+		n1, n2 := byId1[id], byId2[id]
+		if n1 != nil && n2 != nil {
+			d.NumMatchingNodes++
+			d.NodeDiffTotal += nodeGenesDistance(n1, n2)
+		}
+		s1, s2 := nodeSummary(n1, activators1), nodeSummary(n2, activators2)
+		if s1 != nil && s2 != nil && *s1 == *s2 {
+			continue
+		}
+		d.Nodes = append(d.Nodes, &NodeDiff{Id: id, First: s1, Second: s2})
+	}
+}
+
+// Collects traits which are present only in one of genomes or have different parameters.
+func (d *GenomeDiff) compareTraits(traits1, traits2 []*neat.Trait) {
+	byId1, byId2 := make(map[int]*neat.Trait), make(map[int]*neat.Trait)
+	ids1, ids2 := make([]int, len(traits1)), make([]int, len(traits2))
+	for i, t := range traits1 {
+		byId1[t.Id] = t
+		ids1[i] = t.Id
+	}
+	for i, t := range traits2 {
+		byId2[t.Id] = t
+		ids2[i] = t.Id
+	}
+	for _, id := range sortedIdsUnion(ids1, ids2) {
+		t1, t2 := byId1[id], byId2[id]
+		td := &TraitDiff{Id: id}
+		if t1 != nil {
+			td.First = t1.Params
+		}
+		if t2 != nil {
+			td.Second = t2.Params
+		}
+		if t1 != nil && t2 != nil {
+			if len(t1.Params) == len(t2.Params) {
+				changed := false
+				td.ParamsDelta = make([]float64, len(t1.Params))
+				for i := range t1.Params {
+					td.ParamsDelta[i] = t2.Params[i] - t1.Params[i]
+					changed = changed || td.ParamsDelta[i] != 0
+				}
+				if !changed {
+					continue
+				}
+			}
+		}
+		d.Traits = append(d.Traits, td)
+	}
+}
+
+// Aligns MIMO control genes of two genomes by innovation number.
+func (d *GenomeDiff) alignModules(genes1, genes2 []*MIMOControlGene, activators1, activators2 *math.NodeActivatorsFactory) {
+	byInnov1, byInnov2 := make(map[int64]*MIMOControlGene), make(map[int64]*MIMOControlGene)
+	maxInnov1, maxInnov2 := int64(-1), int64(-1)
+	for _, cg := range genes1 {
+		byInnov1[cg.InnovationNum] = cg
+		if cg.InnovationNum > maxInnov1 {
+			maxInnov1 = cg.InnovationNum
+		}
+	}
+	for _, cg := range genes2 {
+		byInnov2[cg.InnovationNum] = cg
+		if cg.InnovationNum > maxInnov2 {
+			maxInnov2 = cg.InnovationNum
+		}
+	}
+	innovs := make([]int64, 0, len(byInnov1)+len(byInnov2))
+	for innov := range byInnov1 {
+		innovs = append(innovs, innov)
+	}
+	for innov := range byInnov2 {
+		if _, ok := byInnov1[innov]; !ok {
+			innovs = append(innovs, innov)
+		}
+	}
+	sort.Slice(innovs, func(i, j int) bool { return innovs[i] < innovs[j] })
+
+	for _, innov := range innovs {
+		cg1, cg2 := byInnov1[innov], byInnov2[innov]
+		md := &ModuleDiff{InnovationNum: innov, First: moduleSummary(cg1, activators1),
+			Second: moduleSummary(cg2, activators2)}
+		if cg1 != nil && cg2 != nil {
+			md.Kind = GeneMatching
+		} else if (cg1 != nil && innov > maxInnov2) || (cg2 != nil && innov > maxInnov1) {
+			md.Kind = GeneExcess
+		} else {
+			md.Kind = GeneDisjoint
+		}
+		d.Modules = append(d.Modules, md)
+	}
+}
+
+func geneSummary(g *Gene) *GeneSummary {
+	s := &GeneSummary{
+		InNodeId:    g.Link.InNode.Id,
+		OutNodeId:   g.Link.OutNode.Id,
+		Weight:      g.Link.ConnectionWeight,
+		MutationNum: g.MutationNum,
+		Enabled:     g.IsEnabled,
+		Recurrent:   g.Link.IsRecurrent,
+		Delay:       g.Link.Delay,
+	}
+	if g.Link.Trait != nil {
+		s.TraitId = g.Link.Trait.Id
+	}
+	return s
+}
+
+func nodeSummary(n *network.NNode, activators *math.NodeActivatorsFactory) *NodeSummary {
+	if n == nil {
+		return nil
+	}
+	actName, err := activators.ActivationNameFromType(n.ActivationType)
+	if err != nil {
+		actName = "unknown"
+	}
+	s := &NodeSummary{
+		NeuronType:     network.NeuronTypeName(n.NeuronType),
+		ActivationType: actName,
+		Bias:           n.Bias,
+		Response:       n.ActivationResponse(),
+	}
+	if n.Trait != nil {
+		s.TraitId = n.Trait.Id
+	}
+	return s
+}
+
+func moduleSummary(cg *MIMOControlGene, activators *math.NodeActivatorsFactory) *ModuleSummary {
+	if cg == nil {
+		return nil
+	}
+	actName, err := activators.ActivationNameFromType(cg.ControlNode.ActivationType)
+	if err != nil {
+		actName = "unknown"
+	}
+	s := &ModuleSummary{
+		ControlNodeId:  cg.ControlNode.Id,
+		ActivationType: actName,
+		InputNodeIds:   make([]int, len(cg.ControlNode.Incoming)),
+		OutputNodeIds:  make([]int, len(cg.ControlNode.Outgoing)),
+		MutationNum:    cg.MutationNum,
+		Enabled:        cg.IsEnabled,
+	}
+	for i, l := range cg.ControlNode.Incoming {
+		s.InputNodeIds[i] = l.InNode.Id
+	}
+	for i, l := range cg.ControlNode.Outgoing {
+		s.OutputNodeIds[i] = l.OutNode.Id
+	}
+	return s
+}
+
+// Returns sorted union of provided IDs lists without duplicates
+func sortedIdsUnion(ids1, ids2 []int) []int {
+	seen := make(map[int]bool, len(ids1)+len(ids2))
+	ids := make([]int, 0, len(ids1)+len(ids2))
+	for _, id := range append(append([]int{}, ids1...), ids2...) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
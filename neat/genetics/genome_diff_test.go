@@ -0,0 +1,152 @@
+package genetics
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"testing"
+)
+
+func TestDiffGenomes_Identical(t *testing.T) {
+	gnome1 := buildTestModularGenome(1)
+	gnome2 := buildTestModularGenome(2)
+
+	diff := DiffGenomes(gnome1, gnome2)
+	require.NotNil(t, diff)
+	assert.Equal(t, 1, diff.FirstId)
+	assert.Equal(t, 2, diff.SecondId)
+	assert.Equal(t, len(gnome1.Genes), diff.NumMatching)
+	assert.Zero(t, diff.NumDisjoint)
+	assert.Zero(t, diff.NumExcess)
+	assert.Len(t, diff.Genes, len(gnome1.Genes))
+	assert.Empty(t, diff.Nodes)
+	assert.Empty(t, diff.Traits)
+	require.Len(t, diff.Modules, 1)
+	assert.Equal(t, GeneMatching, diff.Modules[0].Kind)
+
+	opts := &neat.Options{DisjointCoeff: 1.0, ExcessCoeff: 1.0, MutdiffCoeff: 0.4, CompatThreshold: 3.0}
+	assert.Equal(t, 0.0, diff.Compatibility(opts))
+	assert.True(t, diff.IsCompatible(opts))
+}
+
+func TestDiffGenomes_Alignment(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	gnome2 := buildTestGenome(2)
+
+	// make disjoint gene in the first genome and excess genes in the second genome
+	gnome1.Genes = append(gnome1.Genes, NewGene(1.0, gnome1.Nodes[0], gnome1.Nodes[3], true, 4, 1.0))
+	gnome2.Genes = append(gnome2.Genes, NewGene(2.0, gnome2.Nodes[1], gnome2.Nodes[3], true, 5, 2.0))
+	gnome2.Genes = append(gnome2.Genes, NewGene(3.0, gnome2.Nodes[2], gnome2.Nodes[3], true, 6, 3.0))
+	// change matching gene
+	gnome2.Genes[1].Link.ConnectionWeight = 4.0
+	gnome2.Genes[1].MutationNum = 3.0
+
+	diff := DiffGenomes(gnome1, gnome2)
+	assert.Equal(t, 3, diff.NumMatching)
+	assert.Equal(t, 1, diff.NumDisjoint)
+	assert.Equal(t, 2, diff.NumExcess)
+	assert.Equal(t, 3.0, diff.MutationDiffTotal)
+
+	expected := []struct {
+		innov int64
+		kind  GeneAlignmentKind
+		first bool
+	}{
+		{1, GeneMatching, true},
+		{2, GeneMatching, true},
+		{3, GeneMatching, true},
+		{4, GeneDisjoint, true},
+		{5, GeneExcess, false},
+		{6, GeneExcess, false},
+	}
+	require.Len(t, diff.Genes, len(expected))
+	for i, e := range expected {
+		gd := diff.Genes[i]
+		assert.Equal(t, e.innov, gd.InnovationNum, "wrong innovation at: %d", i)
+		assert.Equal(t, e.kind, gd.Kind, "wrong kind at: %d", i)
+		assert.Equal(t, e.first, gd.First != nil, "wrong first at: %d", i)
+	}
+	assert.Equal(t, 1.5, diff.Genes[1].WeightDelta)
+	assert.Equal(t, 3.0, diff.Genes[1].MutationNumDelta)
+
+	// check that compatibility is consistent with fast method
+	opts := &neat.Options{DisjointCoeff: 0.5, ExcessCoeff: 0.7, MutdiffCoeff: 0.3, CompatThreshold: 1.0,
+		GenCompatMethod: neat.GenomeCompatibilityMethodFast}
+	assert.InDelta(t, gnome1.compatibility(gnome2, opts), diff.Compatibility(opts), 1e-9)
+	assert.False(t, diff.IsCompatible(opts))
+}
+
+func TestDiffGenomes_NodesTraitsModules(t *testing.T) {
+	gnome1 := buildTestModularGenome(1)
+	gnome2 := buildTestGenome(2)
+
+	gnome2.Nodes[3].ActivationType = math.TanhActivation
+	gnome2.Traits[0].Params[0] = 0.5
+	gnome2.Traits = append(gnome2.Traits, &neat.Trait{Id: 4, Params: []float64{0.4, 0, 0, 0, 0, 0, 0, 0}})
+
+	diff := DiffGenomes(gnome1, gnome2)
+
+	// nodes: 4 changed activation, 5, 6, 7 - only in the first genome
+	require.Len(t, diff.Nodes, 4)
+	assert.Equal(t, 4, diff.Nodes[0].Id)
+	assert.Equal(t, "TanhActivation", diff.Nodes[0].Second.ActivationType)
+	for _, nd := range diff.Nodes[1:] {
+		assert.NotNil(t, nd.First)
+		assert.Nil(t, nd.Second)
+	}
+
+	// traits: 1 changed, 4 only in the second genome
+	require.Len(t, diff.Traits, 2)
+	assert.Equal(t, 1, diff.Traits[0].Id)
+	assert.InDelta(t, 0.4, diff.Traits[0].ParamsDelta[0], 1e-9)
+	assert.Equal(t, 4, diff.Traits[1].Id)
+	assert.Nil(t, diff.Traits[1].First)
+
+	// modules: only in the first genome
+	require.Len(t, diff.Modules, 1)
+	assert.Equal(t, GeneExcess, diff.Modules[0].Kind)
+	require.NotNil(t, diff.Modules[0].First)
+	assert.Equal(t, 8, diff.Modules[0].First.ControlNodeId)
+	assert.Equal(t, []int{5, 6}, diff.Modules[0].First.InputNodeIds)
+	assert.Equal(t, []int{7}, diff.Modules[0].First.OutputNodeIds)
+}
+
+func TestGenomeDiff_WriteJSON(t *testing.T) {
+	gnome1 := buildTestModularGenome(1)
+	gnome2 := buildTestGenome(2)
+	gnome2.Genes = append(gnome2.Genes, NewGene(2.0, network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(4, network.OutputNeuron), false, 10, 1.0))
+
+	diff := DiffGenomes(gnome1, gnome2)
+	b := bytes.NewBufferString("")
+	err := diff.WriteJSON(b)
+	require.NoError(t, err)
+
+	var decoded GenomeDiff
+	err = json.Unmarshal(b.Bytes(), &decoded)
+	require.NoError(t, err)
+	assert.EqualValues(t, *diff, decoded)
+}
+
+func TestGenomeDiff_WriteReport(t *testing.T) {
+	gnome1 := buildTestModularGenome(1)
+	gnome2 := buildTestGenome(2)
+
+	diff := DiffGenomes(gnome1, gnome2)
+	opts := &neat.Options{DisjointCoeff: 1.0, ExcessCoeff: 1.0, MutdiffCoeff: 0.4, CompatThreshold: 3.0}
+	b := bytes.NewBufferString("")
+	err := diff.WriteReport(b, opts)
+	require.NoError(t, err)
+	report := b.String()
+	assert.Contains(t, report, "GENOMES DIFF 1 <-> 2")
+	assert.Contains(t, report, "matching: 3, disjoint: 0, excess: 3")
+	assert.Contains(t, report, "Compatibility: 3.000, threshold: 3.000, compatible: false")
+
+	errWriter := ErrorWriter(1)
+	err = diff.WriteReport(&errWriter, nil)
+	assert.EqualError(t, err, alwaysErrorText)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/yaricom/goNEAT/v3/neat"
 	"github.com/yaricom/goNEAT/v3/neat/math"
 	"github.com/yaricom/goNEAT/v3/neat/network"
+	gomath "math"
 	"math/rand"
 )
 
@@ -371,15 +372,19 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 		so we make it match the original, identical mutation which occurred
 		elsewhere in the population by coincidence */
 		if inn.innovationType == newNodeInnType &&
+			inn.NewNeuronType != network.ModulatoryNeuron &&
 			inn.InNodeId == inNode.Id &&
 			inn.OutNodeId == outNode.Id &&
 			inn.OldInnovNum == gene.InnovationNum {
 
 			// Create the new NNode
-			node = network.NewNNode(inn.NewNodeId, network.HiddenNeuron)
+			node = network.NewNNode(inn.NewNodeId, inn.NewNeuronType)
 			// By convention, it will point to the first trait
 			// Note: In future may want to change this
 			node.Trait = g.Traits[0]
+			if node.NeuronType == network.MemoryNeuron {
+				node.GateWeights = newMemoryCellGateWeights()
+			}
 
 			// Create the new Genes
 			gene1 = NewGeneWithTrait(trait, 1.0, inNode, node, link.IsRecurrent, inn.InnovationNum, 0)
@@ -404,6 +409,12 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 		} else {
 			node.ActivationType = activationType
 		}
+		// The new node can be the gated memory cell, the random draw is skipped when memory cells are disabled to
+		// preserve random sequence of the existing experiments
+		if opts.MutateAddMemoryNodeProb > 0 && rand.Float64() < opts.MutateAddMemoryNodeProb {
+			node.NeuronType = network.MemoryNeuron
+			node.GateWeights = newMemoryCellGateWeights()
+		}
 
 		// get the next innovation id for gene 1
 		gene1Innovation := innovations.NextInnovationNumber()
@@ -417,6 +428,7 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 
 		// Store innovation
 		innovation := NewInnovationForNode(inNode.Id, outNode.Id, gene1Innovation, gene2Innovation, node.Id, gene.InnovationNum)
+		innovation.NewNeuronType = node.NeuronType
 		innovations.StoreInnovation(*innovation)
 	} else if node != nil && g.hasNode(node) {
 		// The same add node innovation occurred in the same genome (parent) - just skip.
@@ -442,6 +454,95 @@ func (g *Genome) mutateAddNode(innovations InnovationsObserver, nodeIdGenerator
 	return false, nil
 }
 
+// This mutator adds a modulatory neuron to a Genome alongside an existing link between two nodes. The new neuron receives
+// the signal from the source node of the link and modulates the plasticity of the incoming links of the target node of
+// the link. In contrast to the add node mutation, the chosen link is kept enabled. The innovations list from population
+// is used to assign the same innovation numbers to the new genes if the same mutation already occurred.
+func (g *Genome) mutateAddModulatoryNode(innovations InnovationsObserver, nodeIdGenerator network.NodeIdGenerator, opts *neat.Options) (bool, error) {
+	// Find the enabled genes which can be modulated
+	candidates := make([]*Gene, 0, len(g.Genes))
+	for _, gn := range g.Genes {
+		inType := gn.Link.InNode.NeuronType
+		if gn.IsEnabled && inType != network.BiasNeuron && inType != network.ModulatoryNeuron {
+			candidates = append(candidates, gn)
+		}
+	}
+	if len(candidates) == 0 {
+		return false, nil
+	}
+	gene := candidates[rand.Intn(len(candidates))]
+
+	// Extract the link, its trait and nodes
+	link := gene.Link
+	trait := link.Trait
+	inNode, outNode := link.InNode, link.OutNode
+	if inNode == nil || outNode == nil {
+		return false, fmt.Errorf("mutateAddModulatoryNode: Anomalous link found with either IN or OUT node not set. %s", link)
+	}
+
+	var gene1, gene2 *Gene
+	var node *network.NNode
+
+	// Check to see if this innovation already occurred in the population
+	innovationFound := false
+	for _, inn := range innovations.Innovations() {
+		if inn.innovationType == newNodeInnType &&
+			inn.NewNeuronType == network.ModulatoryNeuron &&
+			inn.InNodeId == inNode.Id &&
+			inn.OutNodeId == outNode.Id &&
+			inn.OldInnovNum == gene.InnovationNum {
+
+			// Create the new NNode
+			node = network.NewNNode(inn.NewNodeId, network.ModulatoryNeuron)
+			// By convention, it will point to the first trait
+			node.Trait = g.Traits[0]
+
+			// Create the new Genes
+			gene1 = NewGeneWithTrait(trait, 1.0, inNode, node, link.IsRecurrent, inn.InnovationNum, 0)
+			gene2 = NewGeneWithTrait(trait, inn.NewWeight, node, outNode, false, inn.InnovationNum2, 0)
+
+			innovationFound = true
+			break
+		}
+	}
+	// The innovation is totally novel
+	if !innovationFound {
+		// Create the new NNode with random activation function
+		node = network.NewNNode(nodeIdGenerator.NextNodeId(), network.ModulatoryNeuron)
+		node.Trait = g.Traits[0]
+		if activationType, err := opts.RandomNodeActivationType(); err != nil {
+			return false, err
+		} else {
+			node.ActivationType = activationType
+		}
+
+		// Create the new genes, the modulatory link gets the random weight
+		modulationWeight := float64(math.RandSign()) * rand.Float64()
+		gene1Innovation := innovations.NextInnovationNumber()
+		gene1 = NewGeneWithTrait(trait, 1.0, inNode, node, link.IsRecurrent, gene1Innovation, 0)
+		gene2Innovation := innovations.NextInnovationNumber()
+		gene2 = NewGeneWithTrait(trait, modulationWeight, node, outNode, false, gene2Innovation, 0)
+
+		// Store innovation
+		innovation := NewInnovationForNode(inNode.Id, outNode.Id, gene1Innovation, gene2Innovation, node.Id, gene.InnovationNum)
+		innovation.NewNeuronType = network.ModulatoryNeuron
+		innovation.NewWeight = modulationWeight
+		innovations.StoreInnovation(*innovation)
+	} else if g.hasNode(node) {
+		// The same innovation already occurred in this genome (parent) - just skip, see mutateAddNode
+		neat.InfoLog(
+			fmt.Sprintf("GENOME: Add modulatory node innovation found in the same genome [%d] for node [%d]\n%s",
+				g.Id, node.Id, g))
+		return false, nil
+	}
+
+	// Now add the new NNode and new Genes to the Genome
+	g.Genes = geneInsert(g.Genes, gene1)
+	g.Genes = geneInsert(g.Genes, gene2)
+	g.Nodes = nodeInsert(g.Nodes, node)
+	return true, nil
+}
+
 // Adds Gaussian noise to link weights either GAUSSIAN or COLD_GAUSSIAN (from zero).
 // The COLD_GAUSSIAN means ALL connection weights will be given completely new values
 func (g *Genome) mutateLinkWeights(power, rate float64, mutationType mutatorType) (bool, error) {
@@ -556,6 +657,121 @@ func (g *Genome) mutateNodeTrait(times int) (bool, error) {
 	return true, nil
 }
 
+// This chooses a random neuron and perturbs its time constant by the log-normal multiplicative noise with given power,
+// which keeps the time constant positive
+func (g *Genome) mutateNodeTimeConstant(power float64) (bool, error) {
+	node, err := g.randomNeuron()
+	if err != nil {
+		return false, err
+	}
+	timeConstant := node.TimeConstant
+	if timeConstant <= 0 {
+		timeConstant = network.DefaultTimeConstant
+	}
+	node.TimeConstant = timeConstant * gomath.Exp(rand.NormFloat64()*power)
+	return true, nil
+}
+
+// This chooses a random neuron and perturbs its bias by the uniform noise with given power
+func (g *Genome) mutateNodeBias(power float64) (bool, error) {
+	node, err := g.randomNeuron()
+	if err != nil {
+		return false, err
+	}
+	node.Bias += float64(math.RandSign()) * rand.Float64() * power
+	return true, nil
+}
+
+// This chooses a random neuron and perturbs its response (gain) by the uniform noise with given power. The response
+// of neuron which was not set is perturbed starting from the network.DefaultNodeResponse.
+func (g *Genome) mutateNodeResponse(power float64) (bool, error) {
+	node, err := g.randomNeuron()
+	if err != nil {
+		return false, err
+	}
+	node.SetResponse(node.ActivationResponse() + float64(math.RandSign())*rand.Float64()*power)
+	return true, nil
+}
+
+// randomNeuron Returns the random neuron node of this genome or error if genome has no neurons
+func (g *Genome) randomNeuron() (*network.NNode, error) {
+	neurons := make([]*network.NNode, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		if node.IsNeuron() {
+			neurons = append(neurons, node)
+		}
+	}
+	if len(neurons) == 0 {
+		return nil, errors.New("genome has no neurons")
+	}
+	return neurons[rand.Intn(len(neurons))], nil
+}
+
+// This chooses a random enabled gene and increments or decrements the delay of its link by one step within
+// [0, maxDelay] range. The delay of recurrent link is not changed, because it already has implicit one step lag.
+// Returns false if maxDelay is not positive or genome has no enabled non-recurrent genes.
+func (g *Genome) mutateLinkDelay(maxDelay int) (bool, error) {
+	if len(g.Genes) == 0 {
+		return false, errors.New("genome has no genes")
+	}
+	if maxDelay <= 0 {
+		return false, nil
+	}
+	genes := make([]*Gene, 0, len(g.Genes))
+	for _, gene := range g.Genes {
+		if gene.IsEnabled && !gene.Link.IsRecurrent {
+			genes = append(genes, gene)
+		}
+	}
+	if len(genes) == 0 {
+		return false, nil
+	}
+	link := genes[rand.Intn(len(genes))].Link
+	delay := link.Delay + int(math.RandSign())
+	if delay < 0 || delay > maxDelay {
+		// reflect from the boundary of the range
+		delay = link.Delay - (delay - link.Delay)
+	}
+	if delay > maxDelay {
+		// the link delay was out of range, e.g., after MaxLinkDelay decrease
+		delay = maxDelay
+	}
+	link.Delay = delay
+	return true, nil
+}
+
+// This chooses a random memory cell neuron and perturbs its gate weights by the uniform noise with given power.
+// Returns false if genome has no memory cell neurons.
+func (g *Genome) mutateMemoryGates(power float64) (bool, error) {
+	memoryNodes := make([]*network.NNode, 0)
+	for _, node := range g.Nodes {
+		if node.NeuronType == network.MemoryNeuron {
+			memoryNodes = append(memoryNodes, node)
+		}
+	}
+	if len(memoryNodes) == 0 {
+		return false, nil
+	}
+	node := memoryNodes[rand.Intn(len(memoryNodes))]
+	if len(node.GateWeights) != network.MemoryCellGateWeightsCount {
+		return false, fmt.Errorf("memory cell neuron: %d has wrong number of gate weights: %d",
+			node.Id, len(node.GateWeights))
+	}
+	for i := range node.GateWeights {
+		node.GateWeights[i] += float64(math.RandSign()) * rand.Float64() * power
+	}
+	return true, nil
+}
+
+// newMemoryCellGateWeights Creates random gate weights for the new memory cell neuron
+func newMemoryCellGateWeights() []float64 {
+	weights := make([]float64, network.MemoryCellGateWeightsCount)
+	for i := range weights {
+		weights[i] = float64(math.RandSign()) * rand.Float64()
+	}
+	return weights
+}
+
 // Toggle genes from enable ON to enable OFF or vice versa. Do it specified number of times.
 func (g *Genome) mutateToggleEnable(times int) (bool, error) {
 	if len(g.Genes) == 0 {
@@ -596,37 +812,91 @@ func (g *Genome) mutateGeneReEnable() (bool, error) {
 }
 
 // Applies all non-structural mutations to this genome
-func (g *Genome) mutateAllNonstructural(context *neat.Options) (bool, error) {
+func (g *Genome) mutateAllNonstructural(context *neat.Options) ([]ReproductionOperator, error) {
+	applied := make([]ReproductionOperator, 0)
 	res := false
 	var err error
 	if rand.Float64() < context.MutateRandomTraitProb {
 		// mutate random trait
-		res, err = g.mutateRandomTrait(context)
+		if res, err = g.mutateRandomTrait(context); res {
+			applied = append(applied, MutateRandomTraitOperator)
+		}
 	}
 
 	if err == nil && rand.Float64() < context.MutateLinkTraitProb {
 		// mutate link trait
-		res, err = g.mutateLinkTrait(1)
+		if res, err = g.mutateLinkTrait(1); res {
+			applied = append(applied, MutateLinkTraitOperator)
+		}
 	}
 
 	if err == nil && rand.Float64() < context.MutateNodeTraitProb {
 		// mutate node trait
-		res, err = g.mutateNodeTrait(1)
+		if res, err = g.mutateNodeTrait(1); res {
+			applied = append(applied, MutateNodeTraitOperator)
+		}
 	}
 
 	if err == nil && rand.Float64() < context.MutateLinkWeightsProb {
 		// mutate link weight
-		res, err = g.mutateLinkWeights(context.WeightMutPower, 1.0, gaussianMutator)
+		if res, err = g.mutateLinkWeights(context.WeightMutPower, 1.0, gaussianMutator); res {
+			applied = append(applied, MutateLinkWeightsOperator)
+		}
 	}
 
 	if err == nil && rand.Float64() < context.MutateToggleEnableProb {
 		// mutate toggle enable
-		res, err = g.mutateToggleEnable(1)
+		if res, err = g.mutateToggleEnable(1); res {
+			applied = append(applied, MutateToggleEnableOperator)
+		}
 	}
 
 	if err == nil && rand.Float64() < context.MutateGeneReenableProb {
 		// mutate gene reenable
-		res, err = g.mutateGeneReEnable()
+		if res, err = g.mutateGeneReEnable(); res {
+			applied = append(applied, MutateGeneReEnableOperator)
+		}
+	}
+
+	// the random draw is skipped when the time constants are not evolved to preserve random sequence of the existing
+	// experiments
+	if err == nil && context.MutateNodeTimeConstantProb > 0 && rand.Float64() < context.MutateNodeTimeConstantProb {
+		// mutate node time constant
+		if res, err = g.mutateNodeTimeConstant(context.TimeConstantMutationPower); res {
+			applied = append(applied, MutateNodeTimeConstantOperator)
+		}
+	}
+
+	// the random draw is skipped when memory cells are disabled to preserve random sequence of the existing experiments
+	if err == nil && context.MutateMemoryGatesProb > 0 && rand.Float64() < context.MutateMemoryGatesProb {
+		// mutate memory cell gates
+		if res, err = g.mutateMemoryGates(context.WeightMutPower); res {
+			applied = append(applied, MutateMemoryGatesOperator)
+		}
+	}
+
+	// the random draws are skipped when the bias and response genes are not evolved to preserve random sequence of
+	// the existing experiments
+	if err == nil && context.MutateNodeBiasProb > 0 && rand.Float64() < context.MutateNodeBiasProb {
+		// mutate node bias
+		if res, err = g.mutateNodeBias(context.NodeBiasMutationPower); res {
+			applied = append(applied, MutateNodeBiasOperator)
+		}
+	}
+
+	if err == nil && context.MutateNodeResponseProb > 0 && rand.Float64() < context.MutateNodeResponseProb {
+		// mutate node response
+		if res, err = g.mutateNodeResponse(context.NodeResponseMutationPower); res {
+			applied = append(applied, MutateNodeResponseOperator)
+		}
+	}
+
+	// the random draw is skipped when the delays are disabled to preserve random sequence of the existing experiments
+	if err == nil && context.MutateLinkDelayProb > 0 && rand.Float64() < context.MutateLinkDelayProb {
+		// mutate link delay
+		if res, err = g.mutateLinkDelay(context.MaxLinkDelay); res {
+			applied = append(applied, MutateLinkDelayOperator)
+		}
 	}
-	return res, err
+	return applied, err
 }
@@ -138,6 +138,115 @@ func TestGenome_mutateAddNode(t *testing.T) {
 	assert.Equal(t, math.SigmoidSteepenedActivation, addedNode.ActivationType, "wrong activation type")
 }
 
+func TestGenome_mutateAddNode_memory(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	context := &neat.Options{
+		NodeActivators:          []math.NodeActivationType{math.SigmoidSteepenedActivation},
+		NodeActivatorsProb:      []float64{1.0},
+		MutateAddMemoryNodeProb: 1.0,
+	}
+	context.PopSize = 1
+	pop := newPopulation()
+	err := pop.spawn(gnome1, context)
+	require.NoError(t, err, "failed to spawn population")
+
+	res, err := gnome1.mutateAddNode(pop, pop, context)
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	require.Len(t, gnome1.Nodes, 5, "wrong number of nodes")
+	addedNode := gnome1.Nodes[4]
+	assert.Equal(t, network.MemoryNeuron, addedNode.NeuronType)
+	assert.Len(t, addedNode.GateWeights, network.MemoryCellGateWeightsCount)
+	require.Len(t, pop.Innovations(), 1, "wrong number of innovations")
+	assert.Equal(t, network.MemoryNeuron, pop.Innovations()[0].NewNeuronType)
+
+	// the same innovation must produce the memory cell as well
+	context.MutateAddMemoryNodeProb = 0
+	found := false
+	for i := 0; i < 100 && !found; i++ {
+		gnome2 := buildTestGenome(2)
+		res, err = gnome2.mutateAddNode(pop, pop, context)
+		require.NoError(t, err, "failed to mutate")
+		if res && gnome2.Nodes[4].Id == addedNode.Id {
+			found = true
+			assert.Equal(t, network.MemoryNeuron, gnome2.Nodes[4].NeuronType)
+			assert.Len(t, gnome2.Nodes[4].GateWeights, network.MemoryCellGateWeightsCount)
+		}
+	}
+	assert.True(t, found, "the same innovation was not applied")
+}
+
+func TestGenome_mutateAddModulatoryNode(t *testing.T) {
+	gnome1 := buildTestGenome(1)
+	context := &neat.Options{
+		NodeActivators:     []math.NodeActivationType{math.LinearActivation},
+		NodeActivatorsProb: []float64{1.0},
+	}
+	context.PopSize = 1
+	pop := newPopulation()
+	err := pop.spawn(gnome1, context)
+	require.NoError(t, err, "failed to spawn population")
+
+	res, err := gnome1.mutateAddModulatoryNode(pop, pop, context)
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+
+	assert.EqualValues(t, 5, pop.nextInnovNum, "wrong next innovation number set for population")
+	assert.Len(t, gnome1.Genes, 5, "wrong number of genes")
+	require.Len(t, gnome1.Nodes, 5, "wrong number of nodes")
+	for _, gn := range gnome1.Genes {
+		assert.True(t, gn.IsEnabled, "all genes must be enabled: %s", gn)
+	}
+	addedNode := gnome1.Nodes[4]
+	assert.Equal(t, network.ModulatoryNeuron, addedNode.NeuronType)
+	assert.Equal(t, math.LinearActivation, addedNode.ActivationType, "wrong activation type")
+	require.Len(t, pop.Innovations(), 1, "wrong number of innovations")
+	innovation := pop.Innovations()[0]
+	assert.Equal(t, network.ModulatoryNeuron, innovation.NewNeuronType)
+
+	// the modulatory neuron receives signal from source and modulates the target of the chosen link
+	var modulated *Gene
+	for _, gn := range gnome1.Genes {
+		if gn.InnovationNum == innovation.OldInnovNum {
+			modulated = gn
+		}
+	}
+	require.NotNil(t, modulated)
+	assert.Equal(t, innovation.InNodeId, modulated.Link.InNode.Id)
+	assert.Equal(t, innovation.OutNodeId, modulated.Link.OutNode.Id)
+
+	// the phenotype can be built and activated
+	net, err := gnome1.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	err = net.LoadSensors([]float64{1.0, 0.5})
+	require.NoError(t, err)
+	_, err = net.ForwardSteps(3)
+	require.NoError(t, err, "failed to activate network")
+
+	// the same innovation must be reused and not confused with add node innovation
+	found := false
+	for i := 0; i < 100 && !found; i++ {
+		gnome2 := buildTestGenome(2)
+		res, err = gnome2.mutateAddModulatoryNode(pop, pop, context)
+		require.NoError(t, err, "failed to mutate")
+		require.True(t, res, "mutation failed")
+		if gnome2.Nodes[4].Id == addedNode.Id {
+			found = true
+			assert.Equal(t, network.ModulatoryNeuron, gnome2.Nodes[4].NeuronType)
+		}
+	}
+	assert.True(t, found, "the same innovation was not applied")
+
+	// no links to modulate
+	gnome3 := buildTestGenome(3)
+	for _, gn := range gnome3.Genes {
+		gn.IsEnabled = false
+	}
+	res, err = gnome3.mutateAddModulatoryNode(pop, pop, context)
+	assert.NoError(t, err)
+	assert.False(t, res)
+}
+
 func TestGenome_mutateLinkWeights(t *testing.T) {
 	rand.Seed(42)
 	gnome1 := buildTestGenome(1)
@@ -222,6 +331,143 @@ func TestGenome_mutateNodeTrait(t *testing.T) {
 	assert.True(t, mutationFound, "No mutation found in nodes traits")
 }
 
+func TestGenome_mutateNodeTimeConstant(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+
+	for i := 0; i < 10; i++ {
+		res, err := gnome1.mutateNodeTimeConstant(0.5)
+		require.NoError(t, err, "failed to mutate")
+		require.True(t, res, "mutation failed")
+	}
+
+	mutationFound := false
+	for _, nd := range gnome1.Nodes {
+		if nd.IsSensor() {
+			assert.Zero(t, nd.TimeConstant, "sensor time constant must not be mutated")
+		} else if nd.TimeConstant != 0 {
+			mutationFound = true
+			assert.True(t, nd.TimeConstant > 0, "time constant must be positive")
+		}
+	}
+	assert.True(t, mutationFound, "No mutation found in nodes time constants")
+
+	// no neurons
+	gnome1.Nodes = gnome1.Nodes[:1]
+	res, err := gnome1.mutateNodeTimeConstant(0.5)
+	assert.Error(t, err)
+	assert.False(t, res)
+}
+
+func TestGenome_mutateNodeBias(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+
+	for i := 0; i < 10; i++ {
+		res, err := gnome1.mutateNodeBias(0.5)
+		require.NoError(t, err, "failed to mutate")
+		require.True(t, res, "mutation failed")
+	}
+
+	mutationFound := false
+	for _, nd := range gnome1.Nodes {
+		if nd.IsSensor() {
+			assert.Zero(t, nd.Bias, "sensor bias must not be mutated")
+		} else if nd.Bias != 0 {
+			mutationFound = true
+		}
+	}
+	assert.True(t, mutationFound, "No mutation found in nodes biases")
+
+	// no neurons
+	gnome1.Nodes = gnome1.Nodes[:1]
+	res, err := gnome1.mutateNodeBias(0.5)
+	assert.Error(t, err)
+	assert.False(t, res)
+}
+
+func TestGenome_mutateNodeResponse(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+
+	res, err := gnome1.mutateNodeResponse(0.5)
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+
+	mutationFound := false
+	for _, nd := range gnome1.Nodes {
+		if nd.IsSensor() {
+			assert.Nil(t, nd.Response, "sensor response must not be mutated")
+		} else if nd.Response != nil {
+			mutationFound = true
+			// perturbed starting from the default response
+			assert.InDelta(t, network.DefaultNodeResponse, *nd.Response, 0.5)
+		}
+	}
+	assert.True(t, mutationFound, "No mutation found in nodes responses")
+
+	// no neurons
+	gnome1.Nodes = gnome1.Nodes[:1]
+	res, err = gnome1.mutateNodeResponse(0.5)
+	assert.Error(t, err)
+	assert.False(t, res)
+}
+
+func TestGenome_mutateLinkDelay(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+
+	for i := 0; i < 20; i++ {
+		res, err := gnome1.mutateLinkDelay(2)
+		require.NoError(t, err, "failed to mutate")
+		require.True(t, res, "mutation failed")
+	}
+	mutationFound := false
+	for _, gene := range gnome1.Genes {
+		assert.True(t, gene.Link.Delay >= 0 && gene.Link.Delay <= 2, "delay out of range: %d", gene.Link.Delay)
+		if gene.Link.Delay > 0 {
+			mutationFound = true
+		}
+	}
+	assert.True(t, mutationFound, "No mutation found in links delays")
+
+	// delays disabled
+	res, err := gnome1.mutateLinkDelay(0)
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	// no genes
+	gnome1.Genes = nil
+	res, err = gnome1.mutateLinkDelay(2)
+	assert.Error(t, err)
+	assert.False(t, res)
+}
+
+func TestGenome_mutateMemoryGates(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestMemoryGenome(1)
+	original := append([]float64(nil), gnome1.Nodes[4].GateWeights...)
+
+	res, err := gnome1.mutateMemoryGates(0.5)
+	require.NoError(t, err, "failed to mutate")
+	require.True(t, res, "mutation failed")
+	for i, w := range gnome1.Nodes[4].GateWeights {
+		assert.NotEqual(t, original[i], w, "gate weight not mutated at: %d", i)
+	}
+
+	// wrong number of gate weights
+	gnome1.Nodes[4].GateWeights = gnome1.Nodes[4].GateWeights[:2]
+	res, err = gnome1.mutateMemoryGates(0.5)
+	assert.Error(t, err)
+	assert.False(t, res)
+
+	// no memory cells
+	gnome2 := buildTestGenome(2)
+	res, err = gnome2.mutateMemoryGates(0.5)
+	assert.NoError(t, err)
+	assert.False(t, res)
+}
+
 func TestGenome_mutateToggleEnable(t *testing.T) {
 	gnome1 := buildTestGenome(1)
 	// add extra connection gene from BIAS to OUT
@@ -261,3 +507,50 @@ func TestGenome_mutateGeneReEnable(t *testing.T) {
 	assert.True(t, gnome1.Genes[1].IsEnabled, "The first encountered gene should be enabled")
 	assert.False(t, gnome1.Genes[3].IsEnabled, "The second disabled gene should still be disabled")
 }
+
+func TestGenome_mutateAllNonstructural(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+	opts := &neat.Options{
+		MutateRandomTraitProb:  1.0,
+		MutateLinkTraitProb:    1.0,
+		MutateNodeTraitProb:    1.0,
+		MutateLinkWeightsProb:  1.0,
+		MutateToggleEnableProb: 1.0,
+		MutateGeneReenableProb: 1.0,
+		TraitParamMutProb:      0.5,
+		TraitMutationPower:     1.0,
+		WeightMutPower:         0.5,
+
+		MutateNodeTimeConstantProb: 1.0,
+		TimeConstantMutationPower:  0.5,
+
+		MutateNodeBiasProb:        1.0,
+		MutateNodeResponseProb:    1.0,
+		NodeBiasMutationPower:     0.5,
+		NodeResponseMutationPower: 0.5,
+
+		MutateLinkDelayProb: 1.0,
+		MaxLinkDelay:        2,
+	}
+	applied, err := gnome1.mutateAllNonstructural(opts)
+	require.NoError(t, err, "failed to mutate")
+	expected := []ReproductionOperator{
+		MutateRandomTraitOperator,
+		MutateLinkTraitOperator,
+		MutateNodeTraitOperator,
+		MutateLinkWeightsOperator,
+		MutateToggleEnableOperator,
+		MutateGeneReEnableOperator,
+		MutateNodeTimeConstantOperator,
+		MutateNodeBiasOperator,
+		MutateNodeResponseOperator,
+		MutateLinkDelayOperator,
+	}
+	assert.Equal(t, expected, applied)
+
+	// no mutations applied
+	applied, err = gnome1.mutateAllNonstructural(&neat.Options{})
+	require.NoError(t, err, "failed to mutate")
+	assert.Empty(t, applied)
+}
@@ -0,0 +1,80 @@
+package genetics
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+)
+
+// AddOutputModule Attaches the module with given activation function to the group of output nodes of this genome.
+// The module decodes the group in place, i.e., it receives the values of the output nodes after their activation and
+// replaces them with its outputs, thus the module activation function must produce output per each input, e.g.,
+// math.SoftmaxModuleActivation, math.LogSoftmaxModuleActivation, math.NormalizeModuleActivation or
+// math.WinnerTakeAllModuleActivation. If outputIds is empty, all output nodes of the genome are included into the
+// group. The output nodes with linear activation function provide the raw logits to the module.
+//
+// This is intended to be used when creating start genomes, e.g., for classification tasks. The control node of the
+// module gets the next free node ID and the control gene gets the next innovation number of this genome.
+func (g *Genome) AddOutputModule(activationType math.NodeActivationType, outputIds ...int) (*MIMOControlGene, error) {
+	if !g.Activators().IsModuleActivationType(activationType) {
+		return nil, fmt.Errorf("not a module activation type: %d", activationType)
+	}
+
+	outputs := make([]*network.NNode, 0)
+	if len(outputIds) == 0 {
+		for _, node := range g.Nodes {
+			if node.NeuronType == network.OutputNeuron {
+				outputs = append(outputs, node)
+			}
+		}
+	} else {
+		for _, id := range outputIds {
+			node := NodeWithId(id, g.Nodes)
+			if node == nil || node.NeuronType != network.OutputNeuron {
+				return nil, fmt.Errorf("no output node with id: %d can be found in genome: %d", id, g.Id)
+			}
+			outputs = append(outputs, node)
+		}
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("genome has no output nodes to attach module")
+	}
+
+	// check that module produces output per each input
+	if values, err := g.Activators().ActivateModuleByType(make([]float64, len(outputs)), nil, activationType); err != nil {
+		return nil, err
+	} else if len(values) != len(outputs) {
+		return nil, fmt.Errorf("module activator produces %d outputs for the group of %d output nodes",
+			len(values), len(outputs))
+	}
+
+	nodeId, err := g.getLastNodeId()
+	if err != nil {
+		return nil, err
+	}
+	innovationNum := int64(1)
+	for _, gene := range g.Genes {
+		if gene.InnovationNum >= innovationNum {
+			innovationNum = gene.InnovationNum + 1
+		}
+	}
+	for _, cg := range g.ControlGenes {
+		if cg.InnovationNum >= innovationNum {
+			innovationNum = cg.InnovationNum + 1
+		}
+	}
+
+	controlNode := network.NewNNode(nodeId+1, network.HiddenNeuron)
+	controlNode.ActivationType = activationType
+	if len(g.Traits) > 0 {
+		controlNode.Trait = g.Traits[0]
+	}
+	for _, node := range outputs {
+		controlNode.Incoming = append(controlNode.Incoming, network.NewLink(1.0, node, controlNode, false))
+		controlNode.Outgoing = append(controlNode.Outgoing, network.NewLink(1.0, controlNode, node, false))
+	}
+	gene := NewMIMOGene(controlNode, innovationNum, 0, true)
+	g.ControlGenes = append(g.ControlGenes, gene)
+	return gene, nil
+}
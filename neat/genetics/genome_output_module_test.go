@@ -0,0 +1,147 @@
+package genetics
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"strings"
+	"testing"
+)
+
+// buildTestClassifierGenome returns test genome with two inputs fully connected to three linear outputs
+func buildTestClassifierGenome(id int) *Genome {
+	trait := &neat.Trait{Id: 1, Params: make([]float64, neat.NumTraitParams)}
+	nodes := []*network.NNode{
+		network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(2, network.InputNeuron),
+		network.NewNNode(3, network.OutputNeuron),
+		network.NewNNode(4, network.OutputNeuron),
+		network.NewNNode(5, network.OutputNeuron),
+	}
+	genes := make([]*Gene, 0)
+	for i, out := range nodes[2:] {
+		out.ActivationType = math.LinearActivation
+		for j, in := range nodes[:2] {
+			link := network.NewLinkWithTrait(trait, float64(i+1)*float64(1-2*j), in, out, false)
+			genes = append(genes, NewConnectionGene(link, int64(len(genes)+1), 0, true))
+		}
+	}
+	return NewGenome(id, []*neat.Trait{trait}, nodes, genes)
+}
+
+func TestGenome_AddOutputModule(t *testing.T) {
+	gnome := buildTestClassifierGenome(1)
+	gene, err := gnome.AddOutputModule(math.SoftmaxModuleActivation)
+	require.NoError(t, err, "failed to add output module")
+	require.Len(t, gnome.ControlGenes, 1)
+	assert.Equal(t, gene, gnome.ControlGenes[0])
+	assert.Equal(t, int64(7), gene.InnovationNum)
+	assert.Equal(t, 6, gene.ControlNode.Id)
+	assert.Len(t, gene.ControlNode.Incoming, 3)
+	assert.Len(t, gene.ControlNode.Outgoing, 3)
+	assert.Empty(t, issueCodes(gnome.Validate()))
+
+	net, err := gnome.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	require.NoError(t, net.LoadSensors([]float64{1.0, 0.5}))
+	_, err = net.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate")
+	outputs := net.ReadOutputs()
+	// logits: 0.5, 1.0, 1.5
+	assert.InDeltaSlice(t, []float64{0.1863237232258476, 0.30719588571849843, 0.5064803910556539}, outputs, 1e-12)
+
+	// the group of selected outputs
+	gene, err = gnome.AddOutputModule(math.WinnerTakeAllModuleActivation, 3, 4)
+	require.NoError(t, err, "failed to add output module")
+	assert.Equal(t, int64(8), gene.InnovationNum)
+	assert.Equal(t, 7, gene.ControlNode.Id)
+	assert.Len(t, gene.ControlNode.Outgoing, 2)
+}
+
+func TestGenome_AddOutputModule_layeredSolver(t *testing.T) {
+	gnome := buildTestClassifierGenome(1)
+	_, err := gnome.AddOutputModule(math.SoftmaxModuleActivation)
+	require.NoError(t, err, "failed to add output module")
+
+	net, err := gnome.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+	// the output neurons are evaluated before the module
+	assert.Equal(t, 2, solver.Depth())
+	layers := solver.Layers()
+	assert.Len(t, layers[0].Neurons, 3)
+	assert.Empty(t, layers[0].Modules)
+	assert.Empty(t, layers[1].Neurons)
+	assert.Len(t, layers[1].Modules, 1)
+
+	data := []float64{1.0, 0.5}
+	require.NoError(t, net.LoadSensors(data))
+	_, err = net.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate network")
+
+	require.NoError(t, solver.LoadSensors(data))
+	res, err := solver.Evaluate()
+	require.NoError(t, err, "failed to activate layered network solver")
+	require.True(t, res)
+	assert.InDeltaSlice(t, net.ReadOutputs(), solver.ReadOutputs(), 1e-12)
+}
+
+func TestGenome_AddOutputModule_errors(t *testing.T) {
+	gnome := buildTestClassifierGenome(1)
+	_, err := gnome.AddOutputModule(math.SigmoidSteepenedActivation)
+	assert.Error(t, err, "node activator is not a module")
+
+	_, err = gnome.AddOutputModule(math.MultiplyModuleActivation)
+	assert.Error(t, err, "module must produce output per each input")
+
+	_, err = gnome.AddOutputModule(math.SoftmaxModuleActivation, 1)
+	assert.Error(t, err, "input node is not an output")
+
+	_, err = gnome.AddOutputModule(math.SoftmaxModuleActivation, 10)
+	assert.Error(t, err, "missing node")
+	assert.Empty(t, gnome.ControlGenes)
+}
+
+func TestGenome_AddOutputModule_encodings(t *testing.T) {
+	gnome := buildTestClassifierGenome(1)
+	_, err := gnome.AddOutputModule(math.LogSoftmaxModuleActivation)
+	require.NoError(t, err, "failed to add output module")
+
+	for _, encoding := range []GenomeEncoding{PlainGenomeEncoding, YAMLGenomeEncoding} {
+		outBuf := bytes.NewBufferString("")
+		wr, err := NewGenomeWriter(bufio.NewWriter(outBuf), encoding)
+		require.NoError(t, err)
+		err = wr.WriteGenome(gnome)
+		require.NoError(t, err, "failed to write genome: %d", encoding)
+		if encoding == PlainGenomeEncoding {
+			assert.Contains(t, outBuf.String(), "module 6 1 LogSoftmaxModuleActivation 7 0 true 3,4,5 3,4,5\n")
+		}
+
+		rd, err := NewGenomeReader(bytes.NewBuffer(outBuf.Bytes()), encoding)
+		require.NoError(t, err)
+		gnomeEnc, err := rd.Read()
+		require.NoError(t, err, "failed to read genome: %d", encoding)
+		equal, err := gnome.IsEqual(gnomeEnc)
+		assert.NoError(t, err, "genome mismatch: %d", encoding)
+		assert.True(t, equal)
+	}
+}
+
+func TestReadPlainControlGene_errors(t *testing.T) {
+	gnome := buildTestClassifierGenome(1)
+	testCases := []string{
+		"6 1 LogSoftmaxModuleActivation 7 0 true 3,4",
+		"6 1 UnknownActivation 7 0 true 3,4 3,4",
+		"6 1 LogSoftmaxModuleActivation 7 0 true 3,x 3,4",
+		"6 1 LogSoftmaxModuleActivation 7 0 true 3,4 3,10",
+	}
+	for _, tc := range testCases {
+		_, err := readPlainControlGene(strings.NewReader(tc), gnome.Traits, gnome.Nodes, gnome.Activators())
+		assert.Error(t, err, tc)
+	}
+}
@@ -0,0 +1,89 @@
+package genetics
+
+import (
+	"github.com/yaricom/goNEAT/v3/neat/network"
+)
+
+// Prune creates the cleaned copy of this genome with given ID. The disabled genes and disabled MIMO control genes are
+// removed as well as the hidden nodes which either not reachable from the sensors or has no path to the outputs along
+// with all genes connected to them. The sensors and outputs are always preserved, and the alive control genes keep
+// all their IO nodes. The traits are copied as is.
+//
+// Unlike the network.Network Prune, the pass-through nodes are not folded, because it requires creation of new genes
+// without innovation history.
+func (g *Genome) Prune(newId int) (*Genome, error) {
+	// build the forward and backward adjacency of the enabled genes, where control nodes are referenced by their IDs
+	forward, backward := make(map[int][]int), make(map[int][]int)
+	addEdge := func(from, to int) {
+		forward[from] = append(forward[from], to)
+		backward[to] = append(backward[to], from)
+	}
+	for _, gn := range g.Genes {
+		if gn.IsEnabled {
+			addEdge(gn.Link.InNode.Id, gn.Link.OutNode.Id)
+		}
+	}
+	for _, cg := range g.ControlGenes {
+		if !cg.IsEnabled {
+			continue
+		}
+		for _, l := range cg.ControlNode.Incoming {
+			addEdge(l.InNode.Id, cg.ControlNode.Id)
+		}
+		for _, l := range cg.ControlNode.Outgoing {
+			addEdge(cg.ControlNode.Id, l.OutNode.Id)
+		}
+	}
+
+	sensors, outputs := make([]int, 0), make([]int, 0)
+	for _, n := range g.Nodes {
+		if n.IsSensor() {
+			sensors = append(sensors, n.Id)
+		} else if n.NeuronType == network.OutputNeuron {
+			outputs = append(outputs, n.Id)
+		}
+	}
+	fromSensors := reachableNodes(forward, sensors...)
+	toOutputs := reachableNodes(backward, outputs...)
+	isLive := func(id int) bool {
+		return fromSensors[id] && toOutputs[id]
+	}
+
+	// the duplicate preserves the order of nodes and genes
+	dup, err := g.duplicate(newId)
+	if err != nil {
+		return nil, err
+	}
+	keep := make(map[int]bool)
+	for _, id := range append(sensors, outputs...) {
+		keep[id] = true
+	}
+	controlGenes := make([]*MIMOControlGene, 0, len(dup.ControlGenes))
+	for i, cg := range dup.ControlGenes {
+		if !g.ControlGenes[i].IsEnabled || !isLive(cg.ControlNode.Id) {
+			continue
+		}
+		controlGenes = append(controlGenes, cg)
+		for _, n := range cg.ioNodes {
+			keep[n.Id] = true
+		}
+	}
+	nodes := make([]*network.NNode, 0, len(dup.Nodes))
+	for _, n := range dup.Nodes {
+		if keep[n.Id] || isLive(n.Id) {
+			keep[n.Id] = true
+			nodes = append(nodes, n)
+		}
+	}
+	genes := make([]*Gene, 0, len(dup.Genes))
+	for i, gn := range dup.Genes {
+		if g.Genes[i].IsEnabled && keep[gn.Link.InNode.Id] && keep[gn.Link.OutNode.Id] {
+			genes = append(genes, gn)
+		}
+	}
+
+	dup.Nodes = nodes
+	dup.Genes = genes
+	dup.ControlGenes = controlGenes
+	return dup, nil
+}
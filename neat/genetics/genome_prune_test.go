@@ -0,0 +1,63 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"testing"
+)
+
+func TestGenome_Prune(t *testing.T) {
+	gnome := buildTestGenome(1)
+	// add dead-end hidden node
+	deadEnd := network.NewNNode(5, network.HiddenNeuron)
+	// add hidden node not reachable from sensors
+	unreachable := network.NewNNode(6, network.HiddenNeuron)
+	gnome.Nodes = append(gnome.Nodes, deadEnd, unreachable)
+	gnome.Genes = append(gnome.Genes,
+		NewConnectionGene(network.NewLinkWithTrait(gnome.Traits[0], 1.0, gnome.Nodes[0], deadEnd, false), 4, 0, true),
+		NewConnectionGene(network.NewLinkWithTrait(gnome.Traits[0], 1.0, unreachable, gnome.Nodes[3], false), 5, 0, true),
+		NewConnectionGene(network.NewLinkWithTrait(gnome.Traits[0], 1.0, gnome.Nodes[1], unreachable, false), 6, 0, false),
+	)
+	// disable gene between sensor and output
+	gnome.Genes[2].IsEnabled = false
+
+	pruned, err := gnome.Prune(2)
+	require.NoError(t, err, "failed to prune")
+	assert.Equal(t, 2, pruned.Id)
+	require.Len(t, pruned.Nodes, 4)
+	for i, n := range pruned.Nodes {
+		assert.Equal(t, i+1, n.Id)
+	}
+	require.Len(t, pruned.Genes, 2)
+	assert.EqualValues(t, 1, pruned.Genes[0].InnovationNum)
+	assert.EqualValues(t, 2, pruned.Genes[1].InnovationNum)
+	assert.Len(t, pruned.Traits, len(gnome.Traits))
+	assert.True(t, pruned.Validate().IsValid())
+
+	// the original genome remains unchanged
+	assert.Len(t, gnome.Nodes, 6)
+	assert.Len(t, gnome.Genes, 6)
+}
+
+func TestGenome_Prune_modular(t *testing.T) {
+	gnome := buildTestModularGenome(1)
+
+	pruned, err := gnome.Prune(2)
+	require.NoError(t, err, "failed to prune")
+	assert.Len(t, pruned.Nodes, len(gnome.Nodes))
+	assert.Len(t, pruned.Genes, len(gnome.Genes))
+	assert.Len(t, pruned.ControlGenes, 1)
+
+	// disabled control gene makes its IO nodes dead
+	gnome.ControlGenes[0].IsEnabled = false
+	pruned, err = gnome.Prune(3)
+	require.NoError(t, err, "failed to prune")
+	assert.Len(t, pruned.Nodes, 4)
+	assert.Len(t, pruned.Genes, 3)
+	assert.Empty(t, pruned.ControlGenes)
+
+	net, err := pruned.Genesis(3)
+	require.NoError(t, err, "failed to create phenotype")
+	assert.Equal(t, 4, net.NodeCount())
+}
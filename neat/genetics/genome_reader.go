@@ -26,20 +26,34 @@ type GenomeReader interface {
 // NewGenomeReaderFromFile creates reader for Genome data automatically resolving
 // genome encoding format of the file.
 func NewGenomeReaderFromFile(genomeFilePath string) (GenomeReader, error) {
+	return NewGenomeReaderFromFileWithActivators(genomeFilePath, nil)
+}
+
+// NewGenomeReaderFromFileWithActivators creates reader for Genome data automatically resolving
+// genome encoding format of the file. The activation functions are resolved using provided factory.
+func NewGenomeReaderFromFileWithActivators(genomeFilePath string, activators *math.NodeActivatorsFactory) (GenomeReader, error) {
 	if genomeFile, err := os.Open(genomeFilePath); err != nil {
 		return nil, err
 	} else {
-		return NewGenomeReader(genomeFile, genomeEncodingFromFileName(genomeFile.Name()))
+		return NewGenomeReaderWithActivators(genomeFile, genomeEncodingFromFileName(genomeFile.Name()), activators)
 	}
 }
 
 // NewGenomeReader Creates reader for Genome data with specified encoding format.
 func NewGenomeReader(r io.Reader, encoding GenomeEncoding) (GenomeReader, error) {
+	return NewGenomeReaderWithActivators(r, encoding, nil)
+}
+
+// NewGenomeReaderWithActivators Creates reader for Genome data with specified encoding format, which resolves the
+// activation functions of the nodes using provided factory, e.g., the neat.Options.Activators() with the custom
+// activation functions of the experiment. The read genomes keep the factory. If activators is nil the default
+// math.NodeActivators is used.
+func NewGenomeReaderWithActivators(r io.Reader, encoding GenomeEncoding, activators *math.NodeActivatorsFactory) (GenomeReader, error) {
 	switch encoding {
 	case PlainGenomeEncoding:
-		return &plainGenomeReader{r: bufio.NewReader(r)}, nil
+		return &plainGenomeReader{r: bufio.NewReader(r), activators: activators}, nil
 	case YAMLGenomeEncoding:
-		return &yamlGenomeReader{r: bufio.NewReader(r)}, nil
+		return &yamlGenomeReader{r: bufio.NewReader(r), activators: activators}, nil
 	default:
 		return nil, ErrUnsupportedGenomeEncoding
 	}
@@ -48,6 +62,8 @@ func NewGenomeReader(r io.Reader, encoding GenomeEncoding) (GenomeReader, error)
 // A PlainGenomeReader reads genome data from plain text file.
 type plainGenomeReader struct {
 	r *bufio.Reader
+	// The factory of activation functions of the read genomes
+	activators *math.NodeActivatorsFactory
 }
 
 func (r *plainGenomeReader) Encoding() GenomeEncoding {
@@ -56,9 +72,10 @@ func (r *plainGenomeReader) Encoding() GenomeEncoding {
 
 func (r *plainGenomeReader) Read() (*Genome, error) {
 	gnome := Genome{
-		Traits: make([]*neat.Trait, 0),
-		Nodes:  make([]*network.NNode, 0),
-		Genes:  make([]*Gene, 0),
+		Traits:     make([]*neat.Trait, 0),
+		Nodes:      make([]*network.NNode, 0),
+		Genes:      make([]*Gene, 0),
+		activators: r.activators,
 	}
 
 	var gId int
@@ -88,7 +105,7 @@ func (r *plainGenomeReader) Read() (*Genome, error) {
 
 		case "node":
 			// Read a Network Node
-			newNode, err := readPlainNetworkNode(lr, gnome.Traits)
+			newNode, err := readPlainNetworkNode(lr, gnome.Traits, gnome.Activators())
 			if err != nil {
 				return nil, err
 			}
@@ -106,6 +123,14 @@ func (r *plainGenomeReader) Read() (*Genome, error) {
 			}
 			gnome.Genes = append(gnome.Genes, gene)
 
+		case "module":
+			// Read a MIMO control gene
+			gene, err := readPlainControlGene(lr, gnome.Traits, gnome.Nodes, gnome.Activators())
+			if err != nil {
+				return nil, err
+			}
+			gnome.ControlGenes = append(gnome.ControlGenes, gene)
+
 		case "genomeend":
 			// Read Genome ID
 			_, err := fmt.Fscanf(lr, "%d", &gId)
@@ -143,7 +168,7 @@ func readPlainTrait(r io.Reader) (*neat.Trait, error) {
 
 // Read a Network Node from specified Reader in plain text format
 // and applies corresponding trait to it from a list of traits provided
-func readPlainNetworkNode(r io.Reader, traits []*neat.Trait) (*network.NNode, error) {
+func readPlainNetworkNode(r io.Reader, traits []*neat.Trait, activators *math.NodeActivatorsFactory) (*network.NNode, error) {
 	n := network.NewNetworkNode()
 	buff := bufio.NewReader(r)
 	line, _, err := buff.ReadLine()
@@ -171,8 +196,42 @@ func readPlainNetworkNode(r io.Reader, traits []*neat.Trait) (*network.NNode, er
 		n.NeuronType = network.NodeNeuronType(neuronType)
 	}
 
-	if len(parts) == 5 {
-		n.ActivationType, err = math.NodeActivators.ActivationTypeFromName(parts[4])
+	if len(parts) >= 5 {
+		if n.ActivationType, err = activators.ActivationTypeFromName(parts[4]); err != nil {
+			return nil, err
+		}
+	}
+	if len(parts) >= 6 {
+		// the optional time constant
+		if n.TimeConstant, err = strconv.ParseFloat(parts[5], 64); err != nil {
+			return nil, err
+		}
+	}
+	if len(parts) > 6 {
+		for _, p := range parts[6:] {
+			if value := strings.TrimPrefix(p, plainNodeBiasKey); value != p {
+				// the optional bias
+				if n.Bias, err = strconv.ParseFloat(value, 64); err != nil {
+					return nil, err
+				}
+			} else if value = strings.TrimPrefix(p, plainNodeResponseKey); value != p {
+				// the optional response
+				var response float64
+				if response, err = strconv.ParseFloat(value, 64); err != nil {
+					return nil, err
+				}
+				n.SetResponse(response)
+			} else if n.NeuronType == network.MemoryNeuron && len(n.GateWeights) < network.MemoryCellGateWeightsCount {
+				// the optional gate weights of the memory cell
+				var weight float64
+				if weight, err = strconv.ParseFloat(p, 64); err != nil {
+					return nil, err
+				}
+				n.GateWeights = append(n.GateWeights, weight)
+			} else {
+				return nil, fmt.Errorf("node line has unexpected value: %s (%s)", p, parts)
+			}
+		}
 	}
 
 	return n, err
@@ -189,6 +248,17 @@ func readPlainConnectionGene(r io.Reader, traits []*neat.Trait, nodes []*network
 	if err != nil {
 		return nil, err
 	}
+	// the optional delay
+	delay := 0
+	if optional, err := io.ReadAll(r); err != nil {
+		return nil, err
+	} else if parts := strings.Fields(string(optional)); len(parts) > 1 {
+		return nil, fmt.Errorf("gene line has wrong number of optional values: %d (%s)", len(parts), parts)
+	} else if len(parts) == 1 {
+		if delay, err = strconv.Atoi(parts[0]); err != nil {
+			return nil, err
+		}
+	}
 
 	trait := TraitWithId(traitId, traits)
 	var inNode, outNode *network.NNode
@@ -200,16 +270,69 @@ func readPlainConnectionGene(r io.Reader, traits []*neat.Trait, nodes []*network
 			outNode = np
 		}
 	}
+	var link *network.Link
 	if trait != nil {
-		return NewConnectionGene(network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		link = network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent)
 	} else {
-		return NewConnectionGene(network.NewLink(weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		link = network.NewLink(weight, inNode, outNode, recurrent)
+	}
+	link.Delay = delay
+	return NewConnectionGene(link, innovationNum, mutNum, enabled), nil
+}
+
+// Reads MIMO control gene from reader in plain text format
+func readPlainControlGene(r io.Reader, traits []*neat.Trait, nodes []*network.NNode,
+	activators *math.NodeActivatorsFactory) (*MIMOControlGene, error) {
+	var controlNodeId, traitId int
+	var activation, inputs, outputs string
+	var innovationNum int64
+	var mutNum float64
+	var enabled bool
+	_, err := fmt.Fscanf(r, "%d %d %s %d %g %t %s %s",
+		&controlNodeId, &traitId, &activation, &innovationNum, &mutNum, &enabled, &inputs, &outputs)
+	if err != nil {
+		return nil, err
+	}
+	controlNode := network.NewNetworkNode()
+	controlNode.Id = controlNodeId
+	controlNode.NeuronType = network.HiddenNeuron
+	controlNode.Trait = TraitWithId(traitId, traits)
+	if controlNode.ActivationType, err = activators.ActivationTypeFromName(activation); err != nil {
+		return nil, err
+	}
+
+	for _, idStr := range strings.Split(inputs, ",") {
+		nodeId, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, err
+		}
+		node := NodeWithId(nodeId, nodes)
+		if node == nil {
+			return nil, fmt.Errorf("no MIMO input node with id: %d can be found for module: %d",
+				nodeId, controlNode.Id)
+		}
+		controlNode.Incoming = append(controlNode.Incoming, network.NewLink(1.0, node, controlNode, false))
+	}
+	for _, idStr := range strings.Split(outputs, ",") {
+		nodeId, err := strconv.Atoi(idStr)
+		if err != nil {
+			return nil, err
+		}
+		node := NodeWithId(nodeId, nodes)
+		if node == nil {
+			return nil, fmt.Errorf("no MIMO output node with id: %d can be found for module: %d",
+				nodeId, controlNode.Id)
+		}
+		controlNode.Outgoing = append(controlNode.Outgoing, network.NewLink(1.0, controlNode, node, false))
 	}
+	return NewMIMOGene(controlNode, innovationNum, mutNum, enabled), nil
 }
 
 // A YAMLGenomeReader reads genome data from YAML encoded text file
 type yamlGenomeReader struct {
 	r *bufio.Reader
+	// The factory of activation functions of the read genomes
+	activators *math.NodeActivatorsFactory
 }
 
 func (r *yamlGenomeReader) Encoding() GenomeEncoding {
@@ -240,6 +363,7 @@ func (r *yamlGenomeReader) Read() (*Genome, error) {
 		Nodes:        make([]*network.NNode, 0),
 		Genes:        make([]*Gene, 0),
 		ControlGenes: make([]*MIMOControlGene, 0),
+		activators:   r.activators,
 	}
 
 	// read traits
@@ -259,7 +383,7 @@ func (r *yamlGenomeReader) Read() (*Genome, error) {
 	// read nodes
 	nodes := gm["nodes"].([]interface{})
 	for _, nd := range nodes {
-		node, err := readNNode(nd.(map[string]interface{}), gnome.Traits)
+		node, err := readNNode(nd.(map[string]interface{}), gnome.Traits, gnome.Activators())
 		if err != nil {
 			return nil, err
 		}
@@ -284,7 +408,7 @@ func (r *yamlGenomeReader) Read() (*Genome, error) {
 	mimoGenes := gm["modules"]
 	if mimoGenes != nil {
 		for _, mg := range mimoGenes.([]interface{}) {
-			mGene, err := readMIMOControlGene(mg.(map[string]interface{}), gnome.Traits, gnome.Nodes)
+			mGene, err := readMIMOControlGene(mg.(map[string]interface{}), gnome.Traits, gnome.Nodes, gnome.Activators())
 			if err != nil {
 				return nil, err
 			}
@@ -324,6 +448,12 @@ func readGene(conf map[string]interface{}, traits []*neat.Trait, nodes []*networ
 	if err != nil {
 		return nil, err
 	}
+	delay := 0
+	if d, ok := conf["delay"]; ok {
+		if delay, err = cast.ToIntE(d); err != nil {
+			return nil, err
+		}
+	}
 
 	trait := TraitWithId(traitId, traits)
 	var inNode, outNode *network.NNode
@@ -335,22 +465,26 @@ func readGene(conf map[string]interface{}, traits []*neat.Trait, nodes []*networ
 			outNode = np
 		}
 	}
+	var link *network.Link
 	if trait != nil {
-		return NewConnectionGene(network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		link = network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent)
 	} else {
-		return NewConnectionGene(network.NewLink(weight, inNode, outNode, recurrent), innovationNum, mutNum, enabled), nil
+		link = network.NewLink(weight, inNode, outNode, recurrent)
 	}
+	link.Delay = delay
+	return NewConnectionGene(link, innovationNum, mutNum, enabled), nil
 }
 
 // Reads MIMOControlGene configuration
-func readMIMOControlGene(conf map[string]interface{}, traits []*neat.Trait, nodes []*network.NNode) (gene *MIMOControlGene, err error) {
+func readMIMOControlGene(conf map[string]interface{}, traits []*neat.Trait, nodes []*network.NNode,
+	activators *math.NodeActivatorsFactory) (gene *MIMOControlGene, err error) {
 	// read control node parameters
 	controlNode := network.NewNetworkNode()
 	controlNode.Id = conf["id"].(int)
 	controlNode.NeuronType = network.HiddenNeuron
 	// set activation function
 	activation := conf["activation"].(string)
-	controlNode.ActivationType, err = math.NodeActivators.ActivationTypeFromName(activation)
+	controlNode.ActivationType, err = activators.ActivationTypeFromName(activation)
 	if err != nil {
 		return nil, err
 	}
@@ -422,7 +556,7 @@ func readMIMOControlGene(conf map[string]interface{}, traits []*neat.Trait, node
 }
 
 // Reads NNode configuration
-func readNNode(conf map[string]interface{}, traits []*neat.Trait) (*network.NNode, error) {
+func readNNode(conf map[string]interface{}, traits []*neat.Trait, activators *math.NodeActivatorsFactory) (*network.NNode, error) {
 	nd := network.NewNetworkNode()
 	nd.Id = conf["id"].(int)
 	traitId := conf["trait_id"].(int)
@@ -433,8 +567,34 @@ func readNNode(conf map[string]interface{}, traits []*neat.Trait) (*network.NNod
 	if err != nil {
 		return nil, err
 	}
+	if timeConstant, ok := conf["time_constant"]; ok {
+		if nd.TimeConstant, err = cast.ToFloat64E(timeConstant); err != nil {
+			return nil, err
+		}
+	}
+	if bias, ok := conf["bias"]; ok {
+		if nd.Bias, err = cast.ToFloat64E(bias); err != nil {
+			return nil, err
+		}
+	}
+	if response, ok := conf["response"]; ok {
+		if r, err := cast.ToFloat64E(response); err != nil {
+			return nil, err
+		} else {
+			nd.SetResponse(r)
+		}
+	}
+	if gateWeights, ok := conf["gate_weights"]; ok {
+		weights := cast.ToSlice(gateWeights)
+		nd.GateWeights = make([]float64, len(weights))
+		for i, w := range weights {
+			if nd.GateWeights[i], err = cast.ToFloat64E(w); err != nil {
+				return nil, err
+			}
+		}
+	}
 	activation := conf["activation"].(string)
-	nd.ActivationType, err = math.NodeActivators.ActivationTypeFromName(activation)
+	nd.ActivationType, err = activators.ActivationTypeFromName(activation)
 	return nd, err
 }
 
@@ -1,6 +1,7 @@
 package genetics
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -119,7 +120,7 @@ func TestReadGene_ReadPlainNNode(t *testing.T) {
 	trait.Id = 10
 	traits := []*neat.Trait{trait}
 
-	node, err := readPlainNetworkNode(strings.NewReader(nodeStr), traits)
+	node, err := readPlainNetworkNode(strings.NewReader(nodeStr), traits, math.NodeActivators)
 	require.NoError(t, err, "failed to read network node")
 
 	assert.Equal(t, nodeId, node.Id, "wrong node ID")
@@ -134,7 +135,7 @@ func TestReadGene_ReadPlainNNode_readError(t *testing.T) {
 	traits := []*neat.Trait{trait}
 
 	errorReader := ErrorReader(1)
-	node, err := readPlainNetworkNode(&errorReader, traits)
+	node, err := readPlainNetworkNode(&errorReader, traits, math.NodeActivators)
 	assert.EqualError(t, err, alwaysErrorText)
 	assert.Nil(t, node)
 }
@@ -173,6 +174,27 @@ func TestReadGene_ReadPlainGene(t *testing.T) {
 	assert.False(t, link.IsRecurrent)
 }
 
+func TestReadGene_ReadPlainGene_delay(t *testing.T) {
+	trait := neat.NewTrait()
+	trait.Id = 1
+	nodes := []*network.NNode{
+		network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(4, network.HiddenNeuron),
+	}
+
+	gene, err := readPlainConnectionGene(strings.NewReader("1 1 4 0.5 false 1 0.5 true 3"), []*neat.Trait{trait}, nodes)
+	require.NoError(t, err, "failed to read gene")
+	assert.Equal(t, 3, gene.Link.Delay)
+
+	// too many optional fields
+	_, err = readPlainConnectionGene(strings.NewReader("1 1 4 0.5 false 1 0.5 true 3 4"), []*neat.Trait{trait}, nodes)
+	assert.Error(t, err)
+
+	// malformed delay
+	_, err = readPlainConnectionGene(strings.NewReader("1 1 4 0.5 false 1 0.5 true x"), []*neat.Trait{trait}, nodes)
+	assert.Error(t, err)
+}
+
 func TestReadGene_ReadPlainGene_readError(t *testing.T) {
 	trait := neat.NewTrait()
 	trait.Id = 1
@@ -372,3 +394,38 @@ func TestYAMLGenomeReader_Read_readError(t *testing.T) {
 	assert.EqualError(t, err, "yaml: input error: "+alwaysErrorText)
 	assert.Nil(t, genome)
 }
+
+func TestNewGenomeReaderWithActivators(t *testing.T) {
+	activators := math.NodeActivators.Clone()
+	cubeType, err := activators.RegisterExpression("CubeActivation", "x^3")
+	require.NoError(t, err, "failed to register activation function")
+
+	gnome := buildTestModularGenome(1)
+	gnome.SetActivators(activators)
+	gnome.Nodes[3].ActivationType = cubeType
+
+	for _, encoding := range []GenomeEncoding{PlainGenomeEncoding, YAMLGenomeEncoding} {
+		outBuf := bytes.NewBufferString("")
+		wr, err := NewGenomeWriter(outBuf, encoding)
+		require.NoError(t, err)
+		err = wr.WriteGenome(gnome)
+		require.NoError(t, err, "failed to write genome: %d", encoding)
+		assert.Contains(t, outBuf.String(), "CubeActivation")
+
+		// the default factory has no custom activation function
+		r, err := NewGenomeReader(bytes.NewReader(outBuf.Bytes()), encoding)
+		require.NoError(t, err)
+		_, err = r.Read()
+		assert.Error(t, err, "unknown activation function expected: %d", encoding)
+
+		r, err = NewGenomeReaderWithActivators(bytes.NewReader(outBuf.Bytes()), encoding, activators)
+		require.NoError(t, err)
+		gnomeRead, err := r.Read()
+		require.NoError(t, err, "failed to read genome: %d", encoding)
+		assert.Same(t, activators, gnomeRead.Activators())
+		assert.Equal(t, cubeType, gnomeRead.Nodes[3].ActivationType)
+		equal, err := gnome.IsEqual(gnomeRead)
+		assert.NoError(t, err, "genome mismatch: %d", encoding)
+		assert.True(t, equal)
+	}
+}
@@ -183,6 +183,9 @@ func (g *Genome) mateMultipoint(og *Genome, genomeId int, fitness1, fitness2 flo
 		} // end SKIP
 	} // end FOR
 
+	// inherit the bias and response genes of the nodes present in both parents
+	g.mateNodeGenes(newNodes, og, false)
+
 	// check if parent's MIMO control genes should be inherited
 	if len(g.ControlGenes) != 0 || len(og.ControlGenes) != 0 {
 		// MIMO control genes found at least in one parent - append it to child if appropriate
@@ -296,10 +299,13 @@ func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2
 				} else {
 					avgGene.Link.OutNode = p2gene.Link.OutNode
 				}
+				// the delay is inherited together with recurrence as both define timing of the signal
 				if rand.Float64() > 0.5 {
 					avgGene.Link.IsRecurrent = p1gene.Link.IsRecurrent
+					avgGene.Link.Delay = p1gene.Link.Delay
 				} else {
 					avgGene.Link.IsRecurrent = p2gene.Link.IsRecurrent
+					avgGene.Link.Delay = p2gene.Link.Delay
 				}
 
 				avgGene.InnovationNum = p1innov
@@ -394,6 +400,9 @@ func (g *Genome) mateMultipointAvg(og *Genome, genomeId int, fitness1, fitness2
 			newGenes = append(newGenes, gene)
 		} // end SKIP
 	} // end FOR
+	// average the bias and response genes of the nodes present in both parents
+	g.mateNodeGenes(newNodes, og, true)
+
 	// check if parent's MIMO control genes should be inherited
 	if len(g.ControlGenes) != 0 || len(og.ControlGenes) != 0 {
 		// MIMO control genes found at least in one parent - append it to child if appropriate
@@ -517,10 +526,13 @@ func (g *Genome) mateSinglePoint(og *Genome, genomeId int) (*Genome, error) {
 					} else {
 						avgGene.Link.OutNode = p2gene.Link.OutNode
 					}
+					// the delay is inherited together with recurrence as both define timing of the signal
 					if rand.Float64() > 0.5 {
 						avgGene.Link.IsRecurrent = p1gene.Link.IsRecurrent
+						avgGene.Link.Delay = p1gene.Link.Delay
 					} else {
 						avgGene.Link.IsRecurrent = p2gene.Link.IsRecurrent
+						avgGene.Link.Delay = p2gene.Link.Delay
 					}
 
 					avgGene.InnovationNum = p1innov
@@ -620,6 +632,10 @@ func (g *Genome) mateSinglePoint(og *Genome, genomeId int) (*Genome, error) {
 			newGenes = append(newGenes, gene)
 		} // end SKIP
 	} // end FOR
+
+	// inherit the bias and response genes of the nodes present in both parents
+	g.mateNodeGenes(newNodes, og, false)
+
 	// check if parent's MIMO control genes should be inherited
 	if len(g.ControlGenes) != 0 || len(og.ControlGenes) != 0 {
 		// MIMO control genes found at least in one parent - append it to child if appropriate
@@ -680,6 +696,44 @@ func findModulesIntersection(nodes map[int]*network.NNode, genes []*MIMOControlG
 	return modules
 }
 
+// Sets the bias and response genes of the child nodes which are present in both parents. The genes are either
+// averaged or inherited randomly from one of the parents.
+func (g *Genome) mateNodeGenes(childNodes []*network.NNode, og *Genome, average bool) {
+	nodes1, nodes2 := make(map[int]*network.NNode, len(g.Nodes)), make(map[int]*network.NNode, len(og.Nodes))
+	for _, n := range g.Nodes {
+		nodes1[n.Id] = n
+	}
+	for _, n := range og.Nodes {
+		nodes2[n.Id] = n
+	}
+	for _, child := range childNodes {
+		n1, ok1 := nodes1[child.Id]
+		n2, ok2 := nodes2[child.Id]
+		if !ok1 || !ok2 {
+			continue
+		}
+		if n1.Bias == 0 && n2.Bias == 0 && n1.Response == nil && n2.Response == nil {
+			// no genes to inherit, the random draw is skipped to preserve random sequence of the existing experiments
+			continue
+		}
+		if average {
+			child.Bias = (n1.Bias + n2.Bias) / 2.0
+			if n1.Response != nil || n2.Response != nil {
+				child.SetResponse((n1.ActivationResponse() + n2.ActivationResponse()) / 2.0)
+			}
+		} else {
+			parent := n1
+			if rand.Float64() < 0.5 {
+				parent = n2
+			}
+			child.Bias, child.Response = parent.Bias, nil
+			if parent.Response != nil {
+				child.SetResponse(*parent.Response)
+			}
+		}
+	}
+}
+
 // Builds array of traits for child genome during crossover
 func (g *Genome) mateTraits(og *Genome) ([]*neat.Trait, error) {
 	newTraits := make([]*neat.Trait, len(g.Traits))
@@ -39,6 +39,77 @@ func TestGenome_mateMultipoint(t *testing.T) {
 	assert.Len(t, genomeChild.Traits, 3, "wrong number of traits")
 }
 
+func TestGenome_mateMultipointMemory(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestMemoryGenome(1)
+	gnome2 := buildTestGenome(2)
+	// the fittest parent has memory cell
+	genomeChild, err := gnome1.mateMultipoint(gnome2, 3, 15.0, 2.3)
+	require.NoError(t, err, "failed to mate")
+	require.NotNil(t, genomeChild, "Failed to create child genome")
+
+	require.Len(t, genomeChild.Nodes, 5, "wrong number of nodes")
+	memory := genomeChild.Nodes[4]
+	assert.Equal(t, network.MemoryNeuron, memory.NeuronType)
+	assert.Equal(t, gnome1.Nodes[4].GateWeights, memory.GateWeights)
+	memory.GateWeights[0] = 100
+	assert.NotEqual(t, gnome1.Nodes[4].GateWeights[0], memory.GateWeights[0], "gate weights must be copied")
+}
+
+func TestGenome_mateNodeGenes(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+	gnome1.Nodes[3].Bias = 1.0
+	gnome1.Nodes[3].SetResponse(2.0)
+	gnome2 := buildTestGenome(2)
+	gnome2.Nodes[3].Bias = -1.0
+
+	genomeChild, err := gnome1.mateMultipoint(gnome2, 3, 1.0, 2.3)
+	require.NoError(t, err, "failed to mate")
+	require.Len(t, genomeChild.Nodes, 4, "wrong number of nodes")
+	out := genomeChild.Nodes[3]
+	if out.Bias == 1.0 {
+		assert.Equal(t, 2.0, out.ActivationResponse(), "genes must be inherited from the same parent")
+	} else {
+		assert.Equal(t, -1.0, out.Bias)
+		assert.Nil(t, out.Response, "genes must be inherited from the same parent")
+	}
+
+	genomeChild, err = gnome1.mateMultipointAvg(gnome2, 3, 1.0, 2.3)
+	require.NoError(t, err, "failed to mate")
+	require.Len(t, genomeChild.Nodes, 4, "wrong number of nodes")
+	assert.Equal(t, 0.0, genomeChild.Nodes[3].Bias)
+	assert.Equal(t, 1.5, genomeChild.Nodes[3].ActivationResponse())
+	assert.Nil(t, genomeChild.Nodes[2].Response, "unset response must remain unset")
+}
+
+func TestGenome_mate_linkDelay(t *testing.T) {
+	rand.Seed(42)
+	gnome1 := buildTestGenome(1)
+	gnome2 := buildTestGenome(2)
+	for _, gene := range gnome1.Genes {
+		gene.Link.Delay = 2
+	}
+	for _, gene := range gnome2.Genes {
+		gene.Link.Delay = 2
+	}
+
+	maters := []func(*Genome, int, float64, float64) (*Genome, error){
+		gnome1.mateMultipoint, gnome1.mateMultipointAvg,
+		func(og *Genome, genomeId int, _, _ float64) (*Genome, error) {
+			return gnome1.mateSinglePoint(og, genomeId)
+		},
+	}
+	for i, mate := range maters {
+		genomeChild, err := mate(gnome2, 3, 1.0, 2.3)
+		require.NoError(t, err, "failed to mate at: %d", i)
+		require.NotEmpty(t, genomeChild.Genes)
+		for _, gene := range genomeChild.Genes {
+			assert.Equal(t, 2, gene.Link.Delay, "delay must be inherited at: %d", i)
+		}
+	}
+}
+
 func TestGenome_mateMultipointModular(t *testing.T) {
 	rand.Seed(42)
 	// Check equal sized gene pools
@@ -46,6 +46,19 @@ func buildTestGenome(id int) *Genome {
 	return NewGenome(id, traits, nodes, genes)
 }
 
+// buildTestMemoryGenome returns test genome with the memory cell neuron between the first input and the output
+func buildTestMemoryGenome(id int) *Genome {
+	gnome := buildTestGenome(id)
+	memory := network.NewNNode(5, network.MemoryNeuron)
+	memory.Trait = gnome.Traits[0]
+	memory.GateWeights = []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	gnome.Nodes = append(gnome.Nodes, memory)
+	gnome.Genes = append(gnome.Genes,
+		NewConnectionGene(network.NewLinkWithTrait(gnome.Traits[0], 1.0, gnome.Nodes[0], memory, false), 4, 0, true),
+		NewConnectionGene(network.NewLinkWithTrait(gnome.Traits[0], 2.0, memory, gnome.Nodes[3], false), 5, 0, true))
+	return gnome
+}
+
 func buildTestModularGenome(id int) *Genome {
 	gnome := buildTestGenome(id)
 
@@ -105,6 +118,78 @@ func TestGenome_Genesis(t *testing.T) {
 	assert.Equal(t, len(gnome.Genes), net.LinkCount(), "wrong links count")
 }
 
+func TestGenome_GenesisActivationParams(t *testing.T) {
+	gnome := buildTestGenome(1)
+	node := gnome.Nodes[3]
+	node.ActivationType, node.Trait = math.PReLUActivation, gnome.Traits[1]
+
+	_, err := gnome.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	require.NotNil(t, node.PhenotypeAnalogue)
+	assert.Equal(t, node.Trait.Params, node.PhenotypeAnalogue.Params, "parameters must be derived from the node trait")
+}
+
+func TestGenome_Activators(t *testing.T) {
+	activators := math.NodeActivators.Clone()
+	cubeType, err := activators.RegisterExpression("CubeActivation", "x^3")
+	require.NoError(t, err, "failed to register activation function")
+
+	gnome := buildTestGenome(1)
+	assert.Same(t, math.NodeActivators, gnome.Activators(), "default factory expected")
+	gnome.SetActivators(activators)
+	assert.Same(t, activators, gnome.Activators())
+	gnome.Nodes[3].ActivationType = cubeType
+
+	// the network gets the factory of genome
+	net, err := gnome.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	assert.Same(t, activators, net.Activators())
+	require.NoError(t, net.LoadSensors([]float64{0.5, 1.0}))
+	_, err = net.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate network")
+
+	// the duplicate has the same factory
+	dup, err := gnome.duplicate(2)
+	require.NoError(t, err, "failed to duplicate")
+	assert.Same(t, activators, dup.Activators())
+
+	// the genome diff resolves the name of activation function
+	dup.Nodes[3].ActivationType = math.SigmoidSteepenedActivation
+	diff := DiffGenomes(gnome, dup)
+	require.Len(t, diff.Nodes, 1)
+	assert.Equal(t, "CubeActivation", diff.Nodes[0].First.ActivationType)
+
+	// reset to default
+	gnome.SetActivators(nil)
+	assert.Same(t, math.NodeActivators, gnome.Activators())
+	_, err = gnome.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	assert.Same(t, math.NodeActivators, gnome.Phenotype.Activators())
+}
+
+func TestGenome_GenesisMemory(t *testing.T) {
+	gnome := buildTestMemoryGenome(1)
+
+	net, err := gnome.Genesis(1)
+	require.NoError(t, err, "genesis failed")
+	memory := gnome.Nodes[4].PhenotypeAnalogue
+	require.NotNil(t, memory)
+	assert.Equal(t, network.MemoryNeuron, memory.NeuronType)
+	assert.Equal(t, gnome.Nodes[4].GateWeights, memory.GateWeights)
+
+	// check that network can be activated by both solvers
+	err = net.LoadSensors([]float64{1.0, 0.5})
+	require.NoError(t, err)
+	_, err = net.ForwardSteps(3)
+	require.NoError(t, err, "failed to activate network")
+	solver, err := net.FastNetworkSolver()
+	require.NoError(t, err)
+	err = solver.LoadSensors([]float64{1.0, 0.5})
+	require.NoError(t, err)
+	_, err = solver.ForwardSteps(3)
+	require.NoError(t, err, "failed to activate fast solver")
+}
+
 func TestGenome_GenesisModular(t *testing.T) {
 	gnome := buildTestModularGenome(1)
 	netId := 10
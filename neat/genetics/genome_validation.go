@@ -0,0 +1,434 @@
+package genetics
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"io"
+	"strings"
+)
+
+// ValidationSeverity defines the severity level of the genome validation issue
+type ValidationSeverity string
+
+const (
+	// ValidationInfo the issue is informational and doesn't affect genome functionality
+	ValidationInfo ValidationSeverity = "info"
+	// ValidationWarning the issue may affect genome evolution or network performance, but genome still can be used
+	ValidationWarning ValidationSeverity = "warning"
+	// ValidationError the issue makes genome invalid
+	ValidationError ValidationSeverity = "error"
+)
+
+// rank returns numeric rank of the severity level to compare severities
+func (s ValidationSeverity) rank() int {
+	switch s {
+	case ValidationInfo:
+		return 0
+	case ValidationWarning:
+		return 1
+	case ValidationError:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// ValidationSeverityByName returns the validation severity level with given name or error if not supported
+func ValidationSeverityByName(name string) (ValidationSeverity, error) {
+	s := ValidationSeverity(name)
+	if s.rank() < 0 {
+		return "", fmt.Errorf("unsupported validation severity: %s", name)
+	}
+	return s, nil
+}
+
+// ValidationCode the code of the particular genome validation issue
+type ValidationCode string
+
+// The codes of the genome validation issues
+const (
+	// ValidationNoGenes the genome has no connection genes
+	ValidationNoGenes ValidationCode = "no_genes"
+	// ValidationNoNodes the genome has no nodes
+	ValidationNoNodes ValidationCode = "no_nodes"
+	// ValidationNoTraits the genome has no traits
+	ValidationNoTraits ValidationCode = "no_traits"
+	// ValidationNoSensors the genome has no input or bias nodes
+	ValidationNoSensors ValidationCode = "no_sensors"
+	// ValidationNoOutputs the genome has no output nodes
+	ValidationNoOutputs ValidationCode = "no_outputs"
+	// ValidationNodesOrder the genome nodes are not sorted by ID in ascending order
+	ValidationNodesOrder ValidationCode = "nodes_out_of_order"
+	// ValidationDuplicateNode the genome has several nodes with the same ID
+	ValidationDuplicateNode ValidationCode = "duplicate_node"
+	// ValidationMissingNode the gene refers to the node not included into the genome nodes list
+	ValidationMissingNode ValidationCode = "missing_node"
+	// ValidationMissingLink the gene has no link
+	ValidationMissingLink ValidationCode = "missing_link"
+	// ValidationDuplicateInnovation the genome has several genes with the same innovation number
+	ValidationDuplicateInnovation ValidationCode = "duplicate_innovation"
+	// ValidationDuplicateGene the genome has several genes representing genetically equal links
+	ValidationDuplicateGene ValidationCode = "duplicate_gene"
+	// ValidationGenesOrder the genome genes are not sorted by innovation number in ascending order
+	ValidationGenesOrder ValidationCode = "genes_out_of_order"
+	// ValidationDuplicateTrait the genome has several traits with the same ID
+	ValidationDuplicateTrait ValidationCode = "duplicate_trait"
+	// ValidationMissingTrait the node or link refers to the trait not included into the genome traits list
+	ValidationMissingTrait ValidationCode = "missing_trait"
+	// ValidationOrphanedTrait the trait is not referenced by any node or link of the genome
+	ValidationOrphanedTrait ValidationCode = "orphaned_trait"
+	// ValidationInvalidControlGene the MIMO control gene has no control node or its IO nodes are invalid
+	ValidationInvalidControlGene ValidationCode = "invalid_control_gene"
+	// ValidationUnreachableOutput the output node can not be reached from any sensor node through enabled genes
+	ValidationUnreachableOutput ValidationCode = "unreachable_output"
+	// ValidationRecurrentFlag the recurrent flag of the link is inconsistent with the genome topology
+	ValidationRecurrentFlag ValidationCode = "recurrent_flag_mismatch"
+	// ValidationInvalidMemoryCell the memory cell neuron has wrong number of gate weights
+	ValidationInvalidMemoryCell ValidationCode = "invalid_memory_cell"
+	// ValidationInvalidDelay the link of the gene has negative delay
+	ValidationInvalidDelay ValidationCode = "invalid_delay"
+)
+
+// ValidationIssue the particular issue found during genome validation
+type ValidationIssue struct {
+	// The severity level of the issue
+	Severity ValidationSeverity `json:"severity"`
+	// The code of the issue
+	Code ValidationCode `json:"code"`
+	// The human-readable description of the issue
+	Message string `json:"message"`
+}
+
+// The stringer
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("[%s] %s: %s", strings.ToUpper(string(i.Severity)), i.Code, i.Message)
+}
+
+// ValidationReport the report holding all issues found during genome validation
+type ValidationReport struct {
+	// The ID of the validated genome
+	GenomeId int `json:"genome_id"`
+	// The list of found issues
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// IsValid returns true if no issues with ValidationError severity was found
+func (r *ValidationReport) IsValid() bool {
+	return len(r.IssuesWithSeverity(ValidationError)) == 0
+}
+
+// IssuesWithSeverity returns the list of issues with severity level greater or equal to the provided one
+func (r *ValidationReport) IssuesWithSeverity(severity ValidationSeverity) []ValidationIssue {
+	res := make([]ValidationIssue, 0)
+	for _, i := range r.Issues {
+		if i.Severity.rank() >= severity.rank() {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// Err returns error combining messages of all issues with ValidationError severity or nil if genome is valid
+func (r *ValidationReport) Err() error {
+	errs := r.IssuesWithSeverity(ValidationError)
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message
+	}
+	return fmt.Errorf("genome %d is invalid: %s", r.GenomeId, strings.Join(messages, "; "))
+}
+
+// WriteJSON is to write this report as JSON into provided writer
+func (r *ValidationReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteReport is to write human-readable report with issues of severity level greater or equal to the provided one
+func (r *ValidationReport) WriteReport(w io.Writer, severity ValidationSeverity) error {
+	issues := r.IssuesWithSeverity(severity)
+	status := "VALID"
+	if !r.IsValid() {
+		status = "INVALID"
+	}
+	if _, err := fmt.Fprintf(w, "GENOME %d: %s, issues: %d\n", r.GenomeId, status, len(issues)); err != nil {
+		return err
+	}
+	for _, i := range issues {
+		if _, err := fmt.Fprintf(w, "\t%s\n", i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ValidationReport) add(severity ValidationSeverity, code ValidationCode, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate is to validate this genome collecting all found issues into the report. Unlike the fail-fast
+// verification, all checks are performed regardless of already found issues.
+func (g *Genome) Validate() *ValidationReport {
+	r := &ValidationReport{GenomeId: g.Id, Issues: make([]ValidationIssue, 0)}
+
+	g.validateNodes(r)
+	g.validateGenes(r)
+	g.validateTraits(r)
+	g.validateControlGenes(r)
+	g.validateTopology(r)
+
+	return r
+}
+
+func (g *Genome) validateNodes(r *ValidationReport) {
+	if len(g.Nodes) == 0 {
+		r.add(ValidationError, ValidationNoNodes, "genome has no nodes")
+		return
+	}
+	sensors, outputs := 0, 0
+	ids := make(map[int]bool, len(g.Nodes))
+	lastId := 0
+	for _, n := range g.Nodes {
+		if ids[n.Id] {
+			r.add(ValidationError, ValidationDuplicateNode, "duplicate node ID: %d", n.Id)
+		}
+		ids[n.Id] = true
+		if n.Id < lastId {
+			r.add(ValidationError, ValidationNodesOrder, "node %d is out of order after node %d", n.Id, lastId)
+		}
+		lastId = n.Id
+
+		if n.IsSensor() {
+			sensors++
+		} else if n.NeuronType == network.OutputNeuron {
+			outputs++
+		} else if n.NeuronType == network.MemoryNeuron && len(n.GateWeights) != network.MemoryCellGateWeightsCount {
+			r.add(ValidationError, ValidationInvalidMemoryCell, "memory cell node %d has %d gate weights instead of %d",
+				n.Id, len(n.GateWeights), network.MemoryCellGateWeightsCount)
+		}
+	}
+	if sensors == 0 {
+		r.add(ValidationError, ValidationNoSensors, "genome has no input or bias nodes")
+	}
+	if outputs == 0 {
+		r.add(ValidationError, ValidationNoOutputs, "genome has no output nodes")
+	}
+}
+
+func (g *Genome) validateGenes(r *ValidationReport) {
+	if len(g.Genes) == 0 {
+		r.add(ValidationError, ValidationNoGenes, "genome has no genes")
+		return
+	}
+	innovations := make(map[int64]bool, len(g.Genes))
+	var lastInnovation int64
+	for i, gn := range g.Genes {
+		if innovations[gn.InnovationNum] {
+			r.add(ValidationError, ValidationDuplicateInnovation, "duplicate innovation number: %d", gn.InnovationNum)
+		}
+		innovations[gn.InnovationNum] = true
+		if i > 0 && gn.InnovationNum < lastInnovation {
+			r.add(ValidationWarning, ValidationGenesOrder, "gene with innovation %d is out of order after innovation %d",
+				gn.InnovationNum, lastInnovation)
+		}
+		lastInnovation = gn.InnovationNum
+
+		if gn.Link == nil {
+			r.add(ValidationError, ValidationMissingLink, "gene with innovation %d has no link", gn.InnovationNum)
+			continue
+		}
+		if gn.Link.InNode == nil || NodeWithId(gn.Link.InNode.Id, g.Nodes) == nil {
+			r.add(ValidationError, ValidationMissingNode, "missing input node of gene with innovation %d",
+				gn.InnovationNum)
+		}
+		if gn.Link.OutNode == nil || NodeWithId(gn.Link.OutNode.Id, g.Nodes) == nil {
+			r.add(ValidationError, ValidationMissingNode, "missing output node of gene with innovation %d",
+				gn.InnovationNum)
+		}
+		if gn.Link.Delay < 0 {
+			r.add(ValidationError, ValidationInvalidDelay, "gene with innovation %d has negative delay: %d",
+				gn.InnovationNum, gn.Link.Delay)
+		}
+	}
+
+	// check for genetically duplicate genes
+	for i, gn := range g.Genes {
+		if gn.Link == nil || gn.Link.InNode == nil || gn.Link.OutNode == nil {
+			continue
+		}
+		for _, gn2 := range g.Genes[i+1:] {
+			if gn2.Link != nil && gn2.Link.InNode != nil && gn2.Link.OutNode != nil &&
+				gn.Link.IsEqualGenetically(gn2.Link) {
+				r.add(ValidationError, ValidationDuplicateGene, "duplicate genes with innovations %d and %d",
+					gn.InnovationNum, gn2.InnovationNum)
+			}
+		}
+	}
+}
+
+func (g *Genome) validateTraits(r *ValidationReport) {
+	if len(g.Traits) == 0 {
+		r.add(ValidationError, ValidationNoTraits, "genome has no traits")
+	}
+	ids := make(map[int]bool, len(g.Traits))
+	for _, t := range g.Traits {
+		if ids[t.Id] {
+			r.add(ValidationError, ValidationDuplicateTrait, "duplicate trait ID: %d", t.Id)
+		}
+		ids[t.Id] = true
+	}
+
+	used := make(map[int]bool)
+	checkTrait := func(t *neat.Trait, owner string) {
+		if t == nil {
+			return
+		}
+		used[t.Id] = true
+		if !ids[t.Id] {
+			r.add(ValidationError, ValidationMissingTrait, "%s refers to missing trait: %d", owner, t.Id)
+		}
+	}
+	for _, n := range g.Nodes {
+		checkTrait(n.Trait, fmt.Sprintf("node %d", n.Id))
+	}
+	for _, gn := range g.Genes {
+		if gn.Link != nil {
+			checkTrait(gn.Link.Trait, fmt.Sprintf("gene with innovation %d", gn.InnovationNum))
+		}
+	}
+	for _, t := range g.Traits {
+		if !used[t.Id] {
+			r.add(ValidationInfo, ValidationOrphanedTrait, "trait %d is not referenced by any node or gene", t.Id)
+		}
+	}
+}
+
+func (g *Genome) validateControlGenes(r *ValidationReport) {
+	innovations := make(map[int64]bool, len(g.ControlGenes))
+	for _, cg := range g.ControlGenes {
+		if innovations[cg.InnovationNum] {
+			r.add(ValidationError, ValidationDuplicateInnovation, "duplicate control gene innovation number: %d",
+				cg.InnovationNum)
+		}
+		innovations[cg.InnovationNum] = true
+
+		if cg.ControlNode == nil {
+			r.add(ValidationError, ValidationInvalidControlGene, "control gene with innovation %d has no control node",
+				cg.InnovationNum)
+			continue
+		}
+		cnId := cg.ControlNode.Id
+		if NodeWithId(cnId, g.Nodes) != nil {
+			r.add(ValidationError, ValidationInvalidControlGene, "control node %d duplicates ID of genome node", cnId)
+		}
+		if len(cg.ControlNode.Incoming) == 0 {
+			r.add(ValidationError, ValidationInvalidControlGene, "control node %d has no input nodes", cnId)
+		}
+		if len(cg.ControlNode.Outgoing) == 0 {
+			r.add(ValidationError, ValidationInvalidControlGene, "control node %d has no output nodes", cnId)
+		}
+		for _, l := range cg.ControlNode.Incoming {
+			if l.InNode == nil || NodeWithId(l.InNode.Id, g.Nodes) == nil {
+				r.add(ValidationError, ValidationInvalidControlGene, "control node %d refers to missing input node",
+					cnId)
+			}
+		}
+		for _, l := range cg.ControlNode.Outgoing {
+			if l.OutNode == nil || NodeWithId(l.OutNode.Id, g.Nodes) == nil {
+				r.add(ValidationError, ValidationInvalidControlGene, "control node %d refers to missing output node",
+					cnId)
+			}
+		}
+	}
+}
+
+// validateTopology checks reachability of the output nodes and consistency of the recurrent flags of the links
+// considering only enabled genes.
+func (g *Genome) validateTopology(r *ValidationReport) {
+	// build adjacency lists: all enabled links and only forward (not recurrent) links, the time-delayed links relay
+	// the lagged signals similar to the recurrent links
+	all := make(map[int][]int)
+	forward := make(map[int][]int)
+	for _, gn := range g.Genes {
+		if !gn.IsEnabled || gn.Link == nil || gn.Link.InNode == nil || gn.Link.OutNode == nil {
+			continue
+		}
+		in, out := gn.Link.InNode.Id, gn.Link.OutNode.Id
+		all[in] = append(all[in], out)
+		if !gn.Link.IsRecurrent && gn.Link.Delay == 0 {
+			forward[in] = append(forward[in], out)
+		}
+	}
+	for _, cg := range g.ControlGenes {
+		if !cg.IsEnabled || cg.ControlNode == nil {
+			continue
+		}
+		// the control node passes signals from its inputs to its outputs
+		for _, inLink := range cg.ControlNode.Incoming {
+			for _, outLink := range cg.ControlNode.Outgoing {
+				if inLink.InNode != nil && outLink.OutNode != nil {
+					all[inLink.InNode.Id] = append(all[inLink.InNode.Id], outLink.OutNode.Id)
+					forward[inLink.InNode.Id] = append(forward[inLink.InNode.Id], outLink.OutNode.Id)
+				}
+			}
+		}
+	}
+
+	// check that all outputs are reachable from sensors
+	sensors := make([]int, 0)
+	for _, n := range g.Nodes {
+		if n.IsSensor() {
+			sensors = append(sensors, n.Id)
+		}
+	}
+	reachable := reachableNodes(all, sensors...)
+	for _, n := range g.Nodes {
+		if n.NeuronType == network.OutputNeuron && !reachable[n.Id] {
+			r.add(ValidationWarning, ValidationUnreachableOutput,
+				"output node %d is not reachable from any sensor node", n.Id)
+		}
+	}
+
+	// check recurrent flags
+	for _, gn := range g.Genes {
+		if !gn.IsEnabled || gn.Link == nil || gn.Link.InNode == nil || gn.Link.OutNode == nil {
+			continue
+		}
+		in, out := gn.Link.InNode.Id, gn.Link.OutNode.Id
+		if gn.Link.IsRecurrent {
+			if in != out && !reachableNodes(all, out)[in] {
+				r.add(ValidationWarning, ValidationRecurrentFlag,
+					"link of gene with innovation %d is marked recurrent, but doesn't close any loop", gn.InnovationNum)
+			}
+		} else if gn.Link.Delay == 0 && (in == out || reachableNodes(forward, out)[in]) {
+			r.add(ValidationWarning, ValidationRecurrentFlag,
+				"link of gene with innovation %d closes the loop, but not marked recurrent", gn.InnovationNum)
+		}
+	}
+}
+
+// reachableNodes returns the set of node IDs reachable from the provided start nodes using adjacency lists
+func reachableNodes(adjacency map[int][]int, start ...int) map[int]bool {
+	visited := make(map[int]bool)
+	stack := append([]int{}, start...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		stack = append(stack, adjacency[id]...)
+	}
+	return visited
+}
@@ -0,0 +1,215 @@
+package genetics
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"testing"
+)
+
+func issueCodes(r *ValidationReport) []ValidationCode {
+	codes := make([]ValidationCode, len(r.Issues))
+	for i, issue := range r.Issues {
+		codes[i] = issue.Code
+	}
+	return codes
+}
+
+func TestGenome_Validate(t *testing.T) {
+	gnome := buildTestGenome(1)
+	report := gnome.Validate()
+	assert.Equal(t, 1, report.GenomeId)
+	assert.Empty(t, report.Issues)
+	assert.True(t, report.IsValid())
+	assert.NoError(t, report.Err())
+
+	gnome = buildTestModularGenome(2)
+	report = gnome.Validate()
+	assert.True(t, report.IsValid(), report.Issues)
+}
+
+func TestGenome_Validate_collectsAllIssues(t *testing.T) {
+	gnome := buildTestGenome(1)
+	// missing input and output nodes
+	gnome.Genes = append(gnome.Genes, NewGene(1.0, network.NewNNode(100, network.InputNeuron),
+		network.NewNNode(400, network.OutputNeuron), false, 4, 1.0))
+	// duplicate innovation and genetically duplicate gene
+	gnome.Genes = append(gnome.Genes, NewConnectionGene(
+		network.NewLinkWithTrait(gnome.Traits[0], 1.0, gnome.Nodes[0], gnome.Nodes[3], false), 1, 0, true))
+	// orphaned trait
+	gnome.Traits = append(gnome.Traits, &neat.Trait{Id: 4, Params: make([]float64, 8)})
+	// missing trait
+	gnome.Nodes[3].Trait = &neat.Trait{Id: 10}
+
+	report := gnome.Validate()
+	assert.False(t, report.IsValid())
+	codes := issueCodes(report)
+	assert.Contains(t, codes, ValidationMissingNode)
+	assert.Contains(t, codes, ValidationDuplicateInnovation)
+	assert.Contains(t, codes, ValidationDuplicateGene)
+	assert.Contains(t, codes, ValidationGenesOrder)
+	assert.Contains(t, codes, ValidationOrphanedTrait)
+	assert.Contains(t, codes, ValidationMissingTrait)
+
+	errs := report.IssuesWithSeverity(ValidationError)
+	assert.Len(t, errs, 5)
+	warnings := report.IssuesWithSeverity(ValidationWarning)
+	assert.Len(t, warnings, 6)
+	assert.Len(t, report.IssuesWithSeverity(ValidationInfo), len(report.Issues))
+
+	err := report.Err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "genome 1 is invalid: missing input node of gene with innovation 4")
+}
+
+func TestGenome_Validate_emptyGenome(t *testing.T) {
+	gnome := NewGenome(1, nil, nil, nil)
+	report := gnome.Validate()
+	assert.Equal(t, []ValidationCode{ValidationNoNodes, ValidationNoGenes, ValidationNoTraits}, issueCodes(report))
+}
+
+func TestGenome_Validate_nodes(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Nodes[0], gnome.Nodes[1] = gnome.Nodes[1], gnome.Nodes[0]
+	gnome.Nodes = append(gnome.Nodes, gnome.Nodes[3])
+
+	report := gnome.Validate()
+	assert.Equal(t, []ValidationCode{ValidationNodesOrder, ValidationDuplicateNode}, issueCodes(report))
+
+	// no outputs and sensors
+	gnome = buildTestGenome(1)
+	gnome.Nodes[0].NeuronType = network.HiddenNeuron
+	gnome.Nodes[1].NeuronType = network.HiddenNeuron
+	gnome.Nodes[2].NeuronType = network.HiddenNeuron
+	gnome.Nodes[3].NeuronType = network.HiddenNeuron
+	report = gnome.Validate()
+	assert.Equal(t, []ValidationCode{ValidationNoSensors, ValidationNoOutputs}, issueCodes(report))
+}
+
+func TestGenome_Validate_memoryCell(t *testing.T) {
+	gnome := buildTestMemoryGenome(1)
+	report := gnome.Validate()
+	assert.Empty(t, issueCodes(report))
+
+	gnome.Nodes[4].GateWeights = nil
+	report = gnome.Validate()
+	assert.Equal(t, []ValidationCode{ValidationInvalidMemoryCell}, issueCodes(report))
+}
+
+func TestGenome_Validate_delay(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Genes[0].Link.Delay = 2
+	report := gnome.Validate()
+	assert.Empty(t, issueCodes(report))
+
+	gnome.Genes[0].Link.Delay = -1
+	report = gnome.Validate()
+	assert.Equal(t, []ValidationCode{ValidationInvalidDelay}, issueCodes(report))
+}
+
+func TestGenome_Validate_controlGenes(t *testing.T) {
+	gnome := buildTestModularGenome(1)
+	controlNode := network.NewNNode(9, network.HiddenNeuron)
+	controlNode.Incoming = append(controlNode.Incoming, network.NewLink(1.0, network.NewNNode(100, network.HiddenNeuron), controlNode, false))
+	gnome.ControlGenes = append(gnome.ControlGenes, NewMIMOGene(controlNode, gnome.ControlGenes[0].InnovationNum, 1.0, true))
+
+	report := gnome.Validate()
+	assert.False(t, report.IsValid())
+	assert.Equal(t, []ValidationCode{
+		ValidationDuplicateInnovation,
+		ValidationInvalidControlGene, // no output nodes
+		ValidationInvalidControlGene, // missing input node
+	}, issueCodes(report))
+}
+
+func TestGenome_Validate_topology(t *testing.T) {
+	gnome := buildTestGenome(1)
+	// disable all links to the output
+	for _, gn := range gnome.Genes {
+		gn.IsEnabled = false
+	}
+	report := gnome.Validate()
+	assert.True(t, report.IsValid())
+	assert.Equal(t, []ValidationCode{ValidationUnreachableOutput}, issueCodes(report))
+
+	// the spurious recurrent flag and the missing recurrent flag for the loop
+	gnome = buildTestGenome(1)
+	gnome.Genes[0].Link.IsRecurrent = true
+	gnome.Genes = append(gnome.Genes, NewConnectionGene(
+		network.NewLinkWithTrait(gnome.Traits[0], 1.0, gnome.Nodes[3], gnome.Nodes[3], false), 4, 0, true))
+	report = gnome.Validate()
+	assert.True(t, report.IsValid())
+	assert.Equal(t, []ValidationCode{ValidationRecurrentFlag, ValidationRecurrentFlag}, issueCodes(report))
+}
+
+func TestGenome_Validate_topology_delayedLoop(t *testing.T) {
+	// the loop closed through the time-delayed links is not required to be marked recurrent
+	gnome := buildTestGenome(1)
+	hidden := network.NewNNode(5, network.HiddenNeuron)
+	gnome.Nodes = append(gnome.Nodes, hidden)
+	output := gnome.Nodes[3]
+	delayed := network.NewLinkWithTrait(gnome.Traits[0], 1.0, output, hidden, false)
+	delayed.Delay = 2
+	selfDelayed := network.NewLinkWithTrait(gnome.Traits[0], 0.5, output, output, false)
+	selfDelayed.Delay = 1
+	gnome.Genes = append(gnome.Genes,
+		NewConnectionGene(delayed, 4, 0, true),
+		NewConnectionGene(network.NewLinkWithTrait(gnome.Traits[0], 1.0, hidden, output, false), 5, 0, true),
+		NewConnectionGene(selfDelayed, 6, 0, true))
+	report := gnome.Validate()
+	assert.True(t, report.IsValid())
+	assert.Empty(t, issueCodes(report))
+
+	// without delay the loop is reported
+	delayed.Delay = 0
+	report = gnome.Validate()
+	assert.Equal(t, []ValidationCode{ValidationRecurrentFlag, ValidationRecurrentFlag}, issueCodes(report))
+}
+
+func TestValidationReport_WriteReport(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Traits = append(gnome.Traits, &neat.Trait{Id: 4, Params: make([]float64, 8)})
+	report := gnome.Validate()
+
+	b := bytes.NewBufferString("")
+	err := report.WriteReport(b, ValidationInfo)
+	require.NoError(t, err)
+	assert.Equal(t, "GENOME 1: VALID, issues: 1\n\t[INFO] orphaned_trait: trait 4 is not referenced by any node or gene\n", b.String())
+
+	b = bytes.NewBufferString("")
+	err = report.WriteReport(b, ValidationWarning)
+	require.NoError(t, err)
+	assert.Equal(t, "GENOME 1: VALID, issues: 0\n", b.String())
+
+	errWriter := ErrorWriter(1)
+	err = report.WriteReport(&errWriter, ValidationInfo)
+	assert.EqualError(t, err, alwaysErrorText)
+}
+
+func TestValidationReport_WriteJSON(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Genes = append(gnome.Genes, NewGene(1.0, network.NewNNode(100, network.InputNeuron),
+		network.NewNNode(4, network.OutputNeuron), false, 4, 1.0))
+	report := gnome.Validate()
+
+	b := bytes.NewBufferString("")
+	err := report.WriteJSON(b)
+	require.NoError(t, err)
+
+	var decoded ValidationReport
+	err = json.Unmarshal(b.Bytes(), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, *report, decoded)
+}
+
+func TestValidationSeverityByName(t *testing.T) {
+	s, err := ValidationSeverityByName("warning")
+	assert.NoError(t, err)
+	assert.Equal(t, ValidationWarning, s)
+
+	_, err = ValidationSeverityByName("fatal")
+	assert.EqualError(t, err, "unsupported validation severity: fatal")
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/yaricom/goNEAT/v3/neat/network"
 	"gopkg.in/yaml.v3"
 	"io"
+	"strconv"
+	"strings"
 )
 
 // GenomeWriter is the interface to define genome writer
@@ -54,7 +56,7 @@ func (wr *plainGenomeWriter) WriteGenome(g *Genome) error {
 		if _, err := fmt.Fprint(wr.w, "node "); err != nil {
 			return err
 		}
-		if err := wr.writeNetworkNode(nd); err != nil {
+		if err := wr.writeNetworkNode(nd, g.Activators()); err != nil {
 			return err
 		}
 		if _, err := fmt.Fprintln(wr.w, ""); err != nil {
@@ -73,6 +75,17 @@ func (wr *plainGenomeWriter) WriteGenome(g *Genome) error {
 			return err
 		}
 	}
+	for _, cg := range g.ControlGenes {
+		if _, err := fmt.Fprint(wr.w, "module "); err != nil {
+			return err
+		}
+		if err := wr.writeControlGene(cg, g.Activators()); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(wr.w, ""); err != nil {
+			return err
+		}
+	}
 	if _, err := fmt.Fprintf(wr.w, "genomeend %d\n", g.Id); err != nil {
 		return err
 	}
@@ -101,16 +114,32 @@ func (wr *plainGenomeWriter) writeTrait(t *neat.Trait) error {
 }
 
 // Dump network node in plain text format
-func (wr *plainGenomeWriter) writeNetworkNode(n *network.NNode) error {
+func (wr *plainGenomeWriter) writeNetworkNode(n *network.NNode, activators *math.NodeActivatorsFactory) error {
 	traitId := 0
 	if n.Trait != nil {
 		traitId = n.Trait.Id
 	}
-	actStr, err := math.NodeActivators.ActivationNameFromType(n.ActivationType)
+	actStr, err := activators.ActivationNameFromType(n.ActivationType)
 	if err == nil {
 		_, err = fmt.Fprintf(wr.w, "%d %d %d %d %s", n.Id, traitId, n.NodeType(),
 			n.NeuronType, actStr)
 	}
+	if err == nil && (n.TimeConstant > 0 || n.GateWeights != nil || n.Bias != 0 || n.Response != nil) {
+		// the optional time constant
+		_, err = fmt.Fprintf(wr.w, " %g", n.TimeConstant)
+	}
+	for i := 0; err == nil && i < len(n.GateWeights); i++ {
+		// the optional gate weights of the memory cell
+		_, err = fmt.Fprintf(wr.w, " %g", n.GateWeights[i])
+	}
+	if err == nil && n.Bias != 0 {
+		// the optional bias
+		_, err = fmt.Fprintf(wr.w, " %s%g", plainNodeBiasKey, n.Bias)
+	}
+	if err == nil && n.Response != nil {
+		// the optional response
+		_, err = fmt.Fprintf(wr.w, " %s%g", plainNodeResponseKey, *n.Response)
+	}
 	return err
 }
 
@@ -131,6 +160,34 @@ func (wr *plainGenomeWriter) writeConnectionGene(g *Gene) error {
 
 	_, err := fmt.Fprintf(wr.w, "%d %d %d %g %t %d %g %t",
 		traitId, inNodeId, outNodeId, weight, recurrent, innovNum, mutNum, enabled)
+	if err == nil && link.Delay > 0 {
+		// the optional delay
+		_, err = fmt.Fprintf(wr.w, " %d", link.Delay)
+	}
+	return err
+}
+
+// Dump MIMO control gene in plain text format: the control node ID, trait ID, activation, innovation number,
+// mutation number, enabled flag, followed by the comma separated IDs of the input and output nodes
+func (wr *plainGenomeWriter) writeControlGene(g *MIMOControlGene, activators *math.NodeActivatorsFactory) error {
+	traitId := 0
+	if g.ControlNode.Trait != nil {
+		traitId = g.ControlNode.Trait.Id
+	}
+	actStr, err := activators.ActivationNameFromType(g.ControlNode.ActivationType)
+	if err != nil {
+		return err
+	}
+	inputs := make([]string, len(g.ControlNode.Incoming))
+	for i, in := range g.ControlNode.Incoming {
+		inputs[i] = strconv.Itoa(in.InNode.Id)
+	}
+	outputs := make([]string, len(g.ControlNode.Outgoing))
+	for i, out := range g.ControlNode.Outgoing {
+		outputs[i] = strconv.Itoa(out.OutNode.Id)
+	}
+	_, err = fmt.Fprintf(wr.w, "%d %d %s %d %g %t %s %s", g.ControlNode.Id, traitId, actStr, g.InnovationNum,
+		g.MutationNum, g.IsEnabled, strings.Join(inputs, ","), strings.Join(outputs, ","))
 	return err
 }
 
@@ -153,7 +210,7 @@ func (wr *yamlGenomeWriter) WriteGenome(g *Genome) (err error) {
 	// encode network nodes
 	nodes := make([]map[string]interface{}, len(g.Nodes))
 	for i, n := range g.Nodes {
-		nodes[i], err = wr.encodeNetworkNode(n)
+		nodes[i], err = wr.encodeNetworkNode(n, g.Activators())
 		if err != nil {
 			return err
 		}
@@ -171,7 +228,7 @@ func (wr *yamlGenomeWriter) WriteGenome(g *Genome) (err error) {
 	if len(g.ControlGenes) > 0 {
 		modules := make([]map[string]interface{}, len(g.ControlGenes))
 		for i, cg := range g.ControlGenes {
-			modules[i], err = wr.encodeControlGene(cg)
+			modules[i], err = wr.encodeControlGene(cg, g.Activators())
 			if err != nil {
 				return err
 			}
@@ -194,7 +251,7 @@ func (wr *yamlGenomeWriter) WriteGenome(g *Genome) (err error) {
 	return err
 }
 
-func (wr *yamlGenomeWriter) encodeControlGene(gene *MIMOControlGene) (gMap map[string]interface{}, err error) {
+func (wr *yamlGenomeWriter) encodeControlGene(gene *MIMOControlGene, activators *math.NodeActivatorsFactory) (gMap map[string]interface{}, err error) {
 	gMap = make(map[string]interface{})
 	gMap["id"] = gene.ControlNode.Id
 	if gene.ControlNode.Trait != nil {
@@ -205,7 +262,7 @@ func (wr *yamlGenomeWriter) encodeControlGene(gene *MIMOControlGene) (gMap map[s
 	gMap["innov_num"] = gene.InnovationNum
 	gMap["mut_num"] = gene.MutationNum
 	gMap["enabled"] = gene.IsEnabled
-	gMap["activation"], err = math.NodeActivators.ActivationNameFromType(gene.ControlNode.ActivationType)
+	gMap["activation"], err = activators.ActivationNameFromType(gene.ControlNode.ActivationType)
 	if err != nil {
 		return nil, err
 	}
@@ -247,10 +304,13 @@ func (wr *yamlGenomeWriter) encodeConnectionGene(gene *Gene) map[string]interfac
 	gMap["mut_num"] = gene.MutationNum
 	gMap["recurrent"] = gene.Link.IsRecurrent
 	gMap["enabled"] = gene.IsEnabled
+	if gene.Link.Delay > 0 {
+		gMap["delay"] = gene.Link.Delay
+	}
 	return gMap
 }
 
-func (wr *yamlGenomeWriter) encodeNetworkNode(node *network.NNode) (nMap map[string]interface{}, err error) {
+func (wr *yamlGenomeWriter) encodeNetworkNode(node *network.NNode, activators *math.NodeActivatorsFactory) (nMap map[string]interface{}, err error) {
 	nMap = make(map[string]interface{})
 	nMap["id"] = node.Id
 	if node.Trait != nil {
@@ -259,7 +319,19 @@ func (wr *yamlGenomeWriter) encodeNetworkNode(node *network.NNode) (nMap map[str
 		nMap["trait_id"] = 0
 	}
 	nMap["type"] = network.NeuronTypeName(node.NeuronType)
-	nMap["activation"], err = math.NodeActivators.ActivationNameFromType(node.ActivationType)
+	if node.TimeConstant > 0 {
+		nMap["time_constant"] = node.TimeConstant
+	}
+	if node.GateWeights != nil {
+		nMap["gate_weights"] = node.GateWeights
+	}
+	if node.Bias != 0 {
+		nMap["bias"] = node.Bias
+	}
+	if node.Response != nil {
+		nMap["response"] = *node.Response
+	}
+	nMap["activation"], err = activators.ActivationNameFromType(node.ActivationType)
 	return nMap, err
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/math"
 	"github.com/yaricom/goNEAT/v3/neat/network"
 	"strings"
 	"testing"
@@ -54,7 +55,7 @@ func TestPlainGenomeWriter_WriteNetworkNode(t *testing.T) {
 	outBuffer := bytes.NewBufferString("")
 
 	wr := plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
-	err := wr.writeNetworkNode(node)
+	err := wr.writeNetworkNode(node, math.NodeActivators)
 	require.NoError(t, err, "failed to write network node")
 	err = wr.w.Flush()
 	require.NoError(t, err)
@@ -63,10 +64,126 @@ func TestPlainGenomeWriter_WriteNetworkNode(t *testing.T) {
 	assert.Equal(t, nodeStr, outStr, "Node serialization failed")
 }
 
+func TestPlainGenomeWriter_WriteNetworkNode_timeConstant(t *testing.T) {
+	node := network.NewNNode(4, network.HiddenNeuron)
+	node.TimeConstant = 2.5
+	outBuffer := bytes.NewBufferString("")
+
+	wr := plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err := wr.writeNetworkNode(node, math.NodeActivators)
+	require.NoError(t, err, "failed to write network node")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, "4 0 0 0 SigmoidSteepenedActivation 2.5", outBuffer.String())
+
+	// read it back
+	nodeRead, err := readPlainNetworkNode(strings.NewReader(outBuffer.String()), nil, math.NodeActivators)
+	require.NoError(t, err, "failed to read network node")
+	assert.Equal(t, node.TimeConstant, nodeRead.TimeConstant)
+}
+
+func TestPlainGenomeWriter_WriteNetworkNode_memoryCell(t *testing.T) {
+	node := network.NewNNode(5, network.MemoryNeuron)
+	node.GateWeights = []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	outBuffer := bytes.NewBufferString("")
+
+	wr := plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err := wr.writeNetworkNode(node, math.NodeActivators)
+	require.NoError(t, err, "failed to write network node")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, "5 0 0 4 SigmoidSteepenedActivation 0 0.1 0.2 0.3 0.4 0.5 0.6 0.7 0.8 0.9", outBuffer.String())
+
+	// read it back
+	nodeRead, err := readPlainNetworkNode(strings.NewReader(outBuffer.String()), nil, math.NodeActivators)
+	require.NoError(t, err, "failed to read network node")
+	assert.Equal(t, network.MemoryNeuron, nodeRead.NeuronType)
+	assert.Zero(t, nodeRead.TimeConstant)
+	assert.Equal(t, node.GateWeights, nodeRead.GateWeights)
+}
+
+func TestPlainGenomeWriter_WriteNetworkNode_biasGenes(t *testing.T) {
+	node := network.NewNNode(4, network.HiddenNeuron)
+	node.Bias = -0.5
+	node.SetResponse(2)
+	outBuffer := bytes.NewBufferString("")
+
+	wr := plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err := wr.writeNetworkNode(node, math.NodeActivators)
+	require.NoError(t, err, "failed to write network node")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, "4 0 0 0 SigmoidSteepenedActivation 0 bias=-0.5 response=2", outBuffer.String())
+
+	// read it back
+	nodeRead, err := readPlainNetworkNode(strings.NewReader(outBuffer.String()), nil, math.NodeActivators)
+	require.NoError(t, err, "failed to read network node")
+	assert.Zero(t, nodeRead.TimeConstant)
+	assert.Equal(t, node.Bias, nodeRead.Bias)
+	assert.Equal(t, node.Response, nodeRead.Response)
+
+	// memory cell with gate weights and bias genes
+	memory := network.NewNNode(5, network.MemoryNeuron)
+	memory.GateWeights = []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	memory.Bias = 0.25
+	outBuffer = bytes.NewBufferString("")
+	wr = plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err = wr.writeNetworkNode(memory, math.NodeActivators)
+	require.NoError(t, err, "failed to write network node")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+
+	nodeRead, err = readPlainNetworkNode(strings.NewReader(outBuffer.String()), nil, math.NodeActivators)
+	require.NoError(t, err, "failed to read network node")
+	assert.Equal(t, memory.GateWeights, nodeRead.GateWeights)
+	assert.Equal(t, memory.Bias, nodeRead.Bias)
+	assert.Nil(t, nodeRead.Response)
+
+	// memory cell without gate weights, but with bias genes
+	memory.GateWeights = nil
+	memory.SetResponse(0)
+	outBuffer = bytes.NewBufferString("")
+	wr = plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err = wr.writeNetworkNode(memory, math.NodeActivators)
+	require.NoError(t, err, "failed to write network node")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, "5 0 0 4 SigmoidSteepenedActivation 0 bias=0.25 response=0", outBuffer.String())
+
+	nodeRead, err = readPlainNetworkNode(strings.NewReader(outBuffer.String()), nil, math.NodeActivators)
+	require.NoError(t, err, "failed to read network node")
+	assert.Nil(t, nodeRead.GateWeights)
+	assert.Equal(t, memory.Bias, nodeRead.Bias)
+	assert.Equal(t, memory.Response, nodeRead.Response)
+
+	// the unknown values
+	_, err = readPlainNetworkNode(strings.NewReader("4 0 0 0 SigmoidSteepenedActivation 0 -0.5 2"), nil, math.NodeActivators)
+	assert.Error(t, err, "positional bias genes are not supported")
+	_, err = readPlainNetworkNode(strings.NewReader("4 0 0 0 SigmoidSteepenedActivation 0 bias=x"), nil, math.NodeActivators)
+	assert.Error(t, err, "wrong bias")
+}
+
+func TestPlainGenomeWriter_WriteNetworkNode_modulatory(t *testing.T) {
+	node := network.NewNNode(5, network.ModulatoryNeuron)
+	outBuffer := bytes.NewBufferString("")
+
+	wr := plainGenomeWriter{w: bufio.NewWriter(outBuffer)}
+	err := wr.writeNetworkNode(node, math.NodeActivators)
+	require.NoError(t, err, "failed to write network node")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, "5 0 0 5 SigmoidSteepenedActivation", outBuffer.String())
+
+	// read it back
+	nodeRead, err := readPlainNetworkNode(strings.NewReader(outBuffer.String()), nil, math.NodeActivators)
+	require.NoError(t, err, "failed to read network node")
+	assert.Equal(t, network.ModulatoryNeuron, nodeRead.NeuronType)
+}
+
 func TestPlainGenomeWriter_WriteNetworkNode_writeError(t *testing.T) {
 	errorWriter := ErrorWriter(1)
 	wr := plainGenomeWriter{w: bufio.NewWriterSize(&errorWriter, 1)}
-	err := wr.writeNetworkNode(network.NewNNode(1, network.InputNeuron))
+	err := wr.writeNetworkNode(network.NewNNode(1, network.InputNeuron), math.NodeActivators)
 	assert.EqualError(t, err, alwaysErrorText)
 }
 
@@ -96,6 +213,20 @@ func TestPlainGenomeWriter_WriteConnectionGene(t *testing.T) {
 	assert.Equal(t, geneStr, outStr, "Wrong Gene serialization")
 }
 
+func TestPlainGenomeWriter_WriteConnectionGene_delay(t *testing.T) {
+	gene := NewGeneWithTrait(neat.NewTrait(), 0.5, network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(4, network.HiddenNeuron), false, 1, 0.5)
+	gene.Link.Delay = 3
+
+	outBuf := bytes.NewBufferString("")
+	wr := plainGenomeWriter{w: bufio.NewWriter(outBuf)}
+	err := wr.writeConnectionGene(gene)
+	require.NoError(t, err, "failed to write connection gene")
+	err = wr.w.Flush()
+	require.NoError(t, err)
+	assert.Equal(t, "0 1 4 0.5 false 1 0.5 true 3", outBuf.String())
+}
+
 func TestPlainGenomeWriter_WriteConnectionGene_writeError(t *testing.T) {
 	errorWriter := ErrorWriter(1)
 	wr := plainGenomeWriter{w: bufio.NewWriterSize(&errorWriter, 1)}
@@ -201,6 +332,110 @@ func TestYamlGenomeWriter_WriteGenome(t *testing.T) {
 	}
 }
 
+func TestYamlGenomeWriter_WriteGenome_timeConstant(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Nodes[3].TimeConstant = 0.25
+
+	outBuf := bytes.NewBufferString("")
+	wr, err := NewGenomeWriter(bufio.NewWriter(outBuf), YAMLGenomeEncoding)
+	require.NoError(t, err)
+	err = wr.WriteGenome(gnome)
+	require.NoError(t, err, "failed to write genome")
+	assert.Equal(t, 1, strings.Count(outBuf.String(), "time_constant:"), "only set time constant must be written")
+
+	enc := yamlGenomeReader{r: bufio.NewReader(bytes.NewBuffer(outBuf.Bytes()))}
+	gnomeEnc, err := enc.Read()
+	require.NoError(t, err, "failed to read genome")
+	require.Len(t, gnomeEnc.Nodes, len(gnome.Nodes))
+	for i, node := range gnome.Nodes {
+		assert.Equal(t, node.TimeConstant, gnomeEnc.Nodes[i].TimeConstant, "at: %d", i)
+	}
+}
+
+func TestYamlGenomeWriter_WriteGenome_biasGenes(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Nodes[3].Bias = -1.5
+	gnome.Nodes[3].SetResponse(0.5)
+
+	outBuf := bytes.NewBufferString("")
+	wr, err := NewGenomeWriter(bufio.NewWriter(outBuf), YAMLGenomeEncoding)
+	require.NoError(t, err)
+	err = wr.WriteGenome(gnome)
+	require.NoError(t, err, "failed to write genome")
+	assert.Equal(t, 1, strings.Count(outBuf.String(), "bias:"), "only set bias must be written")
+	assert.Equal(t, 1, strings.Count(outBuf.String(), "response:"), "only set response must be written")
+
+	enc := yamlGenomeReader{r: bufio.NewReader(bytes.NewBuffer(outBuf.Bytes()))}
+	gnomeEnc, err := enc.Read()
+	require.NoError(t, err, "failed to read genome")
+	require.Len(t, gnomeEnc.Nodes, len(gnome.Nodes))
+	for i, node := range gnome.Nodes {
+		assert.Equal(t, node.Bias, gnomeEnc.Nodes[i].Bias, "at: %d", i)
+		assert.Equal(t, node.Response, gnomeEnc.Nodes[i].Response, "at: %d", i)
+	}
+}
+
+func TestYamlGenomeWriter_WriteGenome_delay(t *testing.T) {
+	gnome := buildTestGenome(1)
+	gnome.Genes[1].Link.Delay = 2
+
+	outBuf := bytes.NewBufferString("")
+	wr, err := NewGenomeWriter(bufio.NewWriter(outBuf), YAMLGenomeEncoding)
+	require.NoError(t, err)
+	err = wr.WriteGenome(gnome)
+	require.NoError(t, err, "failed to write genome")
+	assert.Equal(t, 1, strings.Count(outBuf.String(), "delay:"), "only set delay must be written")
+
+	enc := yamlGenomeReader{r: bufio.NewReader(bytes.NewBuffer(outBuf.Bytes()))}
+	gnomeEnc, err := enc.Read()
+	require.NoError(t, err, "failed to read genome")
+	require.Len(t, gnomeEnc.Genes, len(gnome.Genes))
+	for i, gene := range gnome.Genes {
+		assert.Equal(t, gene.Link.Delay, gnomeEnc.Genes[i].Link.Delay, "at: %d", i)
+	}
+}
+
+func TestYamlGenomeWriter_WriteGenome_memoryCell(t *testing.T) {
+	gnome := buildTestMemoryGenome(1)
+
+	outBuf := bytes.NewBufferString("")
+	wr, err := NewGenomeWriter(bufio.NewWriter(outBuf), YAMLGenomeEncoding)
+	require.NoError(t, err)
+	err = wr.WriteGenome(gnome)
+	require.NoError(t, err, "failed to write genome")
+
+	enc := yamlGenomeReader{r: bufio.NewReader(bytes.NewBuffer(outBuf.Bytes()))}
+	gnomeEnc, err := enc.Read()
+	require.NoError(t, err, "failed to read genome")
+	equals, err := gnome.IsEqual(gnomeEnc)
+	require.NoError(t, err)
+	assert.True(t, equals)
+	assert.Equal(t, network.MemoryNeuron, gnomeEnc.Nodes[4].NeuronType)
+	assert.Equal(t, gnome.Nodes[4].GateWeights, gnomeEnc.Nodes[4].GateWeights)
+}
+
+func TestGenomeWriter_WriteGenome_customActivation(t *testing.T) {
+	aType, err := math.NodeActivators.RegisterExpression("CubeTestActivation", "x^3")
+	require.NoError(t, err, "failed to register custom activation")
+	gnome := buildTestGenome(1)
+	gnome.Nodes[3].ActivationType = aType
+
+	for _, encoding := range []GenomeEncoding{PlainGenomeEncoding, YAMLGenomeEncoding} {
+		outBuf := bytes.NewBufferString("")
+		wr, err := NewGenomeWriter(bufio.NewWriter(outBuf), encoding)
+		require.NoError(t, err)
+		err = wr.WriteGenome(gnome)
+		require.NoError(t, err, "failed to write genome: %d", encoding)
+		assert.Contains(t, outBuf.String(), "CubeTestActivation")
+
+		rd, err := NewGenomeReader(bytes.NewBuffer(outBuf.Bytes()), encoding)
+		require.NoError(t, err)
+		gnomeEnc, err := rd.Read()
+		require.NoError(t, err, "failed to read genome: %d", encoding)
+		assert.Equal(t, aType, gnomeEnc.Nodes[3].ActivationType)
+	}
+}
+
 func TestYamlGenomeWriter_WriteGenome_writeError(t *testing.T) {
 	errorWriter := ErrorWriter(1)
 	wr, err := NewGenomeWriter(bufio.NewWriter(&errorWriter), YAMLGenomeEncoding)
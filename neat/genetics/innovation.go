@@ -1,5 +1,7 @@
 package genetics
 
+import "github.com/yaricom/goNEAT/v3/neat/network"
+
 // InnovationsObserver the definition of component able to manage records of innovations
 type InnovationsObserver interface {
 	// StoreInnovation is to store specific innovation
@@ -32,6 +34,8 @@ type Innovation struct {
 	NewTraitNum int
 	// If a new node was created, this is its node_id
 	NewNodeId int
+	// If a new node was created, this is its neuron type
+	NewNeuronType network.NodeNeuronType
 
 	// If a new node was created, this is the innovation number of the gene's link it is being stuck inside
 	OldInnovNum int64
@@ -0,0 +1,371 @@
+package genetics
+
+import (
+	"fmt"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/simple"
+	"sort"
+	"strings"
+)
+
+// ReproductionOperator defines the genetic operator applied to produce an offspring organism
+type ReproductionOperator string
+
+// The available reproduction operators
+const (
+	// SpawnOperator the organism was spawned as a member of the initial population
+	SpawnOperator ReproductionOperator = "spawn"
+	// CloneOperator the organism is a clone of the species or population champion
+	CloneOperator ReproductionOperator = "clone"
+	// MutateAddNodeOperator the add node structural mutation was applied
+	MutateAddNodeOperator ReproductionOperator = "mutate_add_node"
+	// MutateAddLinkOperator the add link structural mutation was applied
+	MutateAddLinkOperator ReproductionOperator = "mutate_add_link"
+	// MutateConnectSensorsOperator the connect disconnected sensors structural mutation was applied
+	MutateConnectSensorsOperator ReproductionOperator = "mutate_connect_sensors"
+	// MutateAddModulatoryNodeOperator the add modulatory node structural mutation was applied
+	MutateAddModulatoryNodeOperator ReproductionOperator = "mutate_add_modulatory_node"
+	// MutateLinkWeightsOperator the link weights mutation was applied
+	MutateLinkWeightsOperator ReproductionOperator = "mutate_link_weights"
+	// MutateRandomTraitOperator the parameters of random trait were perturbed
+	MutateRandomTraitOperator ReproductionOperator = "mutate_random_trait"
+	// MutateLinkTraitOperator the random link was re-pointed to the random trait
+	MutateLinkTraitOperator ReproductionOperator = "mutate_link_trait"
+	// MutateNodeTraitOperator the random node was re-pointed to the random trait
+	MutateNodeTraitOperator ReproductionOperator = "mutate_node_trait"
+	// MutateToggleEnableOperator the enabled status of the random gene was toggled
+	MutateToggleEnableOperator ReproductionOperator = "mutate_toggle_enable"
+	// MutateGeneReEnableOperator the first disabled gene was re-enabled
+	MutateGeneReEnableOperator ReproductionOperator = "mutate_gene_reenable"
+	// MutateNodeTimeConstantOperator the time constant of the random neuron was perturbed
+	MutateNodeTimeConstantOperator ReproductionOperator = "mutate_node_time_constant"
+	// MutateMemoryGatesOperator the gate weights of the random memory cell neuron were perturbed
+	MutateMemoryGatesOperator ReproductionOperator = "mutate_memory_gates"
+	// MutateNodeBiasOperator the bias of the random neuron was perturbed
+	MutateNodeBiasOperator ReproductionOperator = "mutate_node_bias"
+	// MutateNodeResponseOperator the response of the random neuron was perturbed
+	MutateNodeResponseOperator ReproductionOperator = "mutate_node_response"
+	// MutateLinkDelayOperator the delay of the random link was changed by one step
+	MutateLinkDelayOperator ReproductionOperator = "mutate_link_delay"
+	// MateMultipointOperator the multipoint crossover was applied
+	MateMultipointOperator ReproductionOperator = "mate_multipoint"
+	// MateMultipointAvgOperator the multipoint crossover with averaging of matching genes was applied
+	MateMultipointAvgOperator ReproductionOperator = "mate_multipoint_avg"
+	// MateSinglePointOperator the single point crossover was applied
+	MateSinglePointOperator ReproductionOperator = "mate_singlepoint"
+)
+
+// ReproductionOperators the list of all reproduction operators which can be applied to produce an offspring from
+// its parents. The order of this list defines the order of columns in the operators' statistics.
+var ReproductionOperators = []ReproductionOperator{
+	CloneOperator,
+	MutateAddNodeOperator,
+	MutateAddLinkOperator,
+	MutateConnectSensorsOperator,
+	MutateAddModulatoryNodeOperator,
+	MutateLinkWeightsOperator,
+	MutateRandomTraitOperator,
+	MutateLinkTraitOperator,
+	MutateNodeTraitOperator,
+	MutateToggleEnableOperator,
+	MutateGeneReEnableOperator,
+	MutateNodeTimeConstantOperator,
+	MutateMemoryGatesOperator,
+	MutateNodeBiasOperator,
+	MutateNodeResponseOperator,
+	MutateLinkDelayOperator,
+	MateMultipointOperator,
+	MateMultipointAvgOperator,
+	MateSinglePointOperator,
+}
+
+// Genealogy holds information about origin of the particular organism: its parents, the reproduction operators
+// applied to produce it and its birth generation.
+type Genealogy struct {
+	// The unique ID of this record within the lineage store or zero if record is not registered yet
+	Id int64 `json:"id"`
+	// The ID of organism's genome assigned when organism joined the population
+	GenomeId int `json:"genome_id"`
+	// The generation when organism was born
+	BirthGeneration int `json:"birth_generation"`
+	// The lineage IDs of the parents (mom first)
+	ParentIds []int64 `json:"parent_ids"`
+	// The genome IDs of the parents at the time of reproduction (mom first)
+	ParentGenomeIds []int `json:"parent_genome_ids"`
+	// The original (not adjusted) fitness scores of the parents at the time of reproduction (mom first)
+	ParentFitness []float64 `json:"parent_fitness"`
+	// The reproduction operators applied in order
+	Operators []ReproductionOperator `json:"operators"`
+}
+
+// newGenealogy creates genealogy record for the baby born in specified generation by applying operators to parents.
+func newGenealogy(generation int, parents []*Organism, operators []ReproductionOperator) *Genealogy {
+	g := &Genealogy{
+		BirthGeneration: generation,
+		ParentIds:       make([]int64, len(parents)),
+		ParentGenomeIds: make([]int, len(parents)),
+		ParentFitness:   make([]float64, len(parents)),
+		Operators:       operators,
+	}
+	for i, p := range parents {
+		if p.Genealogy != nil {
+			g.ParentIds[i] = p.Genealogy.Id
+		}
+		g.ParentGenomeIds[i] = p.Genotype.Id
+		g.ParentFitness[i] = p.originalFitness
+	}
+	return g
+}
+
+// IsMated returns true if organism was produced by crossover
+func (g *Genealogy) IsMated() bool {
+	for _, op := range g.Operators {
+		switch op {
+		case MateMultipointOperator, MateMultipointAvgOperator, MateSinglePointOperator:
+			return true
+		}
+	}
+	return false
+}
+
+// BestParentFitness returns the highest original fitness among the parents. The second returned value is false if
+// organism has no parents, i.e. was spawned.
+func (g *Genealogy) BestParentFitness() (float64, bool) {
+	if len(g.ParentFitness) == 0 {
+		return 0, false
+	}
+	best := g.ParentFitness[0]
+	for _, f := range g.ParentFitness[1:] {
+		if f > best {
+			best = f
+		}
+	}
+	return best, true
+}
+
+// HasOperator returns true if given reproduction operator was applied to produce the organism
+func (g *Genealogy) HasOperator(op ReproductionOperator) bool {
+	for _, o := range g.Operators {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// The stringer
+func (g *Genealogy) String() string {
+	ops := make([]string, len(g.Operators))
+	for i, op := range g.Operators {
+		ops[i] = string(op)
+	}
+	return fmt.Sprintf("[Genealogy #%d genome: %d, born: %d, parents: %v, parent genomes: %v, operators: %s]",
+		g.Id, g.GenomeId, g.BirthGeneration, g.ParentIds, g.ParentGenomeIds, strings.Join(ops, ","))
+}
+
+// LineageStore keeps compact genealogy records of all organisms registered during the evolution run. The records
+// are linked through parents' lineage IDs and can be used to restore the ancestry of any organism.
+type LineageStore struct {
+	// The registered records by lineage ID
+	records map[int64]*Genealogy
+	// The last assigned lineage ID
+	lastId int64
+}
+
+// NewLineageStore creates new empty lineage store
+func NewLineageStore() *LineageStore {
+	return &LineageStore{
+		records: make(map[int64]*Genealogy),
+	}
+}
+
+// Register adds genealogy record of provided organism into this store. If organism has no genealogy record, the new
+// root record will be created as for spawned organism. The genome ID of the record will be updated to match
+// the current ID of organism's genome.
+func (s *LineageStore) Register(org *Organism) *Genealogy {
+	if org.Genealogy == nil {
+		org.Genealogy = newGenealogy(org.Generation, nil, []ReproductionOperator{SpawnOperator})
+	}
+	if org.Genealogy.Id == 0 {
+		s.lastId++
+		org.Genealogy.Id = s.lastId
+	}
+	org.Genealogy.GenomeId = org.Genotype.Id
+	s.records[org.Genealogy.Id] = org.Genealogy
+	return org.Genealogy
+}
+
+// Record returns genealogy record with given lineage ID if found
+func (s *LineageStore) Record(id int64) (*Genealogy, bool) {
+	r, ok := s.records[id]
+	return r, ok
+}
+
+// Size returns the number of records in this store
+func (s *LineageStore) Size() int {
+	return len(s.records)
+}
+
+// Prune removes all records which are not ancestors of provided organisms, i.e. all extinct branches of
+// the lineage. Returns the number of removed records.
+func (s *LineageStore) Prune(organisms []*Organism) int {
+	keep := make(map[int64]bool)
+	stack := make([]int64, 0, len(organisms))
+	for _, org := range organisms {
+		if org.Genealogy != nil && org.Genealogy.Id != 0 {
+			stack = append(stack, org.Genealogy.Id)
+		}
+	}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if keep[id] {
+			continue
+		}
+		if r, ok := s.records[id]; ok {
+			keep[id] = true
+			stack = append(stack, r.ParentIds...)
+		}
+	}
+	removed := 0
+	for id := range s.records {
+		if !keep[id] {
+			delete(s.records, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Ancestry collects the ancestry graph of the organism with given lineage ID. If maxDepth is greater than zero only
+// specified number of ancestor generations will be collected.
+func (s *LineageStore) Ancestry(id int64, maxDepth int) (*Ancestry, error) {
+	root, ok := s.records[id]
+	if !ok {
+		return nil, fmt.Errorf("genealogy record not found for lineage ID: %d", id)
+	}
+	a := &Ancestry{
+		Root:          root,
+		DirectedGraph: simple.NewDirectedGraph(),
+	}
+	a.AddNode(&AncestryNode{Genealogy: root})
+
+	type depthId struct {
+		id    int64
+		depth int
+	}
+	queue := []depthId{{id: id, depth: 0}}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if maxDepth > 0 && curr.depth >= maxDepth {
+			continue
+		}
+		child := s.records[curr.id]
+		for _, pId := range child.ParentIds {
+			parent, ok := s.records[pId]
+			if !ok || pId == curr.id {
+				// unknown or pruned ancestor
+				continue
+			}
+			if a.Node(pId) == nil {
+				a.AddNode(&AncestryNode{Genealogy: parent})
+				queue = append(queue, depthId{id: pId, depth: curr.depth + 1})
+			}
+			if !a.HasEdgeFromTo(pId, curr.id) {
+				a.SetEdge(&AncestryEdge{
+					F: a.Node(pId).(*AncestryNode),
+					T: a.Node(curr.id).(*AncestryNode),
+				})
+			}
+		}
+	}
+	return a, nil
+}
+
+// AncestryOf is to collect the ancestry graph of provided organism. See Ancestry for details.
+func (s *LineageStore) AncestryOf(org *Organism, maxDepth int) (*Ancestry, error) {
+	if org.Genealogy == nil {
+		return nil, fmt.Errorf("organism has no genealogy record: %s", org)
+	}
+	return s.Ancestry(org.Genealogy.Id, maxDepth)
+}
+
+// Ancestry is the directed graph of organism's ancestors where edges are directed from parents to children.
+type Ancestry struct {
+	*simple.DirectedGraph
+	// The genealogy of organism which ancestry is represented
+	Root *Genealogy
+}
+
+// Records returns genealogy records of all ancestors including the root ordered by lineage ID
+func (a *Ancestry) Records() []*Genealogy {
+	records := make([]*Genealogy, 0, a.DirectedGraph.Nodes().Len())
+	nodes := a.DirectedGraph.Nodes()
+	for nodes.Next() {
+		records = append(records, nodes.Node().(*AncestryNode).Genealogy)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Id < records[j].Id
+	})
+	return records
+}
+
+// AncestryNode is the node of the ancestry graph
+type AncestryNode struct {
+	*Genealogy
+}
+
+// ID is to get ID of the node. Implements graph.Node ID method.
+func (n *AncestryNode) ID() int64 {
+	return n.Genealogy.Id
+}
+
+// Attributes returns list of standard attributes associated with the graph node
+func (n *AncestryNode) Attributes() []encoding.Attribute {
+	ops := make([]string, len(n.Operators))
+	for i, op := range n.Operators {
+		ops[i] = string(op)
+	}
+	return []encoding.Attribute{
+		{Key: "label", Value: fmt.Sprintf("\"%d:%d\"", n.BirthGeneration, n.GenomeId)},
+		{Key: "genome_id", Value: fmt.Sprintf("%d", n.GenomeId)},
+		{Key: "birth_generation", Value: fmt.Sprintf("%d", n.BirthGeneration)},
+		{Key: "operators", Value: fmt.Sprintf("\"%s\"", strings.Join(ops, ","))},
+	}
+}
+
+// AncestryEdge is the edge of the ancestry graph directed from parent to child
+type AncestryEdge struct {
+	F, T *AncestryNode
+}
+
+// From returns the parent node of the edge. Implements graph.Edge From method
+func (e *AncestryEdge) From() graph.Node {
+	return e.F
+}
+
+// To returns the child node of the edge. Implements graph.Edge To method
+func (e *AncestryEdge) To() graph.Node {
+	return e.T
+}
+
+// ReversedEdge returns the same edge as reversal is not valid for ancestry
+func (e *AncestryEdge) ReversedEdge() graph.Edge {
+	return e
+}
+
+// IsMate returns true if child was produced by crossover from the parent
+func (e *AncestryEdge) IsMate() bool {
+	return e.T.IsMated()
+}
+
+// Attributes returns list of standard attributes associated with the graph edge
+func (e *AncestryEdge) Attributes() []encoding.Attribute {
+	style := "solid"
+	if e.IsMate() {
+		style = "dashed"
+	}
+	return []encoding.Attribute{{Key: "style", Value: style}}
+}
@@ -0,0 +1,141 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+// buildTestLineage creates lineage store with two spawned roots, their mated child and mutated grandchild
+func buildTestLineage(t *testing.T) (*LineageStore, []*Organism) {
+	store := NewLineageStore()
+	orgs := make([]*Organism, 4)
+	for i := range orgs {
+		org, err := NewOrganism(rand.Float64(), buildTestGenome(i+1), 1)
+		require.NoError(t, err, "failed to create organism")
+		orgs[i] = org
+	}
+	store.Register(orgs[0])
+	store.Register(orgs[1])
+
+	orgs[2].Genealogy = newGenealogy(2, orgs[:2], []ReproductionOperator{MateMultipointOperator})
+	store.Register(orgs[2])
+
+	orgs[3].Genealogy = newGenealogy(3, orgs[2:3], []ReproductionOperator{MutateAddNodeOperator})
+	store.Register(orgs[3])
+
+	return store, orgs
+}
+
+func TestLineageStore_Register(t *testing.T) {
+	store, orgs := buildTestLineage(t)
+	assert.Equal(t, 4, store.Size())
+
+	root := orgs[0].Genealogy
+	require.NotNil(t, root)
+	assert.EqualValues(t, 1, root.Id)
+	assert.Equal(t, orgs[0].Genotype.Id, root.GenomeId)
+	assert.Equal(t, []ReproductionOperator{SpawnOperator}, root.Operators)
+	assert.Empty(t, root.ParentIds)
+
+	child := orgs[2].Genealogy
+	assert.EqualValues(t, 3, child.Id)
+	assert.Equal(t, 2, child.BirthGeneration)
+	assert.Equal(t, []int64{1, 2}, child.ParentIds)
+	assert.Equal(t, []int{1, 2}, child.ParentGenomeIds)
+	assert.True(t, child.IsMated())
+	assert.False(t, orgs[3].Genealogy.IsMated())
+
+	// re-register with new genome ID should keep lineage ID
+	orgs[2].Genotype.Id = 10
+	r := store.Register(orgs[2])
+	assert.EqualValues(t, 3, r.Id)
+	assert.Equal(t, 10, r.GenomeId)
+	assert.Equal(t, 4, store.Size())
+
+	found, ok := store.Record(3)
+	assert.True(t, ok)
+	assert.Equal(t, r, found)
+	_, ok = store.Record(100)
+	assert.False(t, ok)
+}
+
+func TestLineageStore_Prune(t *testing.T) {
+	store, orgs := buildTestLineage(t)
+
+	// the extinct branch
+	extinct, err := NewOrganism(rand.Float64(), buildTestGenome(5), 2)
+	require.NoError(t, err, "failed to create organism")
+	extinct.Genealogy = newGenealogy(2, orgs[1:2], []ReproductionOperator{CloneOperator})
+	store.Register(extinct)
+	require.Equal(t, 5, store.Size())
+
+	removed := store.Prune(orgs[3:])
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, 4, store.Size())
+	_, ok := store.Record(extinct.Genealogy.Id)
+	assert.False(t, ok)
+}
+
+func TestLineageStore_Ancestry(t *testing.T) {
+	store, orgs := buildTestLineage(t)
+
+	a, err := store.AncestryOf(orgs[3], 0)
+	require.NoError(t, err, "failed to collect ancestry")
+	assert.Equal(t, orgs[3].Genealogy, a.Root)
+	assert.Equal(t, 4, a.Nodes().Len())
+	assert.Equal(t, 3, a.Edges().Len())
+	assert.True(t, a.HasEdgeFromTo(1, 3))
+	assert.True(t, a.HasEdgeFromTo(2, 3))
+	assert.True(t, a.HasEdgeFromTo(3, 4))
+
+	records := a.Records()
+	require.Len(t, records, 4)
+	for i, r := range records {
+		assert.EqualValues(t, i+1, r.Id)
+	}
+
+	edge := a.Edge(1, 3).(*AncestryEdge)
+	assert.True(t, edge.IsMate())
+	assert.Equal(t, "dashed", edge.Attributes()[0].Value)
+	edge = a.Edge(3, 4).(*AncestryEdge)
+	assert.False(t, edge.IsMate())
+
+	// limited depth
+	a, err = store.Ancestry(orgs[3].Genealogy.Id, 1)
+	require.NoError(t, err, "failed to collect ancestry")
+	assert.Equal(t, 2, a.Nodes().Len())
+	assert.Equal(t, 1, a.Edges().Len())
+}
+
+func TestLineageStore_Ancestry_fail(t *testing.T) {
+	store, _ := buildTestLineage(t)
+
+	a, err := store.Ancestry(100, 0)
+	assert.EqualError(t, err, "genealogy record not found for lineage ID: 100")
+	assert.Nil(t, a)
+
+	org, err := NewOrganism(rand.Float64(), buildTestGenome(1), 1)
+	require.NoError(t, err, "failed to create organism")
+	a, err = store.AncestryOf(org, 0)
+	assert.Error(t, err)
+	assert.Nil(t, a)
+}
+
+func TestGenealogy_BestParentFitness(t *testing.T) {
+	g := &Genealogy{ParentFitness: []float64{1.5, 3.0}}
+	best, ok := g.BestParentFitness()
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, best)
+
+	g = &Genealogy{}
+	_, ok = g.BestParentFitness()
+	assert.False(t, ok)
+}
+
+func TestGenealogy_HasOperator(t *testing.T) {
+	g := &Genealogy{Operators: []ReproductionOperator{MateSinglePointOperator, MutateLinkWeightsOperator}}
+	assert.True(t, g.HasOperator(MutateLinkWeightsOperator))
+	assert.False(t, g.HasOperator(MutateAddNodeOperator))
+}
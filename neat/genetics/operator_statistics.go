@@ -0,0 +1,69 @@
+package genetics
+
+// OperatorStatistics is the aggregated statistics about evaluated offspring produced with particular reproduction
+// operator. The fitness of each offspring is compared with the best original fitness of its parents.
+type OperatorStatistics struct {
+	// The reproduction operator
+	Operator ReproductionOperator
+	// The number of offspring produced with this operator
+	Offspring int
+	// The number of offspring with fitness higher than the best fitness of their parents
+	Improved int
+	// The number of offspring with fitness lower than the best fitness of their parents
+	Degraded int
+	// The sum of differences between fitness of offspring and the best fitness of their parents
+	FitnessDeltaSum float64
+}
+
+// MeanFitnessDelta returns the mean difference between fitness of offspring and the best fitness of their parents
+func (s OperatorStatistics) MeanFitnessDelta() float64 {
+	if s.Offspring == 0 {
+		return 0
+	}
+	return s.FitnessDeltaSum / float64(s.Offspring)
+}
+
+// ImprovementRate returns the fraction of offspring which fitness exceeds the best fitness of their parents
+func (s OperatorStatistics) ImprovementRate() float64 {
+	if s.Offspring == 0 {
+		return 0
+	}
+	return float64(s.Improved) / float64(s.Offspring)
+}
+
+// CollectOperatorStatistics collects statistics of reproduction operators applied to produce provided organisms.
+// This method should be called after organisms' fitness evaluation and before the next epoch. The organisms
+// without genealogy or without parents (spawned) are ignored. The returned list holds statistics for each operator
+// in the order of ReproductionOperators list.
+func CollectOperatorStatistics(organisms []*Organism) []OperatorStatistics {
+	stats := make([]OperatorStatistics, len(ReproductionOperators))
+	index := make(map[ReproductionOperator]int, len(ReproductionOperators))
+	for i, op := range ReproductionOperators {
+		stats[i].Operator = op
+		index[op] = i
+	}
+	for _, org := range organisms {
+		if org.Genealogy == nil {
+			continue
+		}
+		parentFitness, ok := org.Genealogy.BestParentFitness()
+		if !ok {
+			continue
+		}
+		delta := org.Fitness - parentFitness
+		for _, op := range org.Genealogy.Operators {
+			i, found := index[op]
+			if !found {
+				continue
+			}
+			stats[i].Offspring++
+			stats[i].FitnessDeltaSum += delta
+			if delta > 0 {
+				stats[i].Improved++
+			} else if delta < 0 {
+				stats[i].Degraded++
+			}
+		}
+	}
+	return stats
+}
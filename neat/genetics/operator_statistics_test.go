@@ -0,0 +1,63 @@
+package genetics
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCollectOperatorStatistics(t *testing.T) {
+	organisms := []*Organism{
+		// spawned - ignored
+		{Fitness: 10, Genealogy: &Genealogy{Operators: []ReproductionOperator{SpawnOperator}}},
+		// no genealogy - ignored
+		{Fitness: 10},
+		// improved by mating and mutation
+		{Fitness: 6, Genealogy: &Genealogy{
+			ParentFitness: []float64{2, 4},
+			Operators:     []ReproductionOperator{MateMultipointOperator, MutateAddNodeOperator},
+		}},
+		// degraded by mutation
+		{Fitness: 1, Genealogy: &Genealogy{
+			ParentFitness: []float64{3},
+			Operators:     []ReproductionOperator{MutateAddNodeOperator},
+		}},
+		// unchanged clone
+		{Fitness: 5, Genealogy: &Genealogy{
+			ParentFitness: []float64{5},
+			Operators:     []ReproductionOperator{CloneOperator},
+		}},
+	}
+
+	stats := CollectOperatorStatistics(organisms)
+	require.Len(t, stats, len(ReproductionOperators))
+	byOp := make(map[ReproductionOperator]OperatorStatistics)
+	for i, s := range stats {
+		assert.Equal(t, ReproductionOperators[i], s.Operator)
+		byOp[s.Operator] = s
+	}
+
+	addNode := byOp[MutateAddNodeOperator]
+	assert.Equal(t, 2, addNode.Offspring)
+	assert.Equal(t, 1, addNode.Improved)
+	assert.Equal(t, 1, addNode.Degraded)
+	assert.Equal(t, 0.0, addNode.FitnessDeltaSum)
+	assert.Equal(t, 0.5, addNode.ImprovementRate())
+	assert.Equal(t, 0.0, addNode.MeanFitnessDelta())
+
+	mate := byOp[MateMultipointOperator]
+	assert.Equal(t, 1, mate.Offspring)
+	assert.Equal(t, 1, mate.Improved)
+	assert.Equal(t, 2.0, mate.MeanFitnessDelta())
+	assert.Equal(t, 1.0, mate.ImprovementRate())
+
+	clone := byOp[CloneOperator]
+	assert.Equal(t, 1, clone.Offspring)
+	assert.Zero(t, clone.Improved)
+	assert.Zero(t, clone.Degraded)
+
+	empty := byOp[MateSinglePointOperator]
+	assert.Zero(t, empty.Offspring)
+	assert.Zero(t, empty.ImprovementRate())
+	assert.Zero(t, empty.MeanFitnessDelta())
+}
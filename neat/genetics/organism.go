@@ -2,7 +2,9 @@ package genetics
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/math"
 	"github.com/yaricom/goNEAT/v3/neat/network"
 )
 
@@ -36,6 +38,8 @@ type Organism struct {
 	ExpectedOffspring float64
 	// Tells which generation this Organism is from
 	Generation int
+	// The genealogy record of this Organism describing its parents and reproduction operators applied
+	Genealogy *Genealogy
 
 	// The utility data transfer object to be used by different GA implementations to hold additional data.
 	// Implemented as ANY to allow implementation specific objects.
@@ -104,25 +108,54 @@ func (o *Organism) CheckChampionChildDamaged() bool {
 	return false
 }
 
-// MarshalBinary Encodes this organism for wired transmission during parallel reproduction cycle
+// The prefix of the optional genealogy line appended after the genome in the binary encoding of organism
+const organismGenealogyPrefix = "genealogy "
+
+// MarshalBinary Encodes this organism for wired transmission during parallel reproduction cycle. The genealogy
+// record of organism is appended after the genome to keep the encoding readable by the older versions.
 func (o *Organism) MarshalBinary() ([]byte, error) {
 	var buf bytes.Buffer
 	if _, err := fmt.Fprintln(&buf, o.Fitness, o.Generation, o.highestFitness, o.isPopulationChampionChild, o.Genotype.Id); err != nil {
 		return nil, err
 	} else if err = o.Genotype.Write(&buf); err != nil {
 		return nil, err
+	} else if genealogy, err := json.Marshal(o.Genealogy); err != nil {
+		return nil, err
+	} else if _, err = fmt.Fprintf(&buf, "%s%s\n", organismGenealogyPrefix, genealogy); err != nil {
+		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
 // UnmarshalBinary Decodes organism received over the wire during parallel reproduction cycle
 func (o *Organism) UnmarshalBinary(data []byte) error {
+	return o.UnmarshalBinaryWithActivators(data, nil)
+}
+
+// UnmarshalBinaryWithActivators Decodes organism encoded with MarshalBinary resolving the activation functions of
+// its genome using provided factory, e.g., the neat.Options.Activators() with the custom activation functions of
+// the experiment. If activators is nil the default math.NodeActivators is used.
+func (o *Organism) UnmarshalBinaryWithActivators(data []byte, activators *math.NodeActivatorsFactory) error {
 	// A simple encoding: plain text.
 	b := bytes.NewBuffer(data)
 	var genotypeId int
 	if _, err := fmt.Fscanln(b, &o.Fitness, &o.Generation, &o.highestFitness, &o.isPopulationChampionChild, &genotypeId); err != nil {
 		return err
-	} else if o.Genotype, err = ReadGenome(b, genotypeId); err != nil {
+	}
+	// the genome reader skips the genealogy line, which can be absent in the data encoded by the older versions
+	data = b.Bytes()
+	o.Genealogy = nil
+	if i := bytes.Index(data, []byte("\n"+organismGenealogyPrefix)); i >= 0 {
+		genealogy := data[i+len(organismGenealogyPrefix)+1:]
+		if j := bytes.IndexByte(genealogy, '\n'); j >= 0 {
+			genealogy = genealogy[:j]
+		}
+		if err := json.Unmarshal(genealogy, &o.Genealogy); err != nil {
+			return err
+		}
+	}
+	var err error
+	if o.Genotype, err = ReadGenomeWithActivators(bytes.NewReader(data), genotypeId, activators); err != nil {
 		return err
 	} else if o.Phenotype, err = o.Genotype.Genesis(genotypeId); err != nil {
 		return err
@@ -165,6 +198,7 @@ func (o *Organism) Dump() string {
 	_, _ = fmt.Fprintln(b, "highestFitness: ", o.highestFitness)
 	_, _ = fmt.Fprintln(b, "mutationStructBaby: ", o.mutationStructBaby)
 	_, _ = fmt.Fprintln(b, "mateBaby: ", o.mateBaby)
+	_, _ = fmt.Fprintln(b, "Genealogy: ", o.Genealogy)
 	_, _ = fmt.Fprintln(b, "Flag: ", o.Flag)
 
 	return b.String()
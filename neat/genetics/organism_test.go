@@ -3,8 +3,10 @@ package genetics
 import (
 	"bytes"
 	"encoding/gob"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
 	"math"
 	"math/rand"
 	"sort"
@@ -49,6 +51,8 @@ func TestOrganism_MarshalBinary(t *testing.T) {
 	gnome := buildTestGenome(1)
 	org, err := NewOrganism(rand.Float64(), gnome, 1)
 	require.NoError(t, err, "failed to create organism")
+	org.Genealogy = &Genealogy{Id: 10, GenomeId: 1, BirthGeneration: 2, ParentIds: []int64{3, 4},
+		ParentGenomeIds: []int{5, 6}, Operators: []ReproductionOperator{MateMultipointOperator}}
 
 	// Marshal to binary
 	var buf bytes.Buffer
@@ -64,6 +68,7 @@ func TestOrganism_MarshalBinary(t *testing.T) {
 
 	// check results
 	assert.Equal(t, org.Fitness, decOrg.Fitness)
+	assert.Equal(t, org.Genealogy, decOrg.Genealogy)
 
 	decGnome := decOrg.Genotype
 	assert.Equal(t, gnome.Id, decGnome.Id)
@@ -73,6 +78,57 @@ func TestOrganism_MarshalBinary(t *testing.T) {
 	assert.True(t, equals)
 }
 
+func TestOrganism_UnmarshalBinary_noGenealogy(t *testing.T) {
+	gnome := buildTestGenome(1)
+	_, err := gnome.Genesis(gnome.Id)
+	require.NoError(t, err, "genesis failed")
+	// the encoding of the older versions without genealogy
+	var buf bytes.Buffer
+	_, err = fmt.Fprintln(&buf, 0.5, 3, 0.75, false, gnome.Id)
+	require.NoError(t, err)
+	require.NoError(t, gnome.Write(&buf))
+
+	org := Organism{}
+	err = org.UnmarshalBinary(buf.Bytes())
+	require.NoError(t, err, "failed to decode")
+	assert.Equal(t, 0.5, org.Fitness)
+	assert.Equal(t, 3, org.Generation)
+	assert.Nil(t, org.Genealogy)
+	equals, err := gnome.IsEqual(org.Genotype)
+	require.NoError(t, err, "failed to check equality")
+	assert.True(t, equals)
+	assert.NotNil(t, org.Phenotype)
+}
+
+func TestOrganism_UnmarshalBinaryWithActivators(t *testing.T) {
+	activators := neatmath.NodeActivators.Clone()
+	cube, err := activators.RegisterExpression("CubeActivation", "x^3")
+	require.NoError(t, err)
+
+	gnome := buildTestGenome(1)
+	gnome.Nodes[3].ActivationType = cube
+	gnome.SetActivators(activators)
+	org, err := NewOrganism(rand.Float64(), gnome, 1)
+	require.NoError(t, err, "failed to create organism")
+
+	data, err := org.MarshalBinary()
+	require.NoError(t, err, "failed to encode")
+
+	decOrg := Organism{}
+	err = decOrg.UnmarshalBinaryWithActivators(data, activators)
+	require.NoError(t, err, "failed to decode")
+	assert.Equal(t, org.Fitness, decOrg.Fitness)
+	assert.Equal(t, cube, decOrg.Genotype.Nodes[3].ActivationType)
+	assert.Equal(t, activators, decOrg.Genotype.Activators())
+	equals, err := gnome.IsEqual(decOrg.Genotype)
+	require.NoError(t, err, "failed to check equality")
+	assert.True(t, equals)
+
+	// the default activators factory has no custom activation function
+	err = (&Organism{}).UnmarshalBinary(data)
+	assert.EqualError(t, err, "unsupported activation type name: CubeActivation")
+}
+
 func TestOrganism_CheckChampionChildDamaged(t *testing.T) {
 	gnome := buildTestGenome(1)
 	org, err := NewOrganism(rand.Float64(), gnome, 1)
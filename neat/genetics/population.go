@@ -7,6 +7,7 @@ import (
 	"github.com/yaricom/goNEAT/v3/neat"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"sync/atomic"
 )
@@ -42,6 +43,9 @@ type Population struct {
 	// The next ID for new node in population
 	nextNodeId int32
 
+	// The genealogy records of all organisms born during the evolution
+	Lineage *LineageStore
+
 	// The mutex to guard against concurrent modifications
 	mutex *sync.Mutex
 }
@@ -79,11 +83,13 @@ func NewPopulationRandom(in, out, maxHidden int, recurrent bool, linkProb float6
 	pop := newPopulation()
 	for count := 0; count < opts.PopSize; count++ {
 		gen := newGenomeRand(count, in, out, rand.Intn(maxHidden), maxHidden, recurrent, linkProb)
+		gen.activators = opts.NodeActivatorsFactory
 		org, err := NewOrganism(0.0, gen, 1)
 		if err != nil {
 			return nil, err
 		}
 		pop.Organisms = append(pop.Organisms, org)
+		pop.Lineage.Register(org)
 	}
 	pop.nextNodeId = int32(in + out + maxHidden + 1)
 	pop.nextInnovNum = int64((in+out+maxHidden)*(in+out+maxHidden) + 1)
@@ -109,6 +115,19 @@ func (p *Population) Verify() (bool, error) {
 	return res, nil
 }
 
+// Validate is to validate genomes of all organisms in this population. Returns validation reports per organism
+// sorted by genome ID.
+func (p *Population) Validate() []*ValidationReport {
+	reports := make([]*ValidationReport, len(p.Organisms))
+	for i, o := range p.Organisms {
+		reports[i] = o.Genotype.Validate()
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].GenomeId < reports[j].GenomeId
+	})
+	return reports
+}
+
 // Default private constructor
 func newPopulation() *Population {
 	return &Population{
@@ -117,6 +136,7 @@ func newPopulation() *Population {
 		EpochsHighestLastChanged: 0,
 		Species:                  make([]*Species, 0),
 		Organisms:                make([]*Organism, 0),
+		Lineage:                  NewLineageStore(),
 		mutex:                    &sync.Mutex{},
 	}
 }
@@ -148,6 +168,10 @@ func (p *Population) spawn(g *Genome, opts *neat.Options) (err error) {
 		if err != nil {
 			return err
 		}
+		if newGenome.activators == nil {
+			// use the activation functions of options if not set for the spawning genome
+			newGenome.activators = opts.NodeActivatorsFactory
+		}
 		// introduce initial mutations
 		if _, err = newGenome.mutateLinkWeights(1.0, 1.0, gaussianMutator); err != nil {
 			return err
@@ -157,6 +181,7 @@ func (p *Population) spawn(g *Genome, opts *neat.Options) (err error) {
 			return err
 		} else {
 			p.Organisms = append(p.Organisms, newOrganism)
+			p.Lineage.Register(newOrganism)
 		}
 	}
 	// Keep a record of the innovation and node number we are on
@@ -521,6 +546,8 @@ func (p *Population) purgeOrAgeSpecies() {
 			for _, org := range currSpecies.Organisms {
 				org.Genotype.Id = orgCount
 				p.Organisms = append(p.Organisms, org)
+				// store genealogy of organism with its final genome ID
+				p.Lineage.Register(org)
 				orgCount++
 			}
 			// keep this species
@@ -533,6 +560,12 @@ func (p *Population) purgeOrAgeSpecies() {
 	// Keep only survived species
 	p.Species = speciesToKeep
 
+	// Keep only genealogy records of the survived organisms and their ancestors
+	pruned := p.Lineage.Prune(p.Organisms)
+	if neat.LogLevel == neat.LogLevelDebug {
+		neat.DebugLog(fmt.Sprintf("POPULATION: # of extinct genealogy records pruned: %d", pruned))
+	}
+
 	if neat.LogLevel == neat.LogLevelDebug {
 		neat.DebugLog(fmt.Sprintf("POPULATION: # of species survived: %d, # of organisms survived: %d\n",
 			len(p.Species), len(p.Organisms)))
@@ -239,7 +239,11 @@ func (p *ParallelPopulationEpochExecutor) reproduce(ctx context.Context, generat
 				var buf bytes.Buffer
 				enc := gob.NewEncoder(&buf)
 				for _, baby := range babies {
-					if err = enc.Encode(baby); err != nil {
+					var data []byte
+					if data, err = baby.MarshalBinary(); err != nil {
+						break
+					}
+					if err = enc.Encode(data); err != nil {
 						break
 					}
 				}
@@ -269,9 +273,13 @@ func (p *ParallelPopulationEpochExecutor) reproduce(ctx context.Context, generat
 		// read baby genome
 		dec := gob.NewDecoder(bytes.NewBuffer(result.babies))
 		for i := 0; i < result.babiesStored; i++ {
+			var data []byte
+			if err := dec.Decode(&data); err != nil {
+				return fmt.Errorf("failed to decode baby organism, reason: %v", err)
+			}
+			// decode with the activators factory of the experiment to resolve custom activation functions
 			org := Organism{}
-			err := dec.Decode(&org)
-			if err != nil {
+			if err := org.UnmarshalBinaryWithActivators(data, opts.NodeActivatorsFactory); err != nil {
 				return fmt.Errorf("failed to decode baby organism, reason: %v", err)
 			}
 			babies = append(babies, &org)
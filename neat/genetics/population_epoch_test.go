@@ -58,4 +58,15 @@ func TestPopulationEpochExecutor_NextEpoch(t *testing.T) {
 	// test parallel executor
 	err = parallelExecutorNextEpoch(pop, &conf)
 	assert.NoError(t, err, "failed to run parallel epoch executor")
+
+	// check that genealogy of organisms was tracked
+	for _, org := range pop.Organisms {
+		require.NotNil(t, org.Genealogy, "genealogy expected")
+		assert.NotZero(t, org.Genealogy.Id, "organism should be registered")
+		assert.Equal(t, org.Genotype.Id, org.Genealogy.GenomeId)
+		_, ok := pop.Lineage.Record(org.Genealogy.Id)
+		assert.True(t, ok, "genealogy record not found")
+	}
+	assert.True(t, pop.Lineage.Size() > len(pop.Organisms))
+	assert.Zero(t, pop.Lineage.Prune(pop.Organisms), "extinct genealogy records must be pruned each epoch")
 }
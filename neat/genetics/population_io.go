@@ -36,7 +36,12 @@ func ReadPopulation(ir io.Reader, options *neat.Options) (pop *Population, err e
 			if _, err = fmt.Fprintf(outBuff, "genomeend %d", idCheck); err != nil {
 				return nil, err
 			}
-			newGenome, err := ReadGenome(bufio.NewReader(outBuff), idCheck)
+			// read genome resolving the activation functions with the factory of options
+			reader, err := NewGenomeReaderWithActivators(outBuff, PlainGenomeEncoding, options.NodeActivatorsFactory)
+			if err != nil {
+				return nil, err
+			}
+			newGenome, err := reader.Read()
 			if err != nil {
 				return nil, err
 			}
@@ -45,6 +50,7 @@ func ReadPopulation(ir io.Reader, options *neat.Options) (pop *Population, err e
 				return nil, err
 			} else {
 				pop.Organisms = append(pop.Organisms, newOrganism)
+				pop.Lineage.Register(newOrganism)
 			}
 
 			if lastNodeId, err := newGenome.getLastNodeId(); err == nil {
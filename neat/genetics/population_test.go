@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/math"
 	"math/rand"
 	"strings"
 	"testing"
@@ -64,6 +65,29 @@ func TestNewPopulation(t *testing.T) {
 	}
 }
 
+func TestNewPopulation_activators(t *testing.T) {
+	rand.Seed(42)
+	conf := neat.Options{
+		CompatThreshold:       0.5,
+		PopSize:               10,
+		NodeActivatorsFactory: math.NodeActivators.Clone(),
+	}
+	gen := newGenomeRand(1, 3, 2, 3, 5, false, 0.5)
+	pop, err := NewPopulation(gen, &conf)
+	require.NoError(t, err, "failed to create population")
+	for _, org := range pop.Organisms {
+		assert.Same(t, conf.NodeActivatorsFactory, org.Genotype.Activators())
+		assert.Same(t, conf.NodeActivatorsFactory, org.Phenotype.Activators())
+	}
+
+	pop, err = NewPopulationRandom(3, 2, 5, false, 0.5, &conf)
+	require.NoError(t, err, "failed to create population")
+	for _, org := range pop.Organisms {
+		assert.Same(t, conf.NodeActivatorsFactory, org.Genotype.Activators())
+		assert.Same(t, conf.NodeActivatorsFactory, org.Phenotype.Activators())
+	}
+}
+
 func TestPopulation_verify(t *testing.T) {
 	// first create population
 	popStr := "genomestart 1\n" +
@@ -102,3 +126,32 @@ func TestPopulation_verify(t *testing.T) {
 	require.NoError(t, err, "failed to verify population")
 	assert.True(t, res, "Population verification failed, but must not")
 }
+
+func TestPopulation_Validate(t *testing.T) {
+	rand.Seed(42)
+	in, out, nmax := 3, 2, 5
+	linkProb := 0.5
+	conf := neat.Options{
+		CompatThreshold: 0.5,
+		PopSize:         10,
+	}
+	pop, err := NewPopulationRandom(in, out, nmax, false, linkProb, &conf)
+	require.NoError(t, err, "failed to create population")
+
+	// break one genome
+	pop.Organisms[3].Genotype.Nodes = pop.Organisms[3].Genotype.Nodes[1:]
+
+	reports := pop.Validate()
+	require.Len(t, reports, conf.PopSize)
+	invalid := 0
+	for i, r := range reports {
+		if i > 0 {
+			assert.True(t, reports[i-1].GenomeId < r.GenomeId, "reports should be sorted by genome ID")
+		}
+		if !r.IsValid() {
+			invalid++
+			assert.Equal(t, pop.Organisms[3].Genotype.Id, r.GenomeId)
+		}
+	}
+	assert.Equal(t, 1, invalid)
+}
@@ -285,6 +285,9 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 				count, s.ExpectedOffspring, s.Id))
 		}
 		mutStructBaby, mateBaby := false, false
+		// The parents and reproduction operators to track baby's genealogy
+		var parents []*Organism
+		operators := make([]ReproductionOperator, 0)
 
 		// Debug Trap
 		if s.ExpectedOffspring > opts.PopSize {
@@ -302,6 +305,8 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 			if err != nil {
 				return nil, err
 			}
+			parents = []*Organism{mom}
+			operators = append(operators, CloneOperator)
 
 			// Most superchamp offspring will have their connection weights mutated only
 			// The last offspring will be an exact duplicate of this super_champ
@@ -313,13 +318,16 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 					if _, err = newGenome.mutateLinkWeights(opts.WeightMutPower, 1.0, gaussianMutator); err != nil {
 						return nil, err
 					}
+					operators = append(operators, MutateLinkWeightsOperator)
 				} else {
 					// Sometimes we add a link to a superchamp
 					if _, err = newGenome.Genesis(generation); err != nil {
 						return nil, err
 					}
-					if _, err = newGenome.mutateAddLink(pop, opts); err != nil {
+					if ok, err := newGenome.mutateAddLink(pop, opts); err != nil {
 						return nil, err
+					} else if ok {
+						operators = append(operators, MutateAddLinkOperator)
 					}
 					mutStructBaby = true
 				}
@@ -350,6 +358,8 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 			}
 			// Baby is just like mommy
 			champCloneDone = true
+			parents = []*Organism{mom}
+			operators = append(operators, CloneOperator)
 
 			// Create the new baby organism
 			baby, err = NewOrganism(0.0, newGenome, generation)
@@ -367,14 +377,17 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 			if err != nil {
 				return nil, err
 			}
+			parents = []*Organism{mom}
 
 			// Do the mutation depending on probabilities of various mutations
 			if rand.Float64() < opts.MutateAddNodeProb {
 				neat.DebugLog("SPECIES: ---> mutateAddNode")
 
 				// Mutate add node
-				if _, err = newGenome.mutateAddNode(pop, pop, opts); err != nil {
+				if ok, err := newGenome.mutateAddNode(pop, pop, opts); err != nil {
 					return nil, err
+				} else if ok {
+					operators = append(operators, MutateAddNodeOperator)
 				}
 				mutStructBaby = true
 			} else if rand.Float64() < opts.MutateAddLinkProb {
@@ -384,25 +397,38 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 				if _, err = newGenome.Genesis(generation); err != nil {
 					return nil, err
 				}
-				if _, err = newGenome.mutateAddLink(pop, opts); err != nil {
+				if ok, err := newGenome.mutateAddLink(pop, opts); err != nil {
 					return nil, err
+				} else if ok {
+					operators = append(operators, MutateAddLinkOperator)
 				}
 				mutStructBaby = true
 			} else if rand.Float64() < opts.MutateConnectSensors {
 				neat.DebugLog("SPECIES: ---> mutateConnectSensors")
 				if linkAdded, err := newGenome.mutateConnectSensors(pop, opts); err != nil {
 					return nil, err
-				} else {
+				} else if linkAdded {
+					operators = append(operators, MutateConnectSensorsOperator)
 					mutStructBaby = linkAdded
 				}
+			} else if opts.MutateAddModulatoryNodeProb > 0 && rand.Float64() < opts.MutateAddModulatoryNodeProb {
+				neat.DebugLog("SPECIES: ---> mutateAddModulatoryNode")
+				if nodeAdded, err := newGenome.mutateAddModulatoryNode(pop, pop, opts); err != nil {
+					return nil, err
+				} else if nodeAdded {
+					operators = append(operators, MutateAddModulatoryNodeOperator)
+					mutStructBaby = nodeAdded
+				}
 			}
 
 			if !mutStructBaby {
 				neat.DebugLog("SPECIES: ---> mutateAllNonstructural")
 
 				// If we didn't do a structural mutation, we do the other kinds
-				if _, err = newGenome.mutateAllNonstructural(opts); err != nil {
+				if applied, err := newGenome.mutateAllNonstructural(opts); err != nil {
 					return nil, err
+				} else {
+					operators = append(operators, applied...)
 				}
 			}
 
@@ -457,6 +483,7 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 				if err != nil {
 					return nil, err
 				}
+				operators = append(operators, MateMultipointOperator)
 			} else if rand.Float64() < opts.MateMultipointAvgProb/(opts.MateMultipointAvgProb+opts.MateSinglepointProb) {
 				neat.DebugLog("SPECIES: ------> mateMultipointAvg")
 
@@ -465,6 +492,7 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 				if err != nil {
 					return nil, err
 				}
+				operators = append(operators, MateMultipointAvgOperator)
 			} else {
 				neat.DebugLog("SPECIES: ------> mateSinglePoint")
 
@@ -472,9 +500,13 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 				if err != nil {
 					return nil, err
 				}
+				operators = append(operators, MateSinglePointOperator)
 			}
 
+			// the baby uses the same activation functions as its mom
+			newGenome.activators = mom.Genotype.activators
 			mateBaby = true
+			parents = []*Organism{mom, dad}
 
 			// Determine whether to mutate the baby's Genome
 			// This is done randomly or if the mom and dad are the same organism
@@ -488,8 +520,10 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 					neat.DebugLog("SPECIES: ---------> mutateAddNode")
 
 					// mutate_add_node
-					if _, err = newGenome.mutateAddNode(pop, pop, opts); err != nil {
+					if ok, err := newGenome.mutateAddNode(pop, pop, opts); err != nil {
 						return nil, err
+					} else if ok {
+						operators = append(operators, MutateAddNodeOperator)
 					}
 					mutStructBaby = true
 				} else if rand.Float64() < opts.MutateAddLinkProb {
@@ -499,14 +533,25 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 					if _, err = newGenome.Genesis(generation); err != nil {
 						return nil, err
 					}
-					if _, err = newGenome.mutateAddLink(pop, opts); err != nil {
+					if ok, err := newGenome.mutateAddLink(pop, opts); err != nil {
 						return nil, err
+					} else if ok {
+						operators = append(operators, MutateAddLinkOperator)
 					}
 					mutStructBaby = true
 				} else if rand.Float64() < opts.MutateConnectSensors {
 					neat.DebugLog("SPECIES: ---> mutateConnectSensors")
 					if mutStructBaby, err = newGenome.mutateConnectSensors(pop, opts); err != nil {
 						return nil, err
+					} else if mutStructBaby {
+						operators = append(operators, MutateConnectSensorsOperator)
+					}
+				} else if opts.MutateAddModulatoryNodeProb > 0 && rand.Float64() < opts.MutateAddModulatoryNodeProb {
+					neat.DebugLog("SPECIES: ---> mutateAddModulatoryNode")
+					if mutStructBaby, err = newGenome.mutateAddModulatoryNode(pop, pop, opts); err != nil {
+						return nil, err
+					} else if mutStructBaby {
+						operators = append(operators, MutateAddModulatoryNodeOperator)
 					}
 				}
 
@@ -514,8 +559,10 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 					neat.DebugLog("SPECIES: ---> mutateAllNonstructural")
 
 					// If we didn't do a structural mutation, we do the other kinds
-					if _, err = newGenome.mutateAllNonstructural(opts); err != nil {
+					if applied, err := newGenome.mutateAllNonstructural(opts); err != nil {
 						return nil, err
+					} else {
+						operators = append(operators, applied...)
 					}
 				}
 			}
@@ -528,6 +575,7 @@ func (s *Species) reproduce(ctx context.Context, generation int, pop *Population
 
 		baby.mutationStructBaby = mutStructBaby
 		baby.mateBaby = mateBaby
+		baby.Genealogy = newGenealogy(generation, parents, operators)
 
 		babies = append(babies, baby)
 
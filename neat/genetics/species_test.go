@@ -237,4 +237,11 @@ func TestSpecies_reproduce(t *testing.T) {
 	require.NotEmpty(t, babies, "offsprings expected")
 
 	assert.Len(t, babies, pop.Species[0].ExpectedOffspring, "Wrong number of babies was created")
+	for _, baby := range babies {
+		require.NotNil(t, baby.Genealogy, "genealogy expected")
+		assert.Equal(t, 1, baby.Genealogy.BirthGeneration)
+		assert.NotEmpty(t, baby.Genealogy.ParentIds, "parents expected")
+		assert.NotEmpty(t, baby.Genealogy.Operators, "operators expected")
+		assert.Zero(t, baby.Genealogy.Id, "baby should not be registered yet")
+	}
 }
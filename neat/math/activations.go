@@ -3,6 +3,8 @@ package math
 import (
 	"fmt"
 	"math"
+	"strings"
+	"unicode"
 )
 
 // NodeActivationType defines the type of activation function to use for the neuron node
@@ -37,8 +39,31 @@ const (
 	MultiplyModuleActivation
 	MaxModuleActivation
 	MinModuleActivation
+	SoftmaxModuleActivation
+	LogSoftmaxModuleActivation
+	NormalizeModuleActivation
+	WinnerTakeAllModuleActivation
+
+	// The ReLU family and softplus activators, appended after the modular activators to keep the values
+	// of the existing activation types
+	ReLUActivation
+	LeakyReLUActivation
+	ELUActivation
+	SoftplusActivation
+
+	// The parametric activators reading the shape parameters from the auxiliary parameters of the node,
+	// see NNode.Params
+	ParametricLeakyReLUActivation
+	PReLUActivation
+	ParametricELUActivation
+	ParametricGaussianActivation
+	ParametricSigmoidActivation
 )
 
+// FirstCustomActivation The first activation type assigned to the activation functions registered from expressions,
+// see NodeActivatorsFactory.RegisterExpression
+const FirstCustomActivation NodeActivationType = 128
+
 // ActivationFunction The neuron node activation function type
 type ActivationFunction func(float64, []float64) float64
 
@@ -58,6 +83,9 @@ type NodeActivatorsFactory struct {
 	// The forward and inverse maps of activator type and function name
 	forward map[NodeActivationType]string
 	inverse map[string]NodeActivationType
+
+	// The expressions of the activation functions registered from expressions by type
+	expressions map[NodeActivationType]string
 }
 
 // NewNodeActivatorsFactory Returns node activator factory initialized with default activation functions
@@ -67,6 +95,7 @@ func NewNodeActivatorsFactory() *NodeActivatorsFactory {
 		moduleActivators: make(map[NodeActivationType]ModuleActivationFunction),
 		forward:          make(map[NodeActivationType]string),
 		inverse:          make(map[string]NodeActivationType),
+		expressions:      make(map[NodeActivationType]string),
 	}
 	// Register neuron node activators
 	af.Register(SigmoidPlainActivation, plainSigmoid, "SigmoidPlainActivation")
@@ -90,14 +119,57 @@ func NewNodeActivatorsFactory() *NodeActivatorsFactory {
 	af.Register(SineActivation, sineFunction, "SineActivation")
 	af.Register(StepActivation, stepFunction, "StepActivation")
 
+	af.Register(ReLUActivation, rectifiedLinear, "ReLUActivation")
+	af.Register(LeakyReLUActivation, leakyRectifiedLinear, "LeakyReLUActivation")
+	af.Register(ELUActivation, exponentialLinear, "ELUActivation")
+	af.Register(SoftplusActivation, softplus, "SoftplusActivation")
+
+	af.Register(ParametricLeakyReLUActivation, parametricLeakyRectifiedLinear, "ParametricLeakyReLUActivation")
+	af.Register(PReLUActivation, parametricRectifiedLinear, "PReLUActivation")
+	af.Register(ParametricELUActivation, parametricExponentialLinear, "ParametricELUActivation")
+	af.Register(ParametricGaussianActivation, parametricGaussian, "ParametricGaussianActivation")
+	af.Register(ParametricSigmoidActivation, parametricSigmoid, "ParametricSigmoidActivation")
+
 	// register neuron modules activators
 	af.RegisterModule(MultiplyModuleActivation, multiplyModule, "MultiplyModuleActivation")
 	af.RegisterModule(MaxModuleActivation, maxModule, "MaxModuleActivation")
 	af.RegisterModule(MinModuleActivation, minModule, "MinModuleActivation")
+	af.RegisterModule(SoftmaxModuleActivation, softmaxModule, "SoftmaxModuleActivation")
+	af.RegisterModule(LogSoftmaxModuleActivation, logSoftmaxModule, "LogSoftmaxModuleActivation")
+	af.RegisterModule(NormalizeModuleActivation, normalizeModule, "NormalizeModuleActivation")
+	af.RegisterModule(WinnerTakeAllModuleActivation, winnerTakeAllModule, "WinnerTakeAllModuleActivation")
 
 	return af
 }
 
+// Clone Returns the copy of this factory with all registered activation functions. The activation functions registered
+// into the copy are not visible from this factory and vice versa.
+func (a *NodeActivatorsFactory) Clone() *NodeActivatorsFactory {
+	af := &NodeActivatorsFactory{
+		activators:       make(map[NodeActivationType]ActivationFunction, len(a.activators)),
+		moduleActivators: make(map[NodeActivationType]ModuleActivationFunction, len(a.moduleActivators)),
+		forward:          make(map[NodeActivationType]string, len(a.forward)),
+		inverse:          make(map[string]NodeActivationType, len(a.inverse)),
+		expressions:      make(map[NodeActivationType]string, len(a.expressions)),
+	}
+	for t, fn := range a.activators {
+		af.activators[t] = fn
+	}
+	for t, fn := range a.moduleActivators {
+		af.moduleActivators[t] = fn
+	}
+	for t, name := range a.forward {
+		af.forward[t] = name
+	}
+	for name, t := range a.inverse {
+		af.inverse[name] = t
+	}
+	for t, expr := range a.expressions {
+		af.expressions[t] = expr
+	}
+	return af
+}
+
 // ActivateByType is to calculate activation value for give input and auxiliary parameters using activation function with specified type.
 // Will return error and -0.0 activation if unsupported activation type requested.
 func (a *NodeActivatorsFactory) ActivateByType(input float64, auxParams []float64, aType NodeActivationType) (float64, error) {
@@ -118,6 +190,12 @@ func (a *NodeActivatorsFactory) ActivateModuleByType(inputs []float64, auxParams
 	}
 }
 
+// IsModuleActivationType Checks whether the activation function with given type is registered as module activator
+func (a *NodeActivatorsFactory) IsModuleActivationType(aType NodeActivationType) bool {
+	_, ok := a.moduleActivators[aType]
+	return ok
+}
+
 // Register Registers given neuron activation function with provided type and name into the factory
 func (a *NodeActivatorsFactory) Register(aType NodeActivationType, aFunc ActivationFunction, fName string) {
 	// store function
@@ -136,6 +214,48 @@ func (a *NodeActivatorsFactory) RegisterModule(aType NodeActivationType, aFunc M
 	a.inverse[fName] = aType
 }
 
+// RegisterExpression Compiles given activation function expression (see CompileExpression) and registers it into the
+// factory with provided name under the next free activation type starting from FirstCustomActivation. Registering of
+// the same expression with the same name again returns the already assigned type. Returns error if expression is
+// invalid or the name is already used by another activation function.
+func (a *NodeActivatorsFactory) RegisterExpression(fName, expression string) (NodeActivationType, error) {
+	if len(fName) == 0 || strings.IndexFunc(fName, unicode.IsSpace) >= 0 {
+		return 0, fmt.Errorf("invalid activation function name: %q", fName)
+	}
+	if aType, ok := a.inverse[fName]; ok {
+		if expr, ok := a.expressions[aType]; ok && expr == expression {
+			return aType, nil
+		}
+		return 0, fmt.Errorf("activation function name already registered: %s", fName)
+	}
+	fn, err := CompileExpression(expression)
+	if err != nil {
+		return 0, err
+	}
+	aType := FirstCustomActivation
+	for ; a.isRegistered(aType); aType++ {
+		if aType == math.MaxUint8 {
+			return 0, fmt.Errorf("no free activation type to register: %s", fName)
+		}
+	}
+	a.Register(aType, fn, fName)
+	a.expressions[aType] = expression
+	return aType, nil
+}
+
+// ActivationExpression Returns the expression of the activation function with given type if it was registered from
+// expression, see RegisterExpression
+func (a *NodeActivatorsFactory) ActivationExpression(aType NodeActivationType) (string, bool) {
+	expr, ok := a.expressions[aType]
+	return expr, ok
+}
+
+// isRegistered Checks whether any activation function is registered with given type
+func (a *NodeActivatorsFactory) isRegistered(aType NodeActivationType) bool {
+	_, ok := a.forward[aType]
+	return ok
+}
+
 // ActivationTypeFromName Parse node activation type name and return corresponding activation type
 func (a *NodeActivatorsFactory) ActivationTypeFromName(name string) (NodeActivationType, error) {
 	if t, ok := a.inverse[name]; ok {
@@ -272,6 +392,120 @@ var (
 	}
 )
 
+// The ReLU family and softplus activation functions
+var (
+	// The rectified linear unit x<0 ? 0.0 : x
+	rectifiedLinear = func(input float64, auxParams []float64) float64 {
+		return math.Max(0.0, input)
+	}
+	// The leaky rectified linear unit with slope 0.01 for negative inputs
+	leakyRectifiedLinear = func(input float64, auxParams []float64) float64 {
+		return leakyReLU(input, DefaultLeakyReLUSlope)
+	}
+	// The exponential linear unit with alpha 1.0
+	exponentialLinear = func(input float64, auxParams []float64) float64 {
+		return elu(input, DefaultELUAlpha)
+	}
+	// The softplus, i.e., the smooth approximation of ReLU log(1 + e^x)
+	softplus = func(input float64, auxParams []float64) float64 {
+		// log1p(exp(x)) overflows for large inputs where softplus(x) = x + log1p(exp(-x))
+		return math.Max(0.0, input) + math.Log1p(math.Exp(-math.Abs(input)))
+	}
+)
+
+// The default shape parameters of the parametric activation functions used when node has no auxiliary parameters
+const (
+	// DefaultLeakyReLUSlope the default slope of the leaky ReLU for negative inputs
+	DefaultLeakyReLUSlope = 0.01
+	// DefaultPReLUSlope the default slope of the PReLU for negative inputs (He et al., 2015)
+	DefaultPReLUSlope = 0.25
+	// DefaultELUAlpha the default saturation value of the ELU for negative inputs
+	DefaultELUAlpha = 1.0
+	// DefaultGaussianWidth the default standard deviation of the parametric Gaussian
+	DefaultGaussianWidth = 1.0
+	// DefaultSigmoidSlope the default slope of the parametric sigmoid, the same as of the steepened sigmoid
+	DefaultSigmoidSlope = 4.924273
+	// DefaultSigmoidShift the default shift of the parametric sigmoid along the input axis
+	DefaultSigmoidShift = 0.0
+)
+
+// The ranges of the shape parameters of the parametric activation functions. The auxiliary parameter is clamped to the
+// [0, 1] range and mapped linearly onto the range of the shape parameter, see AuxParamInRange.
+const (
+	// ParametricLeakyReLUMinSlope, ParametricLeakyReLUMaxSlope the slope range of the parametric leaky ReLU
+	ParametricLeakyReLUMinSlope, ParametricLeakyReLUMaxSlope = 0.0, 0.3
+	// PReLUMinSlope, PReLUMaxSlope the slope range of the PReLU, from ReLU to linear
+	PReLUMinSlope, PReLUMaxSlope = 0.0, 1.0
+	// ParametricELUMinAlpha, ParametricELUMaxAlpha the alpha range of the parametric ELU
+	ParametricELUMinAlpha, ParametricELUMaxAlpha = 0.0, 2.0
+	// ParametricGaussianMinWidth, ParametricGaussianMaxWidth the standard deviation range of the parametric Gaussian
+	ParametricGaussianMinWidth, ParametricGaussianMaxWidth = 0.1, 3.0
+	// ParametricSigmoidMinSlope, ParametricSigmoidMaxSlope the slope range of the parametric sigmoid
+	ParametricSigmoidMinSlope, ParametricSigmoidMaxSlope = 0.5, 10.0
+	// ParametricSigmoidMinShift, ParametricSigmoidMaxShift the shift range of the parametric sigmoid
+	ParametricSigmoidMinShift, ParametricSigmoidMaxShift = -2.4621365, 2.4621365
+)
+
+// AuxParamInRange Maps the auxiliary parameter at given index clamped to [0, 1] onto the range [min, max]. Returns
+// the default value if there is no parameter at given index.
+func AuxParamInRange(auxParams []float64, index int, min, max, def float64) float64 {
+	if index >= len(auxParams) {
+		return def
+	}
+	value := math.Max(0.0, math.Min(1.0, auxParams[index]))
+	return min + value*(max-min)
+}
+
+// The parametric activation functions reading the shape parameters from the auxiliary parameters, the first parameter
+// defines the slope, the alpha or the width of the function.
+var (
+	// The leaky ReLU with evolvable slope for negative inputs
+	parametricLeakyRectifiedLinear = func(input float64, auxParams []float64) float64 {
+		return leakyReLU(input, AuxParamInRange(auxParams, 0,
+			ParametricLeakyReLUMinSlope, ParametricLeakyReLUMaxSlope, DefaultLeakyReLUSlope))
+	}
+	// The parametric ReLU (He et al., 2015) with evolvable slope for negative inputs
+	parametricRectifiedLinear = func(input float64, auxParams []float64) float64 {
+		return leakyReLU(input, AuxParamInRange(auxParams, 0, PReLUMinSlope, PReLUMaxSlope, DefaultPReLUSlope))
+	}
+	// The ELU with evolvable saturation value for negative inputs
+	parametricExponentialLinear = func(input float64, auxParams []float64) float64 {
+		return elu(input, AuxParamInRange(auxParams, 0,
+			ParametricELUMinAlpha, ParametricELUMaxAlpha, DefaultELUAlpha))
+	}
+	// The Gaussian exp(-x^2 / (2 * width^2)) with evolvable width
+	parametricGaussian = func(input float64, auxParams []float64) float64 {
+		width := AuxParamInRange(auxParams, 0,
+			ParametricGaussianMinWidth, ParametricGaussianMaxWidth, DefaultGaussianWidth)
+		return math.Exp(-input * input / (2.0 * width * width))
+	}
+	// The sigmoid 1 / (1 + exp(-(slope * input + shift))) with evolvable slope and shift, the second parameter
+	// defines the shift
+	parametricSigmoid = func(input float64, auxParams []float64) float64 {
+		slope := AuxParamInRange(auxParams, 0,
+			ParametricSigmoidMinSlope, ParametricSigmoidMaxSlope, DefaultSigmoidSlope)
+		shift := AuxParamInRange(auxParams, 1,
+			ParametricSigmoidMinShift, ParametricSigmoidMaxShift, DefaultSigmoidShift)
+		return 1.0 / (1.0 + math.Exp(-(slope*input + shift)))
+	}
+)
+
+// leakyReLU Returns the input for non-negative inputs and the input scaled by slope otherwise
+func leakyReLU(input, slope float64) float64 {
+	if input < 0.0 {
+		return slope * input
+	}
+	return input
+}
+
+// elu Returns the input for non-negative inputs and alpha * (e^x - 1) otherwise
+func elu(input, alpha float64) float64 {
+	if input < 0.0 {
+		return alpha * math.Expm1(input)
+	}
+	return input
+}
+
 // The modular activators
 var (
 	// Multiplies input values and returns multiplication result
@@ -298,4 +532,75 @@ var (
 		}
 		return []float64{min}
 	}
+	// Applies softmax to the input values and returns the probabilities per each input
+	softmaxModule = func(inputs []float64, auxParams []float64) []float64 {
+		ret := make([]float64, len(inputs))
+		if len(inputs) == 0 {
+			return ret
+		}
+		// subtract maximal value for numerical stability
+		max := inputs[0]
+		for _, v := range inputs[1:] {
+			max = math.Max(max, v)
+		}
+		sum := 0.0
+		for i, v := range inputs {
+			ret[i] = math.Exp(v - max)
+			sum += ret[i]
+		}
+		for i := range ret {
+			ret[i] /= sum
+		}
+		return ret
+	}
+	// Applies logarithm of softmax to the input values and returns the log-probabilities per each input
+	logSoftmaxModule = func(inputs []float64, auxParams []float64) []float64 {
+		ret := make([]float64, len(inputs))
+		if len(inputs) == 0 {
+			return ret
+		}
+		max := inputs[0]
+		for _, v := range inputs[1:] {
+			max = math.Max(max, v)
+		}
+		sum := 0.0
+		for _, v := range inputs {
+			sum += math.Exp(v - max)
+		}
+		logSum := math.Log(sum)
+		for i, v := range inputs {
+			ret[i] = v - max - logSum
+		}
+		return ret
+	}
+	// Divides input values by the sum of their absolute values, the zero inputs are returned unchanged
+	normalizeModule = func(inputs []float64, auxParams []float64) []float64 {
+		ret := make([]float64, len(inputs))
+		sum := 0.0
+		for _, v := range inputs {
+			sum += math.Abs(v)
+		}
+		if sum == 0 {
+			return ret
+		}
+		for i, v := range inputs {
+			ret[i] = v / sum
+		}
+		return ret
+	}
+	// Returns one for the first maximal input value and zero for all others
+	winnerTakeAllModule = func(inputs []float64, auxParams []float64) []float64 {
+		ret := make([]float64, len(inputs))
+		if len(inputs) == 0 {
+			return ret
+		}
+		winner := 0
+		for i, v := range inputs {
+			if v > inputs[winner] {
+				winner = i
+			}
+		}
+		ret[winner] = 1.0
+		return ret
+	}
 )
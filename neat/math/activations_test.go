@@ -0,0 +1,157 @@
+package math
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestNodeActivationType_values(t *testing.T) {
+	// the values of activation types are stored in the genome files and must be kept stable
+	assert.Equal(t, NodeActivationType(1), SigmoidPlainActivation)
+	assert.Equal(t, NodeActivationType(19), StepActivation)
+	assert.Equal(t, NodeActivationType(20), MultiplyModuleActivation)
+	assert.Equal(t, NodeActivationType(21), MaxModuleActivation)
+	assert.Equal(t, NodeActivationType(22), MinModuleActivation)
+	assert.Equal(t, NodeActivationType(23), SoftmaxModuleActivation)
+	assert.Equal(t, NodeActivationType(26), WinnerTakeAllModuleActivation)
+	assert.Equal(t, NodeActivationType(27), ReLUActivation)
+	assert.Equal(t, NodeActivationType(35), ParametricSigmoidActivation)
+}
+
+func TestNodeActivatorsFactory_ActivateModuleByType_decoders(t *testing.T) {
+	inputs := []float64{1.0, 2.0, 3.0}
+	softmax := []float64{0.09003057317038046, 0.24472847105479767, 0.6652409557748219}
+	testCases := []struct {
+		aType    NodeActivationType
+		expected []float64
+	}{
+		{aType: SoftmaxModuleActivation, expected: softmax},
+		{aType: LogSoftmaxModuleActivation, expected: []float64{math.Log(softmax[0]), math.Log(softmax[1]), math.Log(softmax[2])}},
+		{aType: NormalizeModuleActivation, expected: []float64{1.0 / 6.0, 2.0 / 6.0, 3.0 / 6.0}},
+		{aType: WinnerTakeAllModuleActivation, expected: []float64{0, 0, 1}},
+	}
+	for _, tc := range testCases {
+		name, err := NodeActivators.ActivationNameFromType(tc.aType)
+		require.NoError(t, err)
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, NodeActivators.IsModuleActivationType(tc.aType))
+			outputs, err := NodeActivators.ActivateModuleByType(inputs, nil, tc.aType)
+			require.NoError(t, err, "failed to activate")
+			assert.InDeltaSlice(t, tc.expected, outputs, 1e-12)
+
+			// the module must produce output per each input, even for empty inputs
+			outputs, err = NodeActivators.ActivateModuleByType([]float64{}, nil, tc.aType)
+			require.NoError(t, err, "failed to activate")
+			assert.Empty(t, outputs)
+		})
+	}
+	assert.False(t, NodeActivators.IsModuleActivationType(SigmoidSteepenedActivation))
+}
+
+func TestSoftmaxModule_largeInputs(t *testing.T) {
+	outputs := softmaxModule([]float64{1000, 1000}, nil)
+	assert.Equal(t, []float64{0.5, 0.5}, outputs)
+
+	outputs = logSoftmaxModule([]float64{1000, 1000}, nil)
+	assert.InDeltaSlice(t, []float64{-math.Ln2, -math.Ln2}, outputs, 1e-12)
+}
+
+func TestNormalizeModule_zeroInputs(t *testing.T) {
+	assert.Equal(t, []float64{0, 0}, normalizeModule([]float64{0, 0}, nil))
+	assert.Equal(t, []float64{-0.25, 0.75}, normalizeModule([]float64{-1, 3}, nil))
+}
+
+func TestWinnerTakeAllModule_ties(t *testing.T) {
+	assert.Equal(t, []float64{0, 1, 0}, winnerTakeAllModule([]float64{-1, 2, 2}, nil))
+}
+
+func TestNodeActivatorsFactory_ActivateByType_reluFamily(t *testing.T) {
+	testCases := []struct {
+		aType    NodeActivationType
+		input    float64
+		expected float64
+	}{
+		{aType: ReLUActivation, input: -2.0, expected: 0.0},
+		{aType: ReLUActivation, input: 2.0, expected: 2.0},
+		{aType: LeakyReLUActivation, input: -2.0, expected: -0.02},
+		{aType: LeakyReLUActivation, input: 2.0, expected: 2.0},
+		{aType: ELUActivation, input: -1.0, expected: math.Exp(-1.0) - 1.0},
+		{aType: ELUActivation, input: 2.0, expected: 2.0},
+		{aType: SoftplusActivation, input: 0.0, expected: math.Ln2},
+		{aType: SoftplusActivation, input: 1000.0, expected: 1000.0},
+		{aType: SoftplusActivation, input: -1000.0, expected: 0.0},
+	}
+	for _, tc := range testCases {
+		out, err := NodeActivators.ActivateByType(tc.input, nil, tc.aType)
+		require.NoError(t, err)
+		assert.InDelta(t, tc.expected, out, 1e-12, "type: %d, input: %f", tc.aType, tc.input)
+	}
+}
+
+func TestNodeActivatorsFactory_ActivateByType_parametric(t *testing.T) {
+	testCases := []struct {
+		name      string
+		aType     NodeActivationType
+		input     float64
+		auxParams []float64
+		expected  float64
+	}{
+		{name: "leaky ReLU default", aType: ParametricLeakyReLUActivation, input: -1.0, expected: -0.01},
+		{name: "leaky ReLU", aType: ParametricLeakyReLUActivation, input: -1.0, auxParams: []float64{0.5}, expected: -0.15},
+		{name: "leaky ReLU positive", aType: ParametricLeakyReLUActivation, input: 1.0, auxParams: []float64{0.5}, expected: 1.0},
+		{name: "PReLU default", aType: PReLUActivation, input: -1.0, expected: -0.25},
+		{name: "PReLU clamped", aType: PReLUActivation, input: -1.0, auxParams: []float64{2.0}, expected: -1.0},
+		{name: "PReLU zero", aType: PReLUActivation, input: -1.0, auxParams: []float64{0.0}, expected: 0.0},
+		{name: "ELU default", aType: ParametricELUActivation, input: -1.0, expected: math.Expm1(-1.0)},
+		{name: "ELU", aType: ParametricELUActivation, input: -1.0, auxParams: []float64{1.0}, expected: 2.0 * math.Expm1(-1.0)},
+		{name: "Gaussian default", aType: ParametricGaussianActivation, input: 1.0, expected: math.Exp(-0.5)},
+		{name: "Gaussian", aType: ParametricGaussianActivation, input: 3.0, auxParams: []float64{1.0}, expected: math.Exp(-0.5)},
+		{name: "Gaussian center", aType: ParametricGaussianActivation, input: 0.0, auxParams: []float64{0.2}, expected: 1.0},
+		{name: "sigmoid default", aType: ParametricSigmoidActivation, input: 0.5, expected: steepenedSigmoid(0.5, nil)},
+		{name: "sigmoid slope", aType: ParametricSigmoidActivation, input: 0.1, auxParams: []float64{1.0, 0.5}, expected: 1.0 / (1.0 + math.Exp(-1.0))},
+		{name: "sigmoid shift", aType: ParametricSigmoidActivation, input: 0.0, auxParams: []float64{0.0, 1.0}, expected: 1.0 / (1.0 + math.Exp(-2.4621365))},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := NodeActivators.ActivateByType(tc.input, tc.auxParams, tc.aType)
+			require.NoError(t, err)
+			assert.InDelta(t, tc.expected, out, 1e-12)
+		})
+	}
+}
+
+func TestAuxParamInRange(t *testing.T) {
+	assert.Equal(t, 5.0, AuxParamInRange(nil, 0, 1.0, 3.0, 5.0))
+	assert.Equal(t, 5.0, AuxParamInRange([]float64{0.5}, 1, 1.0, 3.0, 5.0))
+	assert.Equal(t, 2.0, AuxParamInRange([]float64{0.5}, 0, 1.0, 3.0, 5.0))
+	assert.Equal(t, 1.0, AuxParamInRange([]float64{-1.0}, 0, 1.0, 3.0, 5.0))
+	assert.Equal(t, 3.0, AuxParamInRange([]float64{10.0}, 0, 1.0, 3.0, 5.0))
+}
+
+func TestNodeActivatorsFactory_Clone(t *testing.T) {
+	factory := NewNodeActivatorsFactory()
+	swish, err := factory.RegisterExpression("SwishActivation", "x * sigmoid(x)")
+	require.NoError(t, err)
+
+	clone := factory.Clone()
+	out, err := clone.ActivateByType(0.5, nil, SigmoidSteepenedActivation)
+	require.NoError(t, err)
+	assert.Equal(t, steepenedSigmoid(0.5, nil), out)
+	outputs, err := clone.ActivateModuleByType([]float64{1, 2}, nil, MaxModuleActivation)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2}, outputs)
+	aType, err := clone.ActivationTypeFromName("SwishActivation")
+	require.NoError(t, err)
+	assert.Equal(t, swish, aType)
+	expr, ok := clone.ActivationExpression(swish)
+	assert.True(t, ok)
+	assert.Equal(t, "x * sigmoid(x)", expr)
+
+	// the registrations are independent
+	square, err := clone.RegisterExpression("SquareActivation", "x^2")
+	require.NoError(t, err)
+	_, err = factory.ActivationNameFromType(square)
+	assert.Error(t, err)
+}
@@ -0,0 +1,342 @@
+package math
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The functions which can be used in the activation function expressions with their implementations. The unary
+// functions take one argument and the binary take two arguments.
+var (
+	expressionUnaryFunctions = map[string]func(float64) float64{
+		"abs":     math.Abs,
+		"exp":     math.Exp,
+		"log":     math.Log,
+		"sqrt":    math.Sqrt,
+		"sin":     math.Sin,
+		"cos":     math.Cos,
+		"tan":     math.Tan,
+		"tanh":    math.Tanh,
+		"floor":   math.Floor,
+		"ceil":    math.Ceil,
+		"sigmoid": func(x float64) float64 { return 1.0 / (1.0 + math.Exp(-x)) },
+		"relu":    func(x float64) float64 { return math.Max(0.0, x) },
+		"sign":    func(x float64) float64 { return signFunction(x, nil) },
+		"step":    func(x float64) float64 { return stepFunction(x, nil) },
+	}
+	expressionBinaryFunctions = map[string]func(float64, float64) float64{
+		"min": math.Min,
+		"max": math.Max,
+		"pow": math.Pow,
+	}
+	expressionConstants = map[string]float64{
+		"pi": math.Pi,
+		"e":  math.E,
+	}
+)
+
+// CompileExpression Parses the activation function expression and compiles it into the ActivationFunction. The
+// expression is written in terms of the input value x and the auxiliary parameters a0, a1, ... of the node (see
+// NNode.Params), the missing auxiliary parameters are read as zero. The expression supports the numeric literals, the
+// pi and e constants, the + - * / ^ operators, the parentheses, and the following functions: abs, exp, log, sqrt, sin,
+// cos, tan, tanh, floor, ceil, sigmoid, relu, sign, step, min, max, and pow. For example, the Swish activation function
+// with evolvable slope can be expressed as "x * sigmoid(a0 * x)".
+func CompileExpression(expression string) (ActivationFunction, error) {
+	p := &expressionParser{expression: expression}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty activation expression")
+	}
+	fn, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.position < len(p.tokens) {
+		return nil, p.unexpected()
+	}
+	return ActivationFunction(fn), nil
+}
+
+// expressionFunc The compiled sub-expression
+type expressionFunc func(x float64, auxParams []float64) float64
+
+// expressionToken The token of the activation function expression
+type expressionToken struct {
+	// The text of the token
+	text string
+	// The offset of the token in the expression
+	offset int
+	// The numeric value of the token if it's a number literal
+	value float64
+	// The flag to indicate that token is a number literal
+	number bool
+	// The flag to indicate that token is an identifier, i.e., the name of variable, constant, or function
+	identifier bool
+}
+
+// expressionParser The recursive descent parser of the activation function expressions with following grammar:
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = "-" unary | "+" unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | identifier | identifier "(" sum { "," sum } ")" | "(" sum ")"
+type expressionParser struct {
+	// The expression to parse
+	expression string
+	// The tokens of the expression
+	tokens []expressionToken
+	// The index of the current token
+	position int
+}
+
+// tokenize Splits the expression into the tokens
+func (p *expressionParser) tokenize() error {
+	runes := []rune(p.expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+-*/^(),", r):
+			p.tokens = append(p.tokens, expressionToken{text: string(r), offset: i})
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// the exponent of the number literal, e.g. 1e-3
+			if i < len(runes)-1 && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if runes[j] == '+' || runes[j] == '-' {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					i = j
+					for i < len(runes) && unicode.IsDigit(runes[i]) {
+						i++
+					}
+				}
+			}
+			text := string(runes[start:i])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q at position %d in activation expression: %s", text, start, p.expression)
+			}
+			p.tokens = append(p.tokens, expressionToken{text: text, offset: start, value: value, number: true})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			p.tokens = append(p.tokens, expressionToken{text: string(runes[start:i]), offset: start, identifier: true})
+		default:
+			return fmt.Errorf("unexpected character %q at position %d in activation expression: %s", r, i, p.expression)
+		}
+	}
+	return nil
+}
+
+// peek Returns the text of the current token or empty string if all tokens are consumed
+func (p *expressionParser) peek() string {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position].text
+	}
+	return ""
+}
+
+// expect Consumes the current token if it has given text or returns error otherwise
+func (p *expressionParser) expect(text string) error {
+	if p.peek() != text {
+		return p.unexpected()
+	}
+	p.position++
+	return nil
+}
+
+// unexpected Returns the error describing the unexpected current token
+func (p *expressionParser) unexpected() error {
+	if p.position >= len(p.tokens) {
+		return fmt.Errorf("unexpected end of activation expression: %s", p.expression)
+	}
+	token := p.tokens[p.position]
+	return fmt.Errorf("unexpected token %q at position %d in activation expression: %s",
+		token.text, token.offset, p.expression)
+}
+
+// parseSum Parses the sum or difference of products
+func (p *expressionParser) parseSum() (expressionFunc, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "+" || op == "-"; op = p.peek() {
+		p.position++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		if op == "+" {
+			left = func(x float64, aux []float64) float64 { return l(x, aux) + right(x, aux) }
+		} else {
+			left = func(x float64, aux []float64) float64 { return l(x, aux) - right(x, aux) }
+		}
+	}
+	return left, nil
+}
+
+// parseProduct Parses the product or quotient of unary expressions
+func (p *expressionParser) parseProduct() (expressionFunc, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "*" || op == "/"; op = p.peek() {
+		p.position++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		if op == "*" {
+			left = func(x float64, aux []float64) float64 { return l(x, aux) * right(x, aux) }
+		} else {
+			left = func(x float64, aux []float64) float64 { return l(x, aux) / right(x, aux) }
+		}
+	}
+	return left, nil
+}
+
+// parseUnary Parses the expression with optional unary sign
+func (p *expressionParser) parseUnary() (expressionFunc, error) {
+	switch p.peek() {
+	case "-":
+		p.position++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(x float64, aux []float64) float64 { return -operand(x, aux) }, nil
+	case "+":
+		p.position++
+		return p.parseUnary()
+	}
+	return p.parsePower()
+}
+
+// parsePower Parses the primary expression with optional exponent
+func (p *expressionParser) parsePower() (expressionFunc, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "^" {
+		return base, nil
+	}
+	p.position++
+	// the power is right associative and binds tighter than unary minus of the base, i.e. -x^2 = -(x^2)
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return func(x float64, aux []float64) float64 { return math.Pow(base(x, aux), exponent(x, aux)) }, nil
+}
+
+// parsePrimary Parses the number, the variable, the function call, or the expression in parentheses
+func (p *expressionParser) parsePrimary() (expressionFunc, error) {
+	if p.position >= len(p.tokens) {
+		return nil, p.unexpected()
+	}
+	token := p.tokens[p.position]
+	switch {
+	case token.number:
+		p.position++
+		value := token.value
+		return func(float64, []float64) float64 { return value }, nil
+	case token.text == "(":
+		p.position++
+		fn, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		return fn, nil
+	case token.identifier:
+		p.position++
+		if p.peek() == "(" {
+			return p.parseCall(token)
+		}
+		return p.variable(token)
+	}
+	return nil, p.unexpected()
+}
+
+// parseCall Parses the arguments of the function call
+func (p *expressionParser) parseCall(name expressionToken) (expressionFunc, error) {
+	p.position++ // skip "("
+	args := make([]expressionFunc, 0)
+	for {
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.peek() != "," {
+			break
+		}
+		p.position++
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if fn, ok := expressionUnaryFunctions[name.text]; ok {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("function %q at position %d takes 1 argument, but %d given in activation expression: %s",
+				name.text, name.offset, len(args), p.expression)
+		}
+		arg := args[0]
+		return func(x float64, aux []float64) float64 { return fn(arg(x, aux)) }, nil
+	}
+	if fn, ok := expressionBinaryFunctions[name.text]; ok {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("function %q at position %d takes 2 arguments, but %d given in activation expression: %s",
+				name.text, name.offset, len(args), p.expression)
+		}
+		arg0, arg1 := args[0], args[1]
+		return func(x float64, aux []float64) float64 { return fn(arg0(x, aux), arg1(x, aux)) }, nil
+	}
+	return nil, fmt.Errorf("unknown function %q at position %d in activation expression: %s",
+		name.text, name.offset, p.expression)
+}
+
+// variable Returns the sub-expression reading the input value, the auxiliary parameter or the constant
+func (p *expressionParser) variable(name expressionToken) (expressionFunc, error) {
+	if name.text == "x" {
+		return func(x float64, _ []float64) float64 { return x }, nil
+	}
+	if value, ok := expressionConstants[name.text]; ok {
+		return func(float64, []float64) float64 { return value }, nil
+	}
+	if strings.HasPrefix(name.text, "a") {
+		if index, err := strconv.Atoi(name.text[1:]); err == nil {
+			return func(_ float64, aux []float64) float64 {
+				if index < len(aux) {
+					return aux[index]
+				}
+				return 0.0
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown variable %q at position %d in activation expression: %s",
+		name.text, name.offset, p.expression)
+}
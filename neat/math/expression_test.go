@@ -0,0 +1,134 @@
+package math
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestCompileExpression(t *testing.T) {
+	auxParams := []float64{0.5, 2.0}
+	testCases := []struct {
+		expression string
+		input      float64
+		expected   float64
+	}{
+		{expression: "x", input: 1.5, expected: 1.5},
+		{expression: "2 * x + 1", input: 1.5, expected: 4.0},
+		{expression: "2 * (x + 1)", input: 1.5, expected: 5.0},
+		{expression: "x - 1 - 2", input: 1.0, expected: -2.0},
+		{expression: "12 / 2 / 3", input: 0.0, expected: 2.0},
+		{expression: "-x^2", input: 3.0, expected: -9.0},
+		{expression: "2^3^2", input: 0.0, expected: 512.0},
+		{expression: "x^-1", input: 4.0, expected: 0.25},
+		{expression: "+x - -x", input: 2.0, expected: 4.0},
+		{expression: "1e-3 * x + 2.5E1", input: 1000.0, expected: 26.0},
+		{expression: "x * sigmoid(a0 * x)", input: 2.0, expected: 2.0 / (1.0 + math.Exp(-1.0))},
+		{expression: "a1 * tanh(x) + a5", input: 0.5, expected: 2.0 * math.Tanh(0.5)},
+		{expression: "exp(-x^2 / (2 * a1^2))", input: 2.0, expected: math.Exp(-0.5)},
+		{expression: "max(0, x) + min(0, a0 * x)", input: -2.0, expected: -1.0},
+		{expression: "pow(x, 3) + relu(-x)", input: -1.0, expected: 0.0},
+		{expression: "sin(pi * x) + log(e)", input: 0.5, expected: 2.0},
+		{expression: "abs(x) + sqrt(4) + floor(x) + ceil(x)", input: -1.5, expected: 0.5},
+		{expression: "sign(x) + step(x) + cos(0) + tan(0) + exp(0)", input: -3.0, expected: 1.0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			fn, err := CompileExpression(tc.expression)
+			require.NoError(t, err, "failed to compile")
+			assert.InDelta(t, tc.expected, fn(tc.input, auxParams), 1e-12)
+		})
+	}
+}
+
+func TestCompileExpression_missingAuxParams(t *testing.T) {
+	fn, err := CompileExpression("a0 + a3 * x + 1")
+	require.NoError(t, err, "failed to compile")
+	assert.Equal(t, 1.0, fn(2.0, nil))
+	assert.Equal(t, 1.5, fn(2.0, []float64{0.5}))
+}
+
+func TestCompileExpression_errors(t *testing.T) {
+	testCases := []string{
+		"",
+		"   ",
+		"x +",
+		"x * (1 + x",
+		"x)",
+		"x y",
+		"2 $ x",
+		"1.2.3 * x",
+		"y * x",
+		"a * x",
+		"foo(x)",
+		"sigmoid(x, 1)",
+		"max(x)",
+		"sin()",
+		"sin(x,)",
+		"x,",
+	}
+	for _, tc := range testCases {
+		fn, err := CompileExpression(tc)
+		assert.Error(t, err, "expression: %q", tc)
+		assert.Nil(t, fn)
+	}
+}
+
+func TestNodeActivatorsFactory_RegisterExpression(t *testing.T) {
+	factory := NewNodeActivatorsFactory()
+	aType, err := factory.RegisterExpression("SwishActivation", "x * sigmoid(a0 * x)")
+	require.NoError(t, err, "failed to register")
+	assert.Equal(t, FirstCustomActivation, aType)
+
+	out, err := factory.ActivateByType(2.0, []float64{0.5}, aType)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0/(1.0+math.Exp(-1.0)), out, 1e-12)
+
+	name, err := factory.ActivationNameFromType(aType)
+	require.NoError(t, err)
+	assert.Equal(t, "SwishActivation", name)
+	expr, ok := factory.ActivationExpression(aType)
+	assert.True(t, ok)
+	assert.Equal(t, "x * sigmoid(a0 * x)", expr)
+	_, ok = factory.ActivationExpression(SigmoidSteepenedActivation)
+	assert.False(t, ok)
+
+	// the same expression is registered only once
+	sameType, err := factory.RegisterExpression("SwishActivation", "x * sigmoid(a0 * x)")
+	require.NoError(t, err)
+	assert.Equal(t, aType, sameType)
+
+	nextType, err := factory.RegisterExpression("SquareActivation", "x^2")
+	require.NoError(t, err)
+	assert.Equal(t, FirstCustomActivation+1, nextType)
+
+	// the global factory is not affected
+	_, err = NodeActivators.ActivationTypeFromName("SwishActivation")
+	assert.Error(t, err)
+}
+
+func TestNodeActivatorsFactory_RegisterExpression_errors(t *testing.T) {
+	factory := NewNodeActivatorsFactory()
+	_, err := factory.RegisterExpression("SwishActivation", "x * sigmoid(")
+	assert.Error(t, err, "invalid expression")
+	_, err = factory.RegisterExpression("", "x")
+	assert.Error(t, err, "empty name")
+	_, err = factory.RegisterExpression("Swish Activation", "x")
+	assert.Error(t, err, "name with spaces")
+	_, err = factory.RegisterExpression("SigmoidSteepenedActivation", "x")
+	assert.Error(t, err, "name of built-in activation function")
+
+	_, err = factory.RegisterExpression("SwishActivation", "x * sigmoid(x)")
+	require.NoError(t, err)
+	_, err = factory.RegisterExpression("SwishActivation", "x * sigmoid(2 * x)")
+	assert.Error(t, err, "name registered with other expression")
+
+	// exhaust free activation types
+	for i := 1; i < 128; i++ {
+		_, err = factory.RegisterExpression("Activation"+string(rune('A'+i/26))+string(rune('a'+i%26)), "x")
+		require.NoError(t, err)
+	}
+	_, err = factory.RegisterExpression("OneMoreActivation", "x")
+	assert.Error(t, err, "no free activation types")
+}
@@ -56,6 +56,14 @@ type Options struct {
 	TraitMutationPower float64 `yaml:"trait_mutation_power"`
 	// The power of a link weight mutation
 	WeightMutPower float64 `yaml:"weight_mut_power"`
+	// The power of the neuron's time constant mutation, i.e. the standard deviation of the log-normal multiplicative noise
+	TimeConstantMutationPower float64 `yaml:"time_constant_mutation_power"`
+	// The power of the neuron's bias mutation
+	NodeBiasMutationPower float64 `yaml:"node_bias_mutation_power"`
+	// The power of the neuron's response (gain) mutation
+	NodeResponseMutationPower float64 `yaml:"node_response_mutation_power"`
+	// The maximal delay of the link in activation steps, which can be set by the link delay mutation
+	MaxLinkDelay int `yaml:"max_link_delay"`
 
 	// These 3 global coefficients are used to determine the formula for
 	// computing the compatibility between 2 genomes.  The formula is:
@@ -67,6 +75,9 @@ type Options struct {
 	DisjointCoeff float64 `yaml:"disjoint_coeff"`
 	ExcessCoeff   float64 `yaml:"excess_coeff"`
 	MutdiffCoeff  float64 `yaml:"mutdiff_coeff"`
+	// The coefficient of the average difference of the bias and response genes of the matching nodes, which is added
+	// to the compatibility formula: node_diff_coeff * mdmn
+	NodeDiffCoeff float64 `yaml:"node_diff_coeff"`
 
 	// This global tells compatibility threshold under which
 	// two Genomes are considered the same species
@@ -92,6 +103,20 @@ type Options struct {
 	MutateAddLinkProb      float64 `yaml:"mutate_add_link_prob"`
 	// probability of mutation involving disconnected inputs connection
 	MutateConnectSensors float64 `yaml:"mutate_connect_sensors"`
+	// probability of mutation of the time constant of a random neuron, which is used by CTRNN solver
+	MutateNodeTimeConstantProb float64 `yaml:"mutate_node_time_constant_prob"`
+	// probability that the node added by the add node mutation is the gated memory cell neuron
+	MutateAddMemoryNodeProb float64 `yaml:"mutate_add_memory_node_prob"`
+	// probability of mutation of the gate weights of a random memory cell neuron, uses WeightMutPower
+	MutateMemoryGatesProb float64 `yaml:"mutate_memory_gates_prob"`
+	// probability of mutation adding the modulatory neuron which gates plasticity of the links of existing neuron
+	MutateAddModulatoryNodeProb float64 `yaml:"mutate_add_modulatory_node_prob"`
+	// probability of mutation of the bias of a random neuron
+	MutateNodeBiasProb float64 `yaml:"mutate_node_bias_prob"`
+	// probability of mutation of the response (gain) of a random neuron
+	MutateNodeResponseProb float64 `yaml:"mutate_node_response_prob"`
+	// probability of mutation of the delay of a random link, the delay is limited by MaxLinkDelay
+	MutateLinkDelayProb float64 `yaml:"mutate_link_delay_prob"`
 
 	// Probabilities of a mate being outside species
 	InterspeciesMateRate  float64 `yaml:"interspecies_mate_rate"`
@@ -135,11 +160,35 @@ type Options struct {
 
 	// NodeActivatorsWithProbs the list of supported node activation with probability of each one
 	NodeActivatorsWithProbs []string `yaml:"node_activators"`
+	// CustomActivators the list of activation functions declared as math expressions, which can be referenced by name
+	// in NodeActivatorsWithProbs and in genome files
+	CustomActivators []CustomActivator `yaml:"custom_activators"`
+	// NodeActivatorsFactory the factory of activation functions used in this run, see Activators. It holds the custom
+	// activators of this run, which are not visible to other runs.
+	NodeActivatorsFactory *math.NodeActivatorsFactory `yaml:"-"`
 
 	// LogLevel the log output details level
 	LogLevel string `yaml:"log_level"`
 }
 
+// CustomActivator defines the activation function declared as the math expression of the input value x and the
+// auxiliary parameters a0, a1, ... of the node, e.g. "x * sigmoid(a0 * x)", see math.CompileExpression
+type CustomActivator struct {
+	// The name of the activation function
+	Name string `yaml:"name"`
+	// The math expression of the activation function
+	Expression string `yaml:"expression"`
+}
+
+// Activators Returns the factory of activation functions used in this run, which is the default math.NodeActivators
+// if NodeActivatorsFactory is not set
+func (c *Options) Activators() *math.NodeActivatorsFactory {
+	if c.NodeActivatorsFactory != nil {
+		return c.NodeActivatorsFactory
+	}
+	return math.NodeActivators
+}
+
 // RandomNodeActivationType Returns next random node activation type among registered with this context
 func (c *Options) RandomNodeActivationType() (math.NodeActivationType, error) {
 	// quick check for the most cases
@@ -156,6 +205,16 @@ func (c *Options) RandomNodeActivationType() (math.NodeActivationType, error) {
 
 // set default values for activator type and its probability of selection
 func (c *Options) initNodeActivators() (err error) {
+	// register custom activators to be referenced by name into the own factory of this run, which has all activators
+	// of the default or the provided factory, thus the custom activators never leak into the shared factories
+	if len(c.CustomActivators) > 0 {
+		c.NodeActivatorsFactory = c.Activators().Clone()
+	}
+	for _, ca := range c.CustomActivators {
+		if _, err = c.NodeActivatorsFactory.RegisterExpression(ca.Name, ca.Expression); err != nil {
+			return errors.Wrapf(err, "failed to register custom activator: %s", ca.Name)
+		}
+	}
 	if len(c.NodeActivatorsWithProbs) == 0 {
 		c.NodeActivators = []math.NodeActivationType{math.SigmoidSteepenedActivation}
 		c.NodeActivatorsProb = []float64{1.0}
@@ -167,7 +226,7 @@ func (c *Options) initNodeActivators() (err error) {
 	c.NodeActivatorsProb = make([]float64, len(actFns))
 	for i, line := range actFns {
 		fields := strings.Fields(line)
-		if c.NodeActivators[i], err = math.NodeActivators.ActivationTypeFromName(fields[0]); err != nil {
+		if c.NodeActivators[i], err = c.Activators().ActivationTypeFromName(fields[0]); err != nil {
 			return err
 		}
 		if prob, err := strconv.ParseFloat(fields[1], 64); err != nil {
@@ -245,12 +304,22 @@ func LoadNeatOptions(r io.Reader) (*Options, error) {
 			c.TraitMutationPower = cast.ToFloat64(param)
 		case "weight_mut_power":
 			c.WeightMutPower = cast.ToFloat64(param)
+		case "time_constant_mutation_power":
+			c.TimeConstantMutationPower = cast.ToFloat64(param)
+		case "node_bias_mutation_power":
+			c.NodeBiasMutationPower = cast.ToFloat64(param)
+		case "node_response_mutation_power":
+			c.NodeResponseMutationPower = cast.ToFloat64(param)
+		case "max_link_delay":
+			c.MaxLinkDelay = cast.ToInt(param)
 		case "disjoint_coeff":
 			c.DisjointCoeff = cast.ToFloat64(param)
 		case "excess_coeff":
 			c.ExcessCoeff = cast.ToFloat64(param)
 		case "mutdiff_coeff":
 			c.MutdiffCoeff = cast.ToFloat64(param)
+		case "node_diff_coeff":
+			c.NodeDiffCoeff = cast.ToFloat64(param)
 		case "compat_threshold":
 			c.CompatThreshold = cast.ToFloat64(param)
 		case "age_significance":
@@ -277,6 +346,20 @@ func LoadNeatOptions(r io.Reader) (*Options, error) {
 			c.MutateAddLinkProb = cast.ToFloat64(param)
 		case "mutate_connect_sensors":
 			c.MutateConnectSensors = cast.ToFloat64(param)
+		case "mutate_node_time_constant_prob":
+			c.MutateNodeTimeConstantProb = cast.ToFloat64(param)
+		case "mutate_add_memory_node_prob":
+			c.MutateAddMemoryNodeProb = cast.ToFloat64(param)
+		case "mutate_memory_gates_prob":
+			c.MutateMemoryGatesProb = cast.ToFloat64(param)
+		case "mutate_add_modulatory_node_prob":
+			c.MutateAddModulatoryNodeProb = cast.ToFloat64(param)
+		case "mutate_node_bias_prob":
+			c.MutateNodeBiasProb = cast.ToFloat64(param)
+		case "mutate_node_response_prob":
+			c.MutateNodeResponseProb = cast.ToFloat64(param)
+		case "mutate_link_delay_prob":
+			c.MutateLinkDelayProb = cast.ToFloat64(param)
 		case "interspecies_mate_rate":
 			c.InterspeciesMateRate = cast.ToFloat64(param)
 		case "mate_multipoint_prob":
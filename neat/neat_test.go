@@ -5,7 +5,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaricom/goNEAT/v3/neat/math"
+	gomath "math"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -62,6 +64,90 @@ func TestLoadYAMLOptions(t *testing.T) {
 	}
 }
 
+func TestLoadYAMLOptions_customActivators(t *testing.T) {
+	config := `
+epoch_executor: sequential
+genome_compat_method: linear
+log_level: info
+node_activators:
+  - "SwishTestActivation 0.7"
+  - "SigmoidSteepenedActivation 0.3"
+custom_activators:
+  - name: SwishTestActivation
+    expression: "x * sigmoid(a0 * x)"
+`
+	opts, err := LoadYAMLOptions(strings.NewReader(config))
+	require.NoError(t, err, "failed to load options")
+	require.Len(t, opts.CustomActivators, 1)
+	assert.Equal(t, CustomActivator{Name: "SwishTestActivation", Expression: "x * sigmoid(a0 * x)"}, opts.CustomActivators[0])
+
+	require.Len(t, opts.NodeActivators, 2)
+	swish := opts.NodeActivators[0]
+	assert.GreaterOrEqual(t, swish, math.FirstCustomActivation)
+	assert.Equal(t, []float64{0.7, 0.3}, opts.NodeActivatorsProb)
+	require.NotNil(t, opts.NodeActivatorsFactory)
+	out, err := opts.Activators().ActivateByType(2.0, []float64{0.5}, swish)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0/(1.0+gomath.Exp(-1.0)), out, 1e-12)
+
+	// the custom activators are registered only in the factory of this run
+	_, err = math.NodeActivators.ActivationTypeFromName("SwishTestActivation")
+	assert.Error(t, err)
+
+	// the options can be loaded again
+	optsOther, err := LoadYAMLOptions(strings.NewReader(config))
+	require.NoError(t, err, "failed to load options again")
+	assert.Equal(t, swish, optsOther.NodeActivators[0])
+	assert.NotSame(t, opts.Activators(), optsOther.Activators())
+}
+
+func TestOptions_Activators(t *testing.T) {
+	opts := &Options{}
+	assert.Same(t, math.NodeActivators, opts.Activators())
+
+	factory := math.NewNodeActivatorsFactory()
+	opts.NodeActivatorsFactory = factory
+	assert.Same(t, factory, opts.Activators())
+}
+
+func TestOptions_initNodeActivators_sharedFactory(t *testing.T) {
+	shared := math.NewNodeActivatorsFactory()
+	opts := &Options{
+		CustomActivators:      []CustomActivator{{Name: "SharedTestActivation", Expression: "x * x"}},
+		NodeActivatorsFactory: shared,
+	}
+	err := opts.initNodeActivators()
+	require.NoError(t, err, "failed to init node activators")
+	assert.NotSame(t, shared, opts.Activators())
+	_, err = opts.Activators().ActivationTypeFromName("SharedTestActivation")
+	assert.NoError(t, err)
+
+	// the custom activators are not registered in the shared and the default factories
+	_, err = shared.ActivationTypeFromName("SharedTestActivation")
+	assert.Error(t, err)
+	_, err = math.NodeActivators.ActivationTypeFromName("SharedTestActivation")
+	assert.Error(t, err)
+}
+
+func TestLoadYAMLOptions_customActivatorsError(t *testing.T) {
+	configs := []string{`
+log_level: info
+custom_activators:
+  - name: BrokenTestActivation
+    expression: "x * sigmoid("
+`, `
+log_level: info
+custom_activators:
+  - name: SigmoidSteepenedActivation
+    expression: "x"
+`}
+	for _, config := range configs {
+		opts, err := LoadYAMLOptions(strings.NewReader(config))
+		assert.Error(t, err)
+		assert.Nil(t, opts)
+	}
+}
+
 func TestLoadYAMLOptions_readError(t *testing.T) {
 	errorReader := ErrorReader(1)
 	opts, err := LoadYAMLOptions(&errorReader)
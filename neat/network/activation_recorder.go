@@ -0,0 +1,123 @@
+package network
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/sbinet/npyio/npz"
+	"gonum.org/v1/gonum/mat"
+	"io"
+	"strconv"
+)
+
+// ActivationRecorder records the activation values of all network nodes at every activation step of an episode.
+// It can be attached to the Network or to the FastModularNetworkSolver to trace activations of the recurrent
+// networks, and the recorded trace can be exported into NPZ or CSV for subsequent analysis.
+type ActivationRecorder struct {
+	// The IDs of the recorded nodes
+	NodeIds []int
+	// The recorded activations per step. Each row holds activations of the nodes in order of NodeIds.
+	Steps [][]float64
+}
+
+// NewActivationRecorder Creates new empty activation recorder
+func NewActivationRecorder() *ActivationRecorder {
+	return &ActivationRecorder{
+		Steps: make([][]float64, 0),
+	}
+}
+
+// StepsCount Returns the number of recorded steps
+func (r *ActivationRecorder) StepsCount() int {
+	return len(r.Steps)
+}
+
+// Reset Removes all recorded steps, e.g. to start recording of the new episode
+func (r *ActivationRecorder) Reset() {
+	r.Steps = make([][]float64, 0)
+}
+
+// Activations Returns the activation values per node ID recorded at the given step
+func (r *ActivationRecorder) Activations(step int) (map[int]float64, error) {
+	if step < 0 || step >= len(r.Steps) {
+		return nil, fmt.Errorf("step %d is out of range [0, %d)", step, len(r.Steps))
+	}
+	activations := make(map[int]float64, len(r.NodeIds))
+	for i, id := range r.NodeIds {
+		activations[id] = r.Steps[step][i]
+	}
+	return activations, nil
+}
+
+// Matrix Returns the recorded activations as matrix, where rows are steps and columns are nodes in order of NodeIds.
+// Returns nil if nothing recorded.
+func (r *ActivationRecorder) Matrix() *mat.Dense {
+	if len(r.Steps) == 0 || len(r.NodeIds) == 0 {
+		return nil
+	}
+	m := mat.NewDense(len(r.Steps), len(r.NodeIds), nil)
+	for i, step := range r.Steps {
+		m.SetRow(i, step)
+	}
+	return m
+}
+
+// WriteNPZ Dumps recorded activations to the NPZ file. The file has the following structure:
+// - node_ids - the IDs of the recorded nodes
+// - activations - the matrix of activations, where rows are steps and columns are nodes in order of node_ids
+func (r *ActivationRecorder) WriteNPZ(w io.Writer) error {
+	activations := r.Matrix()
+	if activations == nil {
+		return ErrNoActivationsRecorded
+	}
+	ids := make([]int64, len(r.NodeIds))
+	for i, id := range r.NodeIds {
+		ids[i] = int64(id)
+	}
+	out := npz.NewWriter(w)
+	if err := out.Write("node_ids", ids); err != nil {
+		return err
+	}
+	if err := out.Write("activations", activations); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// WriteCSV Dumps recorded activations to the CSV file. The first row is the header with the step column followed by
+// the IDs of the nodes, and each following row holds activations of the nodes at particular step.
+func (r *ActivationRecorder) WriteCSV(w io.Writer) error {
+	if len(r.Steps) == 0 {
+		return ErrNoActivationsRecorded
+	}
+	out := csv.NewWriter(w)
+	record := make([]string, len(r.NodeIds)+1)
+	record[0] = "step"
+	for i, id := range r.NodeIds {
+		record[i+1] = strconv.Itoa(id)
+	}
+	if err := out.Write(record); err != nil {
+		return err
+	}
+	for step, activations := range r.Steps {
+		record[0] = strconv.Itoa(step)
+		for i, a := range activations {
+			record[i+1] = strconv.FormatFloat(a, 'g', -1, 64)
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// record Appends the activations of the nodes with given IDs as the new step. The IDs are set on the first record.
+func (r *ActivationRecorder) record(ids []int, activations []float64) {
+	if r.NodeIds == nil {
+		r.NodeIds = make([]int, len(ids))
+		copy(r.NodeIds, ids)
+	}
+	step := make([]float64, len(activations))
+	copy(step, activations)
+	r.Steps = append(r.Steps, step)
+}
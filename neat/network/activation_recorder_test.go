@@ -0,0 +1,182 @@
+package network
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/sbinet/npyio/npz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+	"strconv"
+	"testing"
+)
+
+func TestNetwork_SetActivationRecorder(t *testing.T) {
+	net := buildNetwork()
+	recorder := NewActivationRecorder()
+	net.SetActivationRecorder(recorder)
+	assert.Equal(t, recorder, net.ActivationRecorder())
+
+	err := net.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+	_, err = net.ForwardSteps(3)
+	require.NoError(t, err, "failed to activate")
+
+	require.True(t, recorder.StepsCount() >= 3, "not all steps recorded")
+	allNodes := net.BaseNodes()
+	require.Len(t, recorder.NodeIds, len(allNodes))
+	for i, node := range allNodes {
+		assert.Equal(t, node.Id, recorder.NodeIds[i])
+	}
+	// the last step must hold current activations
+	last, err := recorder.Activations(recorder.StepsCount() - 1)
+	require.NoError(t, err)
+	for _, node := range allNodes {
+		assert.Equal(t, node.Activation, last[node.Id], "wrong activation of node: %d", node.Id)
+	}
+
+	// stop recording
+	steps := recorder.StepsCount()
+	net.SetActivationRecorder(nil)
+	_, err = net.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate")
+	assert.Equal(t, steps, recorder.StepsCount())
+}
+
+func TestFastModularNetworkSolver_SetActivationRecorder(t *testing.T) {
+	net := buildNetwork()
+	solver, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create solver")
+	fmm := solver.(*FastModularNetworkSolver)
+	recorder := NewActivationRecorder()
+	fmm.SetActivationRecorder(recorder)
+	assert.Equal(t, recorder, fmm.ActivationRecorder())
+
+	err = fmm.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+	_, err = fmm.ForwardSteps(3)
+	require.NoError(t, err, "failed to activate")
+	assert.Equal(t, 3, recorder.StepsCount())
+
+	// neurons are labeled by node IDs in order: bias, inputs, outputs, hidden
+	assert.Equal(t, []int{3, 1, 2, 7, 8, 4, 5, 6}, recorder.NodeIds)
+	last, err := recorder.Activations(2)
+	require.NoError(t, err)
+	outputs := fmm.ReadOutputs()
+	assert.Equal(t, outputs[0], last[7])
+	assert.Equal(t, outputs[1], last[8])
+	assert.Equal(t, 1.0, last[3], "wrong bias signal")
+	assert.Equal(t, 0.5, last[1], "wrong input signal")
+
+	_, err = fmm.RecursiveSteps()
+	require.NoError(t, err, "failed to activate")
+	assert.Equal(t, 4, recorder.StepsCount())
+
+	recorder.Reset()
+	assert.Equal(t, 0, recorder.StepsCount())
+}
+
+func TestNetworkModel_NewState_ActivationRecorder(t *testing.T) {
+	net := buildNetwork()
+	model, err := net.Model()
+	require.NoError(t, err, "failed to create model")
+	state := model.NewState()
+	recorder := NewActivationRecorder()
+	state.SetActivationRecorder(recorder)
+	_, err = state.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate")
+	assert.Equal(t, []int{3, 1, 2, 7, 8, 4, 5, 6}, recorder.NodeIds)
+}
+
+func TestNewFastModularNetworkSolver_ActivationRecorder_indexes(t *testing.T) {
+	solver := NewFastModularNetworkSolver(0, 1, 1, 2, nil, nil, nil, nil)
+	recorder := NewActivationRecorder()
+	solver.SetActivationRecorder(recorder)
+	solver.recordActivations()
+	assert.Equal(t, []int{0, 1}, recorder.NodeIds)
+}
+
+func TestActivationRecorder_Activations(t *testing.T) {
+	recorder := NewActivationRecorder()
+	recorder.record([]int{1, 2}, []float64{0.1, 0.2})
+	recorder.record([]int{1, 2}, []float64{0.3, 0.4})
+
+	activations, err := recorder.Activations(1)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]float64{1: 0.3, 2: 0.4}, activations)
+
+	_, err = recorder.Activations(2)
+	assert.Error(t, err)
+	_, err = recorder.Activations(-1)
+	assert.Error(t, err)
+}
+
+func TestActivationRecorder_record_copy(t *testing.T) {
+	recorder := NewActivationRecorder()
+	ids, activations := []int{1, 2}, []float64{0.1, 0.2}
+	recorder.record(ids, activations)
+	ids[0], activations[0] = 10, 10.0
+	assert.Equal(t, []int{1, 2}, recorder.NodeIds)
+	assert.Equal(t, []float64{0.1, 0.2}, recorder.Steps[0])
+}
+
+func TestActivationRecorder_Matrix(t *testing.T) {
+	recorder := NewActivationRecorder()
+	assert.Nil(t, recorder.Matrix())
+
+	recorder.record([]int{1, 2}, []float64{0.1, 0.2})
+	recorder.record([]int{1, 2}, []float64{0.3, 0.4})
+	expected := mat.NewDense(2, 2, []float64{0.1, 0.2, 0.3, 0.4})
+	assert.True(t, mat.Equal(expected, recorder.Matrix()))
+}
+
+func TestActivationRecorder_WriteNPZ(t *testing.T) {
+	recorder := NewActivationRecorder()
+	recorder.record([]int{1, 2}, []float64{0.1, 0.2})
+	recorder.record([]int{1, 2}, []float64{0.3, 0.4})
+
+	b := bytes.NewBuffer(nil)
+	err := recorder.WriteNPZ(b)
+	require.NoError(t, err, "failed to write NPZ")
+
+	r := bytes.NewReader(b.Bytes())
+	var ids []int64
+	err = npz.Read(r, "node_ids", &ids)
+	require.NoError(t, err, "failed to read node IDs")
+	assert.Equal(t, []int64{1, 2}, ids)
+
+	var activations mat.Dense
+	err = npz.Read(r, "activations", &activations)
+	require.NoError(t, err, "failed to read activations")
+	assert.True(t, mat.Equal(recorder.Matrix(), &activations))
+}
+
+func TestActivationRecorder_WriteCSV(t *testing.T) {
+	recorder := NewActivationRecorder()
+	recorder.record([]int{1, 2}, []float64{0.1, 0.2})
+	recorder.record([]int{1, 2}, []float64{0.3, -0.4})
+
+	b := bytes.NewBuffer(nil)
+	err := recorder.WriteCSV(b)
+	require.NoError(t, err, "failed to write CSV")
+
+	records, err := csv.NewReader(b).ReadAll()
+	require.NoError(t, err, "failed to read CSV")
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"step", "1", "2"}, records[0])
+	for step, row := range records[1:] {
+		assert.Equal(t, strconv.Itoa(step), row[0])
+		for i, v := range row[1:] {
+			value, err := strconv.ParseFloat(v, 64)
+			require.NoError(t, err)
+			assert.Equal(t, recorder.Steps[step][i], value)
+		}
+	}
+}
+
+func TestActivationRecorder_empty(t *testing.T) {
+	recorder := NewActivationRecorder()
+	b := bytes.NewBuffer(nil)
+	assert.EqualError(t, recorder.WriteNPZ(b), ErrNoActivationsRecorded.Error())
+	assert.EqualError(t, recorder.WriteCSV(b), ErrNoActivationsRecorded.Error())
+}
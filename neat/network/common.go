@@ -17,6 +17,8 @@ var (
 	ErrMaximalNetDepthExceeded = errors.New("depth of the network exceeds maximum allowed, fallback to maximal")
 	// ErrZeroActivationStepsRequested the error to be raised when zero activation steps requested
 	ErrZeroActivationStepsRequested = errors.New("zero activation steps requested")
+	// ErrNoActivationsRecorded the error to be raised when export of the empty activations trace requested
+	ErrNoActivationsRecorded = errors.New("no activations recorded")
 )
 
 // NodeType NNodeType defines the type of NNode to create
@@ -55,6 +57,11 @@ const (
 	OutputNeuron
 	// BiasNeuron The node is bias
 	BiasNeuron
+	// MemoryNeuron The node is gated memory cell in hidden layer, see ActivateMemoryCell
+	MemoryNeuron
+	// ModulatoryNeuron The node is modulatory neuron in hidden layer. Its output is not propagated as regular signal, but
+	// gates the plasticity of the incoming links of its target neurons, see ModulatedWeightChange.
+	ModulatoryNeuron
 )
 
 const (
@@ -62,6 +69,8 @@ const (
 	inputNeuronName  = "INPT"
 	outputNeuronName = "OUTP"
 	biasNeuronName   = "BIAS"
+	memoryNeuronName = "MEMO"
+	modulatoryName   = "MODU"
 	unknownNeuroName = "UNKNOWN NEURON TYPE"
 )
 
@@ -76,6 +85,10 @@ func NeuronTypeName(neuronType NodeNeuronType) string {
 		return outputNeuronName
 	case BiasNeuron:
 		return biasNeuronName
+	case MemoryNeuron:
+		return memoryNeuronName
+	case ModulatoryNeuron:
+		return modulatoryName
 	default:
 		return unknownNeuroName
 	}
@@ -92,15 +105,20 @@ func NeuronTypeByName(name string) (NodeNeuronType, error) {
 		return OutputNeuron, nil
 	case biasNeuronName:
 		return BiasNeuron, nil
+	case memoryNeuronName:
+		return MemoryNeuron, nil
+	case modulatoryName:
+		return ModulatoryNeuron, nil
 	default:
 		return math.MaxInt8, errors.New("Unknown neuron type name: " + name)
 	}
 }
 
 // ActivateNode Method to calculate activation for specified neuron node based on it's ActivationType field value.
+// The activation function is applied to the net input of the node (see NNode.NetInput).
 // Will return error and set -0.0 activation if unsupported activation type requested.
 func ActivateNode(node *NNode, a *neatmath.NodeActivatorsFactory) error {
-	out, err := a.ActivateByType(node.ActivationSum, node.Params, node.ActivationType)
+	out, err := a.ActivateByType(node.NetInput(), node.Params, node.ActivationType)
 	if err == nil {
 		node.setActivation(out)
 	}
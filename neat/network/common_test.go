@@ -36,7 +36,11 @@ func TestNeuronTypeName(t *testing.T) {
 	assert.Equal(t, outputNeuronName, name)
 	name = NeuronTypeName(BiasNeuron)
 	assert.Equal(t, biasNeuronName, name)
-	name = NeuronTypeName(BiasNeuron + 1)
+	name = NeuronTypeName(MemoryNeuron)
+	assert.Equal(t, memoryNeuronName, name)
+	name = NeuronTypeName(ModulatoryNeuron)
+	assert.Equal(t, modulatoryName, name)
+	name = NeuronTypeName(ModulatoryNeuron + 1)
 	assert.Equal(t, unknownNeuroName, name)
 }
 
@@ -53,6 +57,12 @@ func TestNeuronTypeByName(t *testing.T) {
 	nType, err = NeuronTypeByName(biasNeuronName)
 	assert.NoError(t, err)
 	assert.Equal(t, BiasNeuron, nType)
+	nType, err = NeuronTypeByName(memoryNeuronName)
+	assert.NoError(t, err)
+	assert.Equal(t, MemoryNeuron, nType)
+	nType, err = NeuronTypeByName(modulatoryName)
+	assert.NoError(t, err)
+	assert.Equal(t, ModulatoryNeuron, nType)
 	nType, err = NeuronTypeByName(unknownNeuroName)
 	assert.EqualError(t, err, "Unknown neuron type name: "+unknownNeuroName)
 	assert.Equal(t, NodeNeuronType(1<<7-1), nType)
@@ -63,7 +73,7 @@ func TestActivateNode(t *testing.T) {
 	err := ActivateNode(node, math.NodeActivators)
 	assert.NoError(t, err)
 
-	node.ActivationType = math.MinModuleActivation + 1
+	node.ActivationType = math.ParametricSigmoidActivation + 1
 	err = ActivateNode(node, math.NodeActivators)
 	assert.EqualError(t, err, fmt.Sprintf("unknown neuron activation type: %d", node.ActivationType))
 }
@@ -78,7 +88,7 @@ func TestActivateModule(t *testing.T) {
 	err := ActivateModule(node, math.NodeActivators)
 	assert.NoError(t, err)
 
-	node.ActivationType = math.MinModuleActivation + 1
+	node.ActivationType = math.ParametricSigmoidActivation + 1
 	err = ActivateModule(node, math.NodeActivators)
 	assert.EqualError(t, err, fmt.Sprintf("unknown module activation type: %d", node.ActivationType))
 
@@ -0,0 +1,330 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
+	"math"
+)
+
+// DefaultTimeConstant the time constant of the neuron used by the CTRNN solver when NNode.TimeConstant is not set
+const DefaultTimeConstant = 1.0
+
+// DefaultCTRNNTimeStep the default integration time step used by the CTRNN solver for the Solver interface methods
+const DefaultCTRNNTimeStep = 0.1
+
+// CTRNNIntegrationMethod defines the numerical method to integrate the CTRNN dynamics
+type CTRNNIntegrationMethod byte
+
+const (
+	// EulerIntegration the forward Euler method
+	EulerIntegration CTRNNIntegrationMethod = iota
+	// RK4Integration the classic fourth-order Runge-Kutta method
+	RK4Integration
+)
+
+// ctrnnConnection is the connection between neurons of the CTRNN
+type ctrnnConnection struct {
+	// The index of source neuron
+	source int
+	// The index of target neuron
+	target int
+	// The weight of connection
+	weight float64
+}
+
+// CTRNNSolver is the network solver implementing continuous-time recurrent neural network (CTRNN) dynamics.
+// The state y of each neuron evolves according to:
+//
+//	tau * dy/dt = -y + b + r * sum(w * o)
+//
+// where tau is the time constant of the neuron taken from NNode.TimeConstant, b and r are the bias and the response
+// of the neuron, and o are the outputs of the source neurons. The output of the sensor is its loaded value, and the
+// output of other neurons is their activation function applied to the neuron state. The bias is also provided by the
+// connections from the bias neurons. The dynamics is
+// integrated with the Euler or RK4 method over the given time step with Advance. The ForwardSteps and Relax methods
+// of the Solver interface advance the network by TimeStep per step.
+type CTRNNSolver struct {
+	// A network id
+	Id int
+	// Is a name of this network
+	Name string
+	// The integration method
+	Method CTRNNIntegrationMethod
+	// The integration time step used by ForwardSteps and Relax
+	TimeStep float64
+
+	// The factory of activation functions
+	activators *neatmath.NodeActivatorsFactory
+	// The activation functions per neuron
+	activationFunctions []neatmath.NodeActivationType
+	// The auxiliary parameters of the activation functions per neuron, see NNode.Params
+	activationParams [][]float64
+	// The time constants per neuron
+	timeConstants []float64
+	// The biases per neuron
+	biases []float64
+	// The connections between neurons
+	connections []ctrnnConnection
+
+	// The number of bias neurons. This is also the index of the first input neuron.
+	biasNeuronCount int
+	// The total number of sensors (bias + input). This is also the index of the first output neuron.
+	sensorNeuronCount int
+	// The number of output neurons
+	outputNeuronCount int
+
+	// The current state per neuron, for sensors it holds the loaded values
+	states []float64
+	// The outputs per neuron, the buffer to calculate derivatives
+	outputs []float64
+	// The buffers to hold intermediate states and derivatives of the integration
+	k1, k2, k3, k4, tmp []float64
+}
+
+// CTRNNSolver Creates the continuous-time recurrent neural network solver based on the architecture of this network
+// with provided integration method. The network modules (control nodes), memory cell and modulatory neurons, and
+// time-delayed links are not supported, because the delays are defined in discrete activation steps.
+func (n *Network) CTRNNSolver(method CTRNNIntegrationMethod) (*CTRNNSolver, error) {
+	if len(n.controlNodes) > 0 {
+		return nil, errors.New("CTRNN solver doesn't support network modules")
+	}
+	if method != EulerIntegration && method != RK4Integration {
+		return nil, fmt.Errorf("unsupported CTRNN integration method: %d", method)
+	}
+	biasList, inList, hiddenList := make([]*NNode, 0), make([]*NNode, 0), make([]*NNode, 0)
+	for _, ne := range n.allNodes {
+		switch ne.NeuronType {
+		case BiasNeuron:
+			biasList = append(biasList, ne)
+		case InputNeuron:
+			inList = append(inList, ne)
+		case HiddenNeuron:
+			hiddenList = append(hiddenList, ne)
+		case MemoryNeuron:
+			return nil, errors.New("CTRNN solver doesn't support memory cell neurons")
+		case ModulatoryNeuron:
+			return nil, errors.New("CTRNN solver doesn't support modulatory neurons")
+		}
+	}
+	totalNeuronCount := len(n.allNodes)
+	s := &CTRNNSolver{
+		Id:                  n.Id,
+		Name:                n.Name,
+		Method:              method,
+		TimeStep:            DefaultCTRNNTimeStep,
+		activators:          n.Activators(),
+		activationFunctions: make([]neatmath.NodeActivationType, totalNeuronCount),
+		activationParams:    make([][]float64, totalNeuronCount),
+		timeConstants:       make([]float64, totalNeuronCount),
+		biases:              make([]float64, totalNeuronCount),
+		biasNeuronCount:     len(biasList),
+		sensorNeuronCount:   len(biasList) + len(inList),
+		outputNeuronCount:   len(n.Outputs),
+		states:              make([]float64, totalNeuronCount),
+		outputs:             make([]float64, totalNeuronCount),
+		k1:                  make([]float64, totalNeuronCount),
+		k2:                  make([]float64, totalNeuronCount),
+		k3:                  make([]float64, totalNeuronCount),
+		k4:                  make([]float64, totalNeuronCount),
+		tmp:                 make([]float64, totalNeuronCount),
+	}
+
+	// neurons are ordered as following: bias, input, output, hidden
+	neuronLookup := make(map[int]int)
+	index := 0
+	for _, list := range [][]*NNode{biasList, inList, n.Outputs, hiddenList} {
+		for _, ne := range list {
+			neuronLookup[ne.Id] = index
+			s.activationFunctions[index] = ne.ActivationType
+			s.activationParams[index] = ne.Params
+			s.timeConstants[index] = DefaultTimeConstant
+			if ne.TimeConstant > 0 {
+				s.timeConstants[index] = ne.TimeConstant
+			}
+			s.biases[index] = ne.Bias
+			index++
+		}
+	}
+	if index != totalNeuronCount {
+		return nil, fmt.Errorf("the number of ordered neurons: %d doesn't match the total number of neurons: %d",
+			index, totalNeuronCount)
+	}
+	for _, ne := range n.allNodes {
+		if ne.IsSensor() {
+			// the sensors state is defined by the loaded values only
+			continue
+		}
+		for _, in := range ne.Incoming {
+			if in.Delay > 0 {
+				return nil, fmt.Errorf("CTRNN solver doesn't support time-delayed links: %s", in.IDString())
+			}
+			source, ok := neuronLookup[in.InNode.Id]
+			if !ok {
+				return nil, fmt.Errorf("failed to lookup for source neuron with id: %d", in.InNode.Id)
+			}
+			// the response of the neuron is folded into the weights of its incoming connections
+			s.connections = append(s.connections, ctrnnConnection{
+				source: source,
+				target: neuronLookup[ne.Id],
+				weight: in.ConnectionWeight * ne.ActivationResponse(),
+			})
+		}
+	}
+	s.Flush()
+	return s, nil
+}
+
+// Advance Integrates the network dynamics over the given time interval dt using the integration method of this
+// solver. The dt should be small relative to the time constants of neurons for accurate integration.
+func (s *CTRNNSolver) Advance(dt float64) error {
+	if dt <= 0 || math.IsNaN(dt) || math.IsInf(dt, 0) {
+		return fmt.Errorf("the time step must be positive: %f", dt)
+	}
+	switch s.Method {
+	case EulerIntegration:
+		if err := s.derivatives(s.states, s.k1); err != nil {
+			return err
+		}
+		for i := s.sensorNeuronCount; i < len(s.states); i++ {
+			s.states[i] += dt * s.k1[i]
+		}
+	case RK4Integration:
+		if err := s.derivatives(s.states, s.k1); err != nil {
+			return err
+		}
+		if err := s.derivatives(s.shifted(s.k1, dt/2), s.k2); err != nil {
+			return err
+		}
+		if err := s.derivatives(s.shifted(s.k2, dt/2), s.k3); err != nil {
+			return err
+		}
+		if err := s.derivatives(s.shifted(s.k3, dt), s.k4); err != nil {
+			return err
+		}
+		for i := s.sensorNeuronCount; i < len(s.states); i++ {
+			s.states[i] += dt / 6 * (s.k1[i] + 2*s.k2[i] + 2*s.k3[i] + s.k4[i])
+		}
+	default:
+		return fmt.Errorf("unsupported CTRNN integration method: %d", s.Method)
+	}
+	return nil
+}
+
+// ForwardSteps Advances the network given number of steps by TimeStep each.
+// Returns true if network was advanced successfully.
+func (s *CTRNNSolver) ForwardSteps(steps int) (bool, error) {
+	if steps <= 0 {
+		return false, ErrZeroActivationStepsRequested
+	}
+	for i := 0; i < steps; i++ {
+		if err := s.Advance(s.TimeStep); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// RecursiveSteps is not supported by the CTRNN solver, because its dynamics is defined over continuous time
+func (s *CTRNNSolver) RecursiveSteps() (bool, error) {
+	return false, errors.New("recursive activation is not supported by CTRNN solver")
+}
+
+// Relax Advances the network by TimeStep per step until the absolute change of the state of any neuron during
+// the step is less than maxAllowedSignalDelta or maxSteps reached. Returns true if network is relaxed.
+func (s *CTRNNSolver) Relax(maxSteps int, maxAllowedSignalDelta float64) (bool, error) {
+	previous := make([]float64, len(s.states))
+	for step := 0; step < maxSteps; step++ {
+		copy(previous, s.states)
+		if err := s.Advance(s.TimeStep); err != nil {
+			return false, err
+		}
+		relaxed := true
+		for i := s.sensorNeuronCount; i < len(s.states) && relaxed; i++ {
+			relaxed = math.Abs(s.states[i]-previous[i]) <= maxAllowedSignalDelta
+		}
+		if relaxed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Flush Resets the state of all neurons to zero. The bias neurons are set to one.
+func (s *CTRNNSolver) Flush() (bool, error) {
+	for i := range s.states {
+		s.states[i] = 0
+	}
+	for i := 0; i < s.biasNeuronCount; i++ {
+		s.states[i] = 1.0
+	}
+	return true, nil
+}
+
+// LoadSensors Set sensors values to the input nodes of the network
+func (s *CTRNNSolver) LoadSensors(inputs []float64) error {
+	inputCount := s.sensorNeuronCount - s.biasNeuronCount
+	if len(inputs) == inputCount {
+		copy(s.states[s.biasNeuronCount:s.sensorNeuronCount], inputs)
+	} else {
+		return ErrNetUnsupportedSensorsArraySize
+	}
+	return nil
+}
+
+// ReadOutputs Read output values from the output nodes of the network
+func (s *CTRNNSolver) ReadOutputs() []float64 {
+	outputs := make([]float64, s.outputNeuronCount)
+	for i := range outputs {
+		index := s.sensorNeuronCount + i
+		outputs[i], _ = s.activators.ActivateByType(s.states[index], s.activationParams[index], s.activationFunctions[index])
+	}
+	return outputs
+}
+
+// States Returns the copy of the current internal states of all neurons in order: bias, input, output, hidden
+func (s *CTRNNSolver) States() []float64 {
+	return append([]float64(nil), s.states...)
+}
+
+// NodeCount Returns the total number of neural units in the network
+func (s *CTRNNSolver) NodeCount() int {
+	return len(s.states)
+}
+
+// LinkCount Returns the total number of links between nodes in the network
+func (s *CTRNNSolver) LinkCount() int {
+	return len(s.connections)
+}
+
+func (s *CTRNNSolver) String() string {
+	return fmt.Sprintf("CTRNN solver, id: %d, name: [%s], neurons: %d, links: %d, method: %d, time step: %f",
+		s.Id, s.Name, s.NodeCount(), s.LinkCount(), s.Method, s.TimeStep)
+}
+
+// derivatives Calculates the time derivatives of the neurons' states at the given states
+func (s *CTRNNSolver) derivatives(states, dy []float64) (err error) {
+	copy(s.outputs[:s.sensorNeuronCount], states[:s.sensorNeuronCount])
+	for i := s.sensorNeuronCount; i < len(states); i++ {
+		if s.outputs[i], err = s.activators.ActivateByType(states[i], s.activationParams[i], s.activationFunctions[i]); err != nil {
+			return err
+		}
+		dy[i] = s.biases[i] - states[i]
+	}
+	for _, c := range s.connections {
+		dy[c.target] += c.weight * s.outputs[c.source]
+	}
+	for i := s.sensorNeuronCount; i < len(states); i++ {
+		dy[i] /= s.timeConstants[i]
+	}
+	return nil
+}
+
+// shifted Returns the states shifted along the provided derivatives by given time step
+func (s *CTRNNSolver) shifted(dy []float64, dt float64) []float64 {
+	copy(s.tmp[:s.sensorNeuronCount], s.states[:s.sensorNeuronCount])
+	for i := s.sensorNeuronCount; i < len(s.states); i++ {
+		s.tmp[i] = s.states[i] + dt*dy[i]
+	}
+	return s.tmp
+}
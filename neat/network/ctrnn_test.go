@@ -0,0 +1,175 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	gomath "math"
+	"testing"
+)
+
+// buildLeakyIntegratorNetwork returns network with single input connected to the linear output with given time constant
+func buildLeakyIntegratorNetwork(tau float64) *Network {
+	input := NewNNode(1, InputNeuron)
+	output := NewNNode(2, OutputNeuron)
+	output.ActivationType = math.LinearActivation
+	output.TimeConstant = tau
+	output.ConnectFrom(input, 1.0)
+	return NewNetwork([]*NNode{input}, []*NNode{output}, []*NNode{input, output}, 1)
+}
+
+func TestNetwork_CTRNNSolver(t *testing.T) {
+	net := buildNetwork()
+	solver, err := net.CTRNNSolver(RK4Integration)
+	require.NoError(t, err, "failed to create solver")
+	require.NotNil(t, solver)
+
+	assert.Equal(t, net.Id, solver.Id)
+	assert.Equal(t, net.Name, solver.Name)
+	assert.Equal(t, RK4Integration, solver.Method)
+	assert.Equal(t, DefaultCTRNNTimeStep, solver.TimeStep)
+	assert.Equal(t, 8, solver.NodeCount())
+	assert.Equal(t, 8, solver.LinkCount())
+	assert.NotEmpty(t, solver.String())
+
+	// check that bias neuron is set
+	states := solver.States()
+	assert.Equal(t, 1.0, states[0])
+	for _, s := range states[1:] {
+		assert.Zero(t, s)
+	}
+}
+
+func TestNetwork_CTRNNSolver_errors(t *testing.T) {
+	net := buildModularNetwork()
+	solver, err := net.CTRNNSolver(EulerIntegration)
+	assert.Error(t, err, "modular network must not be supported")
+	assert.Nil(t, solver)
+
+	net = buildNetwork()
+	solver, err = net.CTRNNSolver(CTRNNIntegrationMethod(10))
+	assert.Error(t, err, "unsupported integration method")
+	assert.Nil(t, solver)
+}
+
+func TestCTRNNSolver_Advance(t *testing.T) {
+	tau, input, dt, steps := 0.5, 2.0, 0.01, 100
+	expected := input * (1 - gomath.Exp(-float64(steps)*dt/tau))
+
+	testCases := []struct {
+		method CTRNNIntegrationMethod
+		delta  float64
+	}{
+		{method: EulerIntegration, delta: 1e-2},
+		{method: RK4Integration, delta: 1e-8},
+	}
+	for _, tc := range testCases {
+		solver, err := buildLeakyIntegratorNetwork(tau).CTRNNSolver(tc.method)
+		require.NoError(t, err, "failed to create solver")
+		err = solver.LoadSensors([]float64{input})
+		require.NoError(t, err, "failed to load sensors")
+		for i := 0; i < steps; i++ {
+			err = solver.Advance(dt)
+			require.NoError(t, err, "failed to advance at: %d", i)
+		}
+		outputs := solver.ReadOutputs()
+		require.Len(t, outputs, 1)
+		assert.InDelta(t, expected, outputs[0], tc.delta, "method: %d", tc.method)
+	}
+}
+
+func TestCTRNNSolver_Advance_wrongTimeStep(t *testing.T) {
+	solver, err := buildLeakyIntegratorNetwork(1.0).CTRNNSolver(EulerIntegration)
+	require.NoError(t, err, "failed to create solver")
+	for _, dt := range []float64{0, -0.1, gomath.NaN(), gomath.Inf(1)} {
+		assert.Error(t, solver.Advance(dt), "time step: %f", dt)
+	}
+}
+
+func TestCTRNNSolver_ForwardSteps(t *testing.T) {
+	solver, err := buildNetwork().CTRNNSolver(EulerIntegration)
+	require.NoError(t, err, "failed to create solver")
+	err = solver.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+
+	res, err := solver.ForwardSteps(5)
+	require.NoError(t, err, "failed to do forward steps")
+	assert.True(t, res)
+	outputs := solver.ReadOutputs()
+	require.Len(t, outputs, 2)
+	for _, out := range outputs {
+		assert.NotZero(t, out)
+	}
+
+	res, err = solver.ForwardSteps(0)
+	assert.EqualError(t, err, ErrZeroActivationStepsRequested.Error())
+	assert.False(t, res)
+}
+
+func TestCTRNNSolver_RecursiveSteps(t *testing.T) {
+	solver, err := buildNetwork().CTRNNSolver(EulerIntegration)
+	require.NoError(t, err, "failed to create solver")
+	res, err := solver.RecursiveSteps()
+	assert.Error(t, err)
+	assert.False(t, res)
+}
+
+func TestCTRNNSolver_Relax(t *testing.T) {
+	input := 2.0
+	solver, err := buildLeakyIntegratorNetwork(0.5).CTRNNSolver(RK4Integration)
+	require.NoError(t, err, "failed to create solver")
+	err = solver.LoadSensors([]float64{input})
+	require.NoError(t, err, "failed to load sensors")
+
+	relaxed, err := solver.Relax(10, 1e-6)
+	require.NoError(t, err, "failed to relax")
+	assert.False(t, relaxed, "must not relax in few steps")
+
+	relaxed, err = solver.Relax(1000, 1e-6)
+	require.NoError(t, err, "failed to relax")
+	assert.True(t, relaxed)
+	assert.InDelta(t, input, solver.ReadOutputs()[0], 1e-4)
+}
+
+func TestCTRNNSolver_Flush(t *testing.T) {
+	solver, err := buildNetwork().CTRNNSolver(EulerIntegration)
+	require.NoError(t, err, "failed to create solver")
+	err = solver.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+	_, err = solver.ForwardSteps(5)
+	require.NoError(t, err, "failed to do forward steps")
+
+	res, err := solver.Flush()
+	require.NoError(t, err, "failed to flush")
+	assert.True(t, res)
+	states := solver.States()
+	assert.Equal(t, []float64{1, 0, 0, 0, 0, 0, 0, 0}, states)
+}
+
+func TestCTRNNSolver_LoadSensors(t *testing.T) {
+	solver, err := buildNetwork().CTRNNSolver(EulerIntegration)
+	require.NoError(t, err, "failed to create solver")
+
+	err = solver.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+	assert.Equal(t, []float64{1, 0.5, 1.1}, solver.States()[:3])
+
+	err = solver.LoadSensors([]float64{1.0})
+	assert.EqualError(t, err, ErrNetUnsupportedSensorsArraySize.Error())
+}
+
+func TestCTRNNSolver_activationParams(t *testing.T) {
+	net := buildLeakyIntegratorNetwork(0.5)
+	output := net.Outputs[0]
+	output.ActivationType = math.PReLUActivation
+	for _, slope := range []float64{0.0, 0.5, 1.0} {
+		output.Params = []float64{slope}
+		solver, err := net.CTRNNSolver(EulerIntegration)
+		require.NoError(t, err, "failed to create solver")
+		require.NoError(t, solver.LoadSensors([]float64{-2.0}))
+		require.NoError(t, solver.Advance(0.01))
+		state := solver.States()[1]
+		require.Less(t, state, 0.0)
+		assert.InDelta(t, slope*state, solver.ReadOutputs()[0], 1e-12)
+	}
+}
@@ -0,0 +1,58 @@
+package network
+
+// delayLine is the ring buffer of signals relayed by the time-delayed connection. The signal pushed into the delay
+// line is received by the target neuron the given number of steps later.
+type delayLine struct {
+	// The signals in the ring buffer
+	signals []float64
+	// The position of the oldest signal in the ring buffer
+	position int
+}
+
+// newDelayLine Creates new delay line with given delay in steps filled with zero signals
+func newDelayLine(delay int) *delayLine {
+	return &delayLine{signals: make([]float64, delay)}
+}
+
+// shift Pushes the provided signal into the delay line and returns the signal pushed the delay steps ago
+func (d *delayLine) shift(signal float64) float64 {
+	out := d.signals[d.position]
+	d.signals[d.position] = signal
+	d.position = (d.position + 1) % len(d.signals)
+	return out
+}
+
+// flush Resets all signals in the delay line to zero
+func (d *delayLine) flush() {
+	for i := range d.signals {
+		d.signals[i] = 0
+	}
+	d.position = 0
+}
+
+// snapshot Returns the copy of the signals in the delay line ordered from the oldest to the newest
+func (d *delayLine) snapshot() []float64 {
+	signals := make([]float64, 0, len(d.signals))
+	signals = append(signals, d.signals[d.position:]...)
+	return append(signals, d.signals[:d.position]...)
+}
+
+// restore Sets the signals of the delay line from the snapshot ordered from the oldest to the newest
+func (d *delayLine) restore(signals []float64) {
+	copy(d.signals, signals)
+	d.position = 0
+}
+
+// newDelayLines Creates the delay lines for connections with provided delays. Returns nil if no connection is delayed.
+func newDelayLines(delays []int) []*delayLine {
+	var lines []*delayLine
+	for i, delay := range delays {
+		if delay > 0 {
+			if lines == nil {
+				lines = make([]*delayLine, len(delays))
+			}
+			lines[i] = newDelayLine(delay)
+		}
+	}
+	return lines
+}
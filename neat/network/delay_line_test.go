@@ -0,0 +1,151 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"testing"
+)
+
+// buildDelayedNetwork returns network with single input connected to the linear output by the link with given delay
+func buildDelayedNetwork(delay int) *Network {
+	input := NewNNode(1, InputNeuron)
+	output := NewNNode(2, OutputNeuron)
+	output.ActivationType = math.LinearActivation
+	link := output.ConnectFrom(input, 2.0)
+	link.Delay = delay
+	return NewNetwork([]*NNode{input}, []*NNode{output}, []*NNode{input, output}, 1)
+}
+
+var delayedEpisodeInputs = [][]float64{{1}, {2}, {3}, {4}, {5}}
+
+func TestDelayLine(t *testing.T) {
+	line := newDelayLine(3)
+	outputs := make([]float64, 0)
+	for i := 1; i <= 5; i++ {
+		outputs = append(outputs, line.shift(float64(i)))
+	}
+	assert.Equal(t, []float64{0, 0, 0, 1, 2}, outputs)
+	assert.Equal(t, []float64{3, 4, 5}, line.snapshot())
+
+	restored := newDelayLine(3)
+	restored.restore(line.snapshot())
+	assert.Equal(t, line.shift(6), restored.shift(6))
+
+	line.flush()
+	assert.Equal(t, []float64{0, 0, 0}, line.snapshot())
+
+	assert.Nil(t, newDelayLines([]int{0, 0}))
+	lines := newDelayLines([]int{0, 2})
+	require.Len(t, lines, 2)
+	assert.Nil(t, lines[0])
+	assert.Len(t, lines[1].signals, 2)
+}
+
+func TestDelayedLink_solvers(t *testing.T) {
+	expected := [][]float64{{0}, {0}, {2}, {4}, {6}}
+
+	net := buildDelayedNetwork(2)
+	actual := runEpisode(t, net, delayedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	solver, err := buildDelayedNetwork(2).FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	actual = runEpisode(t, solver, delayedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	model, err := buildDelayedNetwork(2).Model()
+	require.NoError(t, err, "failed to create network model")
+	actual = runEpisode(t, model.NewState(), delayedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	// flush resets the delay lines
+	for _, s := range []Solver{net, solver} {
+		_, err = s.Flush()
+		require.NoError(t, err, "failed to flush")
+		actual = runEpisode(t, s, delayedEpisodeInputs[:2], 1)
+		assert.Equal(t, expected[:2], actual)
+	}
+
+	// the recursive activation can not handle delays
+	_, err = solver.RecursiveSteps()
+	assert.Error(t, err)
+}
+
+func TestDelayedLink_biasNeuron(t *testing.T) {
+	net := buildDelayedNetwork(0)
+	bias := NewNNode(3, BiasNeuron)
+	link := net.Outputs[0].ConnectFrom(bias, 1.0)
+	link.Delay = 1
+	net = NewNetwork([]*NNode{net.inputs[0]}, net.Outputs, append(net.allNodes, bias), 1)
+
+	solver, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	inputs := delayedEpisodeInputs[:3]
+	// the delayed bias is received starting from the second step
+	assert.Equal(t, [][]float64{{2}, {5}, {7}}, runEpisode(t, solver, inputs, 1))
+}
+
+func TestDelayedLink_Snapshot_Restore(t *testing.T) {
+	net := buildDelayedNetwork(3)
+	runEpisode(t, net, delayedEpisodeInputs[:2], 1)
+	state := net.Snapshot()
+	require.Len(t, state.LinkDelayLines, 1)
+	expected := runEpisode(t, net, delayedEpisodeInputs, 1)
+
+	err := net.Restore(state)
+	require.NoError(t, err, "failed to restore")
+	actual := runEpisode(t, net, delayedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	state.LinkDelayLines[0] = state.LinkDelayLines[0][:1]
+	assert.Error(t, net.Restore(state))
+	state.LinkDelayLines = nil
+	assert.Error(t, net.Restore(state))
+
+	solver, err := buildDelayedNetwork(3).FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	fastSolver := solver.(*FastModularNetworkSolver)
+	runEpisode(t, fastSolver, delayedEpisodeInputs[:2], 1)
+	solverState := fastSolver.Snapshot()
+	require.Len(t, solverState.DelayLines, 1)
+	expected = runEpisode(t, fastSolver, delayedEpisodeInputs, 1)
+
+	err = fastSolver.Restore(solverState)
+	require.NoError(t, err, "failed to restore")
+	actual = runEpisode(t, fastSolver, delayedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	solverState.DelayLines[0] = nil
+	assert.Error(t, fastSolver.Restore(solverState))
+	solverState.DelayLines = nil
+	assert.Error(t, fastSolver.Restore(solverState))
+}
+
+func TestDelayedLink_SpikingSolver(t *testing.T) {
+	spikeTrain := func(delay int) []bool {
+		net := buildSpikingRelayNetwork(1.0)
+		net.Outputs[0].Incoming[0].Delay = delay
+		solver, err := net.SpikingSolver(LeakyIntegrateAndFire)
+		require.NoError(t, err, "failed to create solver")
+		require.NoError(t, solver.LoadSensors([]float64{1.0}))
+		spikes := make([]bool, 0)
+		for i := 0; i < 100; i++ {
+			require.NoError(t, solver.Step(1.0), "failed to step at: %d", i)
+			spikes = append(spikes, solver.Spikes()[solver.sensorNeuronCount])
+		}
+		return spikes
+	}
+	expected := spikeTrain(0)
+	require.Contains(t, expected, true)
+	// the output spikes are delayed by the given number of steps
+	assert.Equal(t, expected[:95], spikeTrain(5)[5:])
+}
+
+func TestDelayedLink_unsupportedSolvers(t *testing.T) {
+	_, err := buildDelayedNetwork(1).LayeredNetworkSolver()
+	assert.ErrorIs(t, err, ErrNetworkIsRecurrent)
+
+	_, err = buildDelayedNetwork(1).CTRNNSolver(EulerIntegration)
+	assert.Error(t, err)
+}
@@ -17,6 +17,10 @@ type FastNetworkLink struct {
 	Weight float64
 	// The signal relayed by this link
 	Signal float64
+	// The parameters of the modulated plasticity rule of this link, see ModulatedWeightChange
+	Params []float64
+	// The number of activation steps the signal is delayed by this link, zero means no delay
+	Delay int
 }
 
 // FastControlNode The module relay (control node) descriptor for fast network
@@ -30,22 +34,62 @@ type FastControlNode struct {
 }
 
 // FastModularNetworkSolver is the network solver implementation to be used for large neural networks simulation.
+// The solver consists of the immutable network model, which can be shared between solvers, and the activation state
+// owned by the solver. See NetworkModel for running the same network concurrently.
 type FastModularNetworkSolver struct {
 	// A network id
 	Id int
 	// Is a name of this network */
 	Name string
 
+	// The immutable network structure
+	*fastNetworkModel
+
 	// The current activation values per each neuron
 	neuronSignals []float64
 	// This array is a parallel of neuronSignals and used to test network relaxation
 	neuronSignalsBeingProcessed []float64
 
+	// For recursive activation, marks whether we have finished this node yet
+	activated []bool
+	// For recursive activation, makes whether a node is currently being calculated (recurrent connections processing)
+	inActivation []bool
+	// For recursive activation, the previous activation values of recurrent connections (recurrent connections processing)
+	lastActivation []float64
+
+	// The weights of the connections changed by the modulated plasticity, nil if network has no modulatory neurons
+	weights []float64
+	// The modulatory signals received by each neuron during the current activation step
+	modulation []float64
+	// The delay lines of the time-delayed connections, has nil entries for other connections. It is nil if network
+	// has no time-delayed connections.
+	delayLines []*delayLine
+
+	// The optional recorder of the neurons' activations
+	recorder *ActivationRecorder
+}
+
+// fastNetworkModel The immutable structure of the fast modular network, which is safe to share between goroutines
+type fastNetworkModel struct {
 	// The activation functions per neuron, must be in the same order as neuronSignals. Has nil entries for
 	// neurons that are inputs or outputs of a module.
 	activationFunctions []neatmath.NodeActivationType
-	// The bias values associated with neurons
+	// The bias values associated with neurons, i.e. the bias genes of neurons and the weights of links from the bias
+	// neurons scaled by the response of neurons
 	biasList []float64
+	// The responses (gains) of neurons, which scale the sum of incoming signals. It is nil if all neurons have the
+	// default response.
+	responses []float64
+	// The gate weights per neuron for the memory cell neurons, has nil entries for other neurons. It is nil if network
+	// has no memory cell neurons.
+	gateWeights [][]float64
+	// The auxiliary parameters of the activation functions per neuron (see NNode.Params), has nil entries for neurons
+	// without parameters. It is nil if no neuron has parameters.
+	activationParams [][]float64
+	// The flags marking the modulatory neurons. It is nil if network has no modulatory neurons.
+	modulatory []bool
+	// The factory of activation functions
+	activators *neatmath.NodeActivatorsFactory
 	// The control nodes relaying between network modules
 	modules []*FastControlNode
 	// The connections
@@ -62,27 +106,37 @@ type FastModularNetworkSolver struct {
 	// The total number of neurons in network
 	totalNeuronCount int
 
-	// For recursive activation, marks whether we have finished this node yet
-	activated []bool
-	// For recursive activation, makes whether a node is currently being calculated (recurrent connections processing)
-	inActivation []bool
-	// For recursive activation, the previous activation values of recurrent connections (recurrent connections processing)
-	lastActivation []float64
-
 	// The adjacent list to hold IDs of outgoing nodes for each network node
 	adjacentList [][]int
 	// The adjacent list to hold IDs of incoming nodes for each network node
 	reverseAdjacentList [][]int
 	// The adjacent matrix to hold connection weights between all connected nodes
 	adjacentMatrix [][]float64
+
+	// The IDs of the original network nodes per neuron, used to label recorded activations. If not set, the neuron
+	// indexes are used.
+	neuronIds []int
 }
 
-// NewFastModularNetworkSolver Creates new fast modular network solver
+// NewFastModularNetworkSolver Creates new fast modular network solver, which uses the default math.NodeActivators
+// to activate neurons
 func NewFastModularNetworkSolver(biasNeuronCount, inputNeuronCount, outputNeuronCount, totalNeuronCount int,
 	activationFunctions []neatmath.NodeActivationType, connections []*FastNetworkLink,
 	biasList []float64, modules []*FastControlNode) *FastModularNetworkSolver {
+	return NewFastModularNetworkSolverWithActivators(biasNeuronCount, inputNeuronCount, outputNeuronCount,
+		totalNeuronCount, activationFunctions, connections, biasList, modules, nil)
+}
 
-	fmm := FastModularNetworkSolver{
+// NewFastModularNetworkSolverWithActivators Creates new fast modular network solver, which uses provided factory of
+// activation functions to activate neurons. If activators is nil the default math.NodeActivators is used.
+func NewFastModularNetworkSolverWithActivators(biasNeuronCount, inputNeuronCount, outputNeuronCount, totalNeuronCount int,
+	activationFunctions []neatmath.NodeActivationType, connections []*FastNetworkLink,
+	biasList []float64, modules []*FastControlNode, activators *neatmath.NodeActivatorsFactory) *FastModularNetworkSolver {
+	if activators == nil {
+		activators = neatmath.NodeActivators
+	}
+
+	model := fastNetworkModel{
 		biasNeuronCount:     biasNeuronCount,
 		inputNeuronCount:    inputNeuronCount,
 		sensorNeuronCount:   biasNeuronCount + inputNeuronCount,
@@ -92,46 +146,81 @@ func NewFastModularNetworkSolver(biasNeuronCount, inputNeuronCount, outputNeuron
 		biasList:            biasList,
 		modules:             modules,
 		connections:         connections,
+		activators:          activators,
 	}
 
-	// Allocate the arrays that store the states at different points in the neural network.
-	// The neuron signals are initialised to 0 by default. Only bias nodes need setting to 1.
-	fmm.neuronSignals = make([]float64, totalNeuronCount)
-	fmm.neuronSignalsBeingProcessed = make([]float64, totalNeuronCount)
-	for i := 0; i < biasNeuronCount; i++ {
-		fmm.neuronSignals[i] = 1.0 // BIAS neuron signal
-	}
-
-	// Allocate activation arrays
-	fmm.activated = make([]bool, totalNeuronCount)
-	fmm.inActivation = make([]bool, totalNeuronCount)
-	fmm.lastActivation = make([]float64, totalNeuronCount)
-
 	// Build adjacent lists and matrix for fast access of incoming/outgoing nodes and connection weights
-	fmm.adjacentList = make([][]int, totalNeuronCount)
-	fmm.reverseAdjacentList = make([][]int, totalNeuronCount)
-	fmm.adjacentMatrix = make([][]float64, totalNeuronCount)
+	model.adjacentList = make([][]int, totalNeuronCount)
+	model.reverseAdjacentList = make([][]int, totalNeuronCount)
+	model.adjacentMatrix = make([][]float64, totalNeuronCount)
 
 	for i := 0; i < totalNeuronCount; i++ {
-		fmm.adjacentList[i] = make([]int, 0)
-		fmm.reverseAdjacentList[i] = make([]int, 0)
-		fmm.adjacentMatrix[i] = make([]float64, totalNeuronCount)
+		model.adjacentList[i] = make([]int, 0)
+		model.reverseAdjacentList[i] = make([]int, 0)
+		model.adjacentMatrix[i] = make([]float64, totalNeuronCount)
 	}
 
 	for i := 0; i < len(connections); i++ {
 		crs := connections[i].SourceIndex
 		crt := connections[i].TargetIndex
 		// Holds outgoing nodes
-		fmm.adjacentList[crs] = append(fmm.adjacentList[crs], crt)
+		model.adjacentList[crs] = append(model.adjacentList[crs], crt)
 		// Holds incoming nodes
-		fmm.reverseAdjacentList[crt] = append(fmm.reverseAdjacentList[crt], crs)
+		model.reverseAdjacentList[crt] = append(model.reverseAdjacentList[crt], crs)
 		// Holds link weight
-		fmm.adjacentMatrix[crs][crt] = connections[i].Weight
+		model.adjacentMatrix[crs][crt] = connections[i].Weight
+	}
+
+	return newFastModularNetworkSolver(&model)
+}
+
+// newFastModularNetworkSolver Creates new fast modular network solver with the fresh activation state for the given
+// network model
+func newFastModularNetworkSolver(model *fastNetworkModel) *FastModularNetworkSolver {
+	fmm := FastModularNetworkSolver{fastNetworkModel: model}
+
+	// Allocate the arrays that store the states at different points in the neural network.
+	// The neuron signals are initialised to 0 by default. Only bias nodes need setting to 1.
+	fmm.neuronSignals = make([]float64, model.totalNeuronCount)
+	fmm.neuronSignalsBeingProcessed = make([]float64, model.totalNeuronCount)
+	for i := 0; i < model.biasNeuronCount; i++ {
+		fmm.neuronSignals[i] = 1.0 // BIAS neuron signal
+	}
+
+	// Allocate activation arrays
+	fmm.activated = make([]bool, model.totalNeuronCount)
+	fmm.inActivation = make([]bool, model.totalNeuronCount)
+	fmm.lastActivation = make([]float64, model.totalNeuronCount)
+
+	delays := make([]int, len(model.connections))
+	for i, conn := range model.connections {
+		delays[i] = conn.Delay
 	}
+	fmm.delayLines = newDelayLines(delays)
 
+	if model.modulatory != nil {
+		fmm.initPlasticity()
+	}
 	return &fmm
 }
 
+// initPlasticity Allocates the state of the modulated plasticity with initial connections' weights
+func (s *FastModularNetworkSolver) initPlasticity() {
+	s.weights = make([]float64, len(s.connections))
+	s.modulation = make([]float64, s.totalNeuronCount)
+	s.flushPlasticity()
+}
+
+// flushPlasticity Restores the initial connections' weights changed by the modulated plasticity
+func (s *FastModularNetworkSolver) flushPlasticity() {
+	for i, conn := range s.connections {
+		s.weights[i] = conn.Weight
+	}
+	for i := range s.modulation {
+		s.modulation[i] = 0
+	}
+}
+
 // ForwardSteps Propagates activation wave through all network nodes provided number of steps in forward direction.
 // Returns true if activation wave passed from all inputs to the outputs.
 func (s *FastModularNetworkSolver) ForwardSteps(steps int) (res bool, err error) {
@@ -145,11 +234,18 @@ func (s *FastModularNetworkSolver) ForwardSteps(steps int) (res bool, err error)
 
 // RecursiveSteps Propagates activation wave through all network nodes provided number of steps by recursion from output nodes
 // Returns true if activation wave passed from all inputs to the outputs. This method is preferred method
-// of network activation when number of forward steps can not be easy calculated and no network modules are set.
+// of network activation when number of forward steps can not be easy calculated and no network modules,
+// modulatory neurons or time-delayed connections are set.
 func (s *FastModularNetworkSolver) RecursiveSteps() (res bool, err error) {
 	if len(s.modules) > 0 {
 		return false, errors.New("recursive activation can not be used for network with defined modules")
 	}
+	if s.modulatory != nil {
+		return false, errors.New("recursive activation can not be used for network with modulatory neurons")
+	}
+	if s.delayLines != nil {
+		return false, errors.New("recursive activation can not be used for network with time-delayed connections")
+	}
 
 	// Initialize boolean arrays and set the last activation signal for output/hidden neurons
 	for i := 0; i < s.totalNeuronCount; i++ {
@@ -172,6 +268,9 @@ func (s *FastModularNetworkSolver) RecursiveSteps() (res bool, err error) {
 			return false, fmt.Errorf("failed to recursively activate the output neuron at %d", index)
 		}
 	}
+	if s.recorder != nil {
+		s.recordActivations()
+	}
 
 	return res, nil
 }
@@ -222,9 +321,8 @@ func (s *FastModularNetworkSolver) recursiveActivateNode(currentNode int) (res b
 	s.inActivation[currentNode] = false
 
 	// Set this signal after running it through the activation function
-	if s.neuronSignals[currentNode], err = neatmath.NodeActivators.ActivateByType(
-		s.neuronSignalsBeingProcessed[currentNode], nil,
-		s.activationFunctions[currentNode]); err != nil {
+	if s.neuronSignals[currentNode], err = s.activateNeuron(
+		currentNode, s.netInput(currentNode, s.neuronSignalsBeingProcessed[currentNode])); err != nil {
 		// failed to activate
 		res = false
 	} else {
@@ -253,20 +351,24 @@ func (s *FastModularNetworkSolver) forwardStep(maxAllowedSignalDelta float64) (i
 	isRelaxed = true
 
 	// Calculate output signal per each connection and add the signals to the target neurons
-	for _, conn := range s.connections {
-		s.neuronSignalsBeingProcessed[conn.TargetIndex] += s.neuronSignals[conn.SourceIndex] * conn.Weight
+	if s.modulatory == nil {
+		for i, conn := range s.connections {
+			s.neuronSignalsBeingProcessed[conn.TargetIndex] += s.connectionSignal(i) * conn.Weight
+		}
+	} else {
+		// the modulatory signals gate the plasticity and are not summed with regular signals
+		for i, conn := range s.connections {
+			if s.modulatory[conn.SourceIndex] {
+				s.modulation[conn.TargetIndex] += s.neuronSignals[conn.SourceIndex] * s.weights[i]
+			} else {
+				s.neuronSignalsBeingProcessed[conn.TargetIndex] += s.connectionSignal(i) * s.weights[i]
+			}
+		}
 	}
 
 	// Pass the signals through the single-valued activation functions
 	for i := s.sensorNeuronCount; i < s.totalNeuronCount; i++ {
-		signal := s.neuronSignalsBeingProcessed[i]
-		if s.biasNeuronCount > 0 {
-			// append BIAS value to the signal if appropriate
-			signal += s.biasList[i]
-		}
-
-		if s.neuronSignalsBeingProcessed[i], err = neatmath.NodeActivators.ActivateByType(
-			signal, nil, s.activationFunctions[i]); err != nil {
+		if s.neuronSignalsBeingProcessed[i], err = s.activateNeuron(i, s.netInput(i, s.neuronSignalsBeingProcessed[i])); err != nil {
 			return false, err
 		}
 	}
@@ -277,7 +379,7 @@ func (s *FastModularNetworkSolver) forwardStep(maxAllowedSignalDelta float64) (i
 		for i, inIndex := range module.InputIndexes {
 			inputs[i] = s.neuronSignalsBeingProcessed[inIndex]
 		}
-		if outputs, err := neatmath.NodeActivators.ActivateModuleByType(inputs, nil, module.ActivationType); err == nil {
+		if outputs, err := s.activators.ActivateModuleByType(inputs, nil, module.ActivationType); err == nil {
 			// save outputs
 			for i, outIndex := range module.OutputIndexes {
 				s.neuronSignalsBeingProcessed[outIndex] = outputs[i]
@@ -303,16 +405,89 @@ func (s *FastModularNetworkSolver) forwardStep(maxAllowedSignalDelta float64) (i
 			s.neuronSignalsBeingProcessed[i] = 0
 		}
 	}
+	if s.modulatory != nil {
+		s.modulatedLearningStep()
+	}
+	if s.recorder != nil {
+		s.recordActivations()
+	}
 
 	return isRelaxed, err
 }
 
+// connectionSignal Returns the signal received by the target neuron through the connection at given index. The signal
+// of the time-delayed connection is the output of its source neuron the delay steps ago.
+func (s *FastModularNetworkSolver) connectionSignal(index int) float64 {
+	signal := s.neuronSignals[s.connections[index].SourceIndex]
+	if s.delayLines != nil && s.delayLines[index] != nil {
+		return s.delayLines[index].shift(signal)
+	}
+	return signal
+}
+
+// netInput Returns the net input of the neuron at given index from the sum of its incoming signals by applying
+// the response and the bias of the neuron
+func (m *fastNetworkModel) netInput(index int, sum float64) float64 {
+	if m.responses != nil {
+		sum *= m.responses[index]
+	}
+	if m.biasList != nil {
+		sum += m.biasList[index]
+	}
+	return sum
+}
+
+// activateNeuron Calculates the activation of the neuron at given index from its input signal. The memory cell neurons
+// are activated using their current signal as the previous output.
+func (s *FastModularNetworkSolver) activateNeuron(index int, signal float64) (float64, error) {
+	if s.gateWeights != nil && s.gateWeights[index] != nil {
+		return ActivateMemoryCell(signal, s.neuronSignals[index], s.gateWeights[index],
+			s.activationFunctions[index], s.activators)
+	}
+	var auxParams []float64
+	if s.activationParams != nil {
+		auxParams = s.activationParams[index]
+	}
+	return s.activators.ActivateByType(signal, auxParams, s.activationFunctions[index])
+}
+
+// SetActivationRecorder Sets the recorder to capture activations of all neurons at every activation step. Use nil
+// to stop recording.
+func (s *FastModularNetworkSolver) SetActivationRecorder(recorder *ActivationRecorder) {
+	s.recorder = recorder
+}
+
+// ActivationRecorder Returns the recorder of the neurons' activations or nil if not set
+func (s *FastModularNetworkSolver) ActivationRecorder() *ActivationRecorder {
+	return s.recorder
+}
+
+// recordActivations Records current activations of all neurons labeled by IDs of the original network nodes if known
+func (s *FastModularNetworkSolver) recordActivations() {
+	ids := s.neuronIds
+	if ids == nil {
+		ids = make([]int, s.totalNeuronCount)
+		for i := range ids {
+			ids[i] = i
+		}
+	}
+	s.recorder.record(ids, s.neuronSignals)
+}
+
 // Flush Flushes network state by removing all current activations. Returns true if network flushed successfully or
 // false in case of error.
 func (s *FastModularNetworkSolver) Flush() (bool, error) {
 	for i := s.biasNeuronCount; i < s.totalNeuronCount; i++ {
 		s.neuronSignals[i] = 0.0
 	}
+	for _, line := range s.delayLines {
+		if line != nil {
+			line.flush()
+		}
+	}
+	if s.weights != nil {
+		s.flushPlasticity()
+	}
 	return true, nil
 }
 
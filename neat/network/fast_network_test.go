@@ -3,6 +3,7 @@ package network
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
 	"testing"
 )
 
@@ -104,6 +105,106 @@ func TestFastModularNetworkSolver_ForwardSteps(t *testing.T) {
 	}
 }
 
+// buildBiasGenesNetwork returns network without bias neurons which neurons have bias and response genes
+func buildBiasGenesNetwork() *Network {
+	net := buildDisconnectedNetwork()
+	nodes := net.allNodes
+	nodes[3].ConnectFrom(nodes[0], 1.5)
+	nodes[3].Bias = -0.5
+	nodes[3].SetResponse(2.0)
+	nodes[4].ConnectFrom(nodes[1], -2.0)
+	nodes[4].Bias = 0.7
+	nodes[5].ConnectFrom(nodes[3], 0.8)
+	nodes[5].ConnectFrom(nodes[4], 1.2)
+	nodes[5].ActivationType = math.LinearActivation
+	nodes[5].Bias = 0.1
+	nodes[5].SetResponse(0.5)
+	nodes[6].ConnectFrom(nodes[5], 3.0)
+	nodes[6].ConnectFrom(nodes[2], 0.4)
+	nodes[6].SetResponse(0.25)
+	nodes[7].ConnectFrom(nodes[3], -1.0)
+	nodes[7].Bias = -0.2
+	return net
+}
+
+func TestFastModularNetworkSolver_biasGenes(t *testing.T) {
+	net := buildBiasGenesNetwork()
+	data := []float64{0.5, 1.1}
+	expected := activateForOutputs(t, net, append(data, 1.0), 3)
+
+	// the bias and response genes must change outputs
+	plainNet := buildBiasGenesNetwork()
+	for _, node := range plainNet.allNodes {
+		node.Bias, node.Response = 0, nil
+	}
+	assert.NotEqual(t, activateForOutputs(t, plainNet, append(data, 1.0), 3), expected)
+
+	fmm, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast network solver")
+	err = fmm.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	res, err := fmm.ForwardSteps(3)
+	require.NoError(t, err, "failed to do forward steps")
+	require.True(t, res)
+	assert.InDeltaSlice(t, expected, fmm.ReadOutputs(), 1e-12)
+
+	_, err = fmm.Flush()
+	require.NoError(t, err, "failed to flush")
+	err = fmm.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	res, err = fmm.RecursiveSteps()
+	require.NoError(t, err, "failed to do recursive steps")
+	require.True(t, res)
+	assert.InDeltaSlice(t, expected, fmm.ReadOutputs(), 1e-12)
+}
+
+func TestFastModularNetworkSolver_activationParams(t *testing.T) {
+	net := buildBiasGenesNetwork()
+	nodes := net.allNodes
+	nodes[3].ActivationType, nodes[3].Params = math.PReLUActivation, []float64{0.8}
+	nodes[4].ActivationType, nodes[4].Params = math.ParametricELUActivation, []float64{0.3}
+	nodes[6].ActivationType, nodes[6].Params = math.ParametricSigmoidActivation, []float64{0.2, 0.7}
+	nodes[7].ActivationType, nodes[7].Params = math.ParametricGaussianActivation, []float64{0.6}
+	data := []float64{-0.5, 1.1}
+	expected := activateForOutputs(t, net, append(data, 1.0), 3)
+
+	// the activation parameters must change outputs
+	plainNet := buildBiasGenesNetwork()
+	for i, node := range plainNet.allNodes {
+		node.ActivationType = nodes[i].ActivationType
+	}
+	assert.NotEqual(t, activateForOutputs(t, plainNet, append(data, 1.0), 3), expected)
+
+	fmm, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast network solver")
+	err = fmm.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	res, err := fmm.ForwardSteps(3)
+	require.NoError(t, err, "failed to do forward steps")
+	require.True(t, res)
+	assert.InDeltaSlice(t, expected, fmm.ReadOutputs(), 1e-12)
+}
+
+func TestFastModularNetworkSolver_biasGeneEqualsBiasNeuron(t *testing.T) {
+	data := []float64{0.5, 1.1}
+	// the link from the bias neuron
+	withBiasNeuron := buildPlainNetwork()
+	withBiasNeuron.Outputs[1].ConnectFrom(withBiasNeuron.allNodes[2], -0.8)
+	// the same bias expressed by the bias gene
+	withBiasGene := buildPlainNetwork()
+	withBiasGene.Outputs[1].Bias = -0.8
+
+	for _, net := range []*Network{withBiasNeuron, withBiasGene} {
+		fmm, err := net.FastNetworkSolver()
+		require.NoError(t, err, "failed to create fast network solver")
+		err = fmm.LoadSensors(data)
+		require.NoError(t, err, "failed to load sensors")
+		_, err = fmm.ForwardSteps(2)
+		require.NoError(t, err, "failed to do forward steps")
+		assert.InDeltaSlice(t, activateForOutputs(t, withBiasNeuron, append(data, 1.0), 2), fmm.ReadOutputs(), 1e-12)
+	}
+}
+
 func TestFastModularNetworkSolver_Relax(t *testing.T) {
 	net := buildModularNetwork()
 
@@ -183,6 +284,27 @@ func TestFastModularNetworkSolver_LinkCount(t *testing.T) {
 	assert.Equal(t, 9, fmm.LinkCount())
 }
 
+func TestNewFastModularNetworkSolverWithActivators(t *testing.T) {
+	factory := math.NodeActivators.Clone()
+	square, err := factory.RegisterExpression("SquareActivation", "x^2")
+	require.NoError(t, err)
+	activations := []math.NodeActivationType{math.NullActivation, square}
+	connections := []*FastNetworkLink{{SourceIndex: 0, TargetIndex: 1, Weight: 2.0}}
+
+	solver := NewFastModularNetworkSolverWithActivators(0, 1, 1, 2, activations, connections,
+		make([]float64, 2), nil, factory)
+	require.NoError(t, solver.LoadSensors([]float64{1.5}))
+	_, err = solver.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate fast network solver")
+	assert.InDelta(t, 9.0, solver.ReadOutputs()[0], 1e-12)
+
+	// the default factory doesn't know custom activation function
+	solver = NewFastModularNetworkSolver(0, 1, 1, 2, activations, connections, make([]float64, 2), nil)
+	require.NoError(t, solver.LoadSensors([]float64{1.5}))
+	_, err = solver.ForwardSteps(1)
+	assert.Error(t, err)
+}
+
 func countActiveSignals(impl *FastModularNetworkSolver) int {
 	active := 0
 	for i := impl.biasNeuronCount; i < impl.totalNeuronCount; i++ {
@@ -0,0 +1,116 @@
+package formats
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/formats/cytoscapejs"
+	"io"
+)
+
+// WriteAncestryDOT is to write provided ancestry graph of the organism using the GraphViz DOT encoding. The edges
+// are directed from parents to children, and the edges produced by crossover are dashed.
+// See DOT Guide: https://www.graphviz.org/pdf/dotguide.pdf
+func WriteAncestryDOT(w io.Writer, a *genetics.Ancestry) error {
+	name := fmt.Sprintf("ancestry_%d", a.Root.Id)
+	data, err := dot.Marshal(a, name, "", "")
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WriteAncestryCytoscapeJSON is to write provided ancestry graph of the organism using Cytoscape JSON encoding.
+// Generated JSON file can be used for visualization with Cytoscape application https://cytoscape.org or as input
+// to the Cytoscape JavaScript library https://js.cytoscape.org
+func WriteAncestryCytoscapeJSON(w io.Writer, a *genetics.Ancestry) error {
+	elements := cytoscapejs.Elements{
+		Nodes: make([]cytoscapejs.Node, 0),
+		Edges: make([]cytoscapejs.Edge, 0),
+	}
+	for _, r := range a.Records() {
+		elements.Nodes = append(elements.Nodes, genealogyToCyJsNode(r, r.Id == a.Root.Id))
+		for _, pId := range r.ParentIds {
+			if a.HasEdgeFromTo(pId, r.Id) {
+				elements.Edges = append(elements.Edges, genealogyToCyJsEdge(pId, r))
+			}
+		}
+	}
+	graphNodeEdge := cytoscapejs.GraphNodeEdge{
+		Elements: elements,
+		Layout:   map[string]interface{}{"name": "breadthfirst", "directed": true},
+		Style: []interface{}{
+			ElementStyle{Selector: "node", Style: map[string]interface{}{
+				"background-color": "data(background-color)",
+				"label":            "data(label)",
+			}},
+			ElementStyle{Selector: "edge", Style: map[string]interface{}{
+				"curve-style":        "bezier",
+				"line-style":         "data(line-style)",
+				"target-arrow-shape": "triangle",
+			}},
+		},
+	}
+	if data, err := json.Marshal(graphNodeEdge); err != nil {
+		return err
+	} else if _, err = w.Write(data); err != nil {
+		return err
+	}
+	return nil
+}
+
+const (
+	attrGenomeId        = "genome_id"
+	attrBirthGeneration = "birth_generation"
+	attrOperators       = "operators"
+	attrLabel           = "label"
+	attrLineStyle       = "line-style"
+)
+
+func genealogyToCyJsNode(g *genetics.Genealogy, root bool) cytoscapejs.Node {
+	color := colorHidden
+	if root {
+		color = colorOutput
+	} else if len(g.ParentIds) == 0 {
+		color = colorInput
+	}
+	operators := make([]string, len(g.Operators))
+	for i, op := range g.Operators {
+		operators[i] = string(op)
+	}
+	return cytoscapejs.Node{
+		Data: cytoscapejs.NodeData{
+			ID: fmt.Sprintf("%d", g.Id),
+			Attributes: map[string]interface{}{
+				attrGenomeId:        g.GenomeId,
+				attrBirthGeneration: g.BirthGeneration,
+				attrOperators:       operators,
+				attrLabel:           fmt.Sprintf("%d:%d", g.BirthGeneration, g.GenomeId),
+				attrBackgroundColor: color,
+			},
+		},
+		Selectable: true,
+	}
+}
+
+func genealogyToCyJsEdge(parentId int64, child *genetics.Genealogy) cytoscapejs.Edge {
+	lineStyle := "solid"
+	if child.IsMated() {
+		lineStyle = "dashed"
+	}
+	return cytoscapejs.Edge{
+		Data: cytoscapejs.EdgeData{
+			ID:     fmt.Sprintf("%d-%d", parentId, child.Id),
+			Source: fmt.Sprintf("%d", parentId),
+			Target: fmt.Sprintf("%d", child.Id),
+			Attributes: map[string]interface{}{
+				attrLineStyle: lineStyle,
+			},
+		},
+		Selectable: true,
+	}
+}
@@ -0,0 +1,102 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	"gonum.org/v1/gonum/graph/formats/cytoscapejs"
+	"testing"
+)
+
+func buildAncestry(t *testing.T) *genetics.Ancestry {
+	store := genetics.NewLineageStore()
+	mom := &genetics.Organism{Genotype: &genetics.Genome{Id: 1}, Generation: 1}
+	dad := &genetics.Organism{Genotype: &genetics.Genome{Id: 2}, Generation: 1}
+	store.Register(mom)
+	store.Register(dad)
+
+	child := &genetics.Organism{
+		Genotype: &genetics.Genome{Id: 3},
+		Genealogy: &genetics.Genealogy{
+			BirthGeneration: 2,
+			ParentIds:       []int64{mom.Genealogy.Id, dad.Genealogy.Id},
+			ParentGenomeIds: []int{1, 2},
+			Operators:       []genetics.ReproductionOperator{genetics.MateMultipointOperator},
+		},
+	}
+	store.Register(child)
+
+	grandchild := &genetics.Organism{
+		Genotype: &genetics.Genome{Id: 4},
+		Genealogy: &genetics.Genealogy{
+			BirthGeneration: 3,
+			ParentIds:       []int64{child.Genealogy.Id},
+			ParentGenomeIds: []int{3},
+			Operators:       []genetics.ReproductionOperator{genetics.MutateAddLinkOperator},
+		},
+	}
+	store.Register(grandchild)
+
+	a, err := store.AncestryOf(grandchild, 0)
+	require.NoError(t, err, "failed to collect ancestry")
+	return a
+}
+
+func TestWriteAncestryDOT(t *testing.T) {
+	a := buildAncestry(t)
+
+	b := bytes.NewBufferString("")
+	err := WriteAncestryDOT(b, a)
+	require.NoError(t, err, "failed to DOT encode")
+	out := b.String()
+	assert.Contains(t, out, "strict digraph ancestry_4 {")
+	assert.Contains(t, out, "1 -> 3 [style=dashed];")
+	assert.Contains(t, out, "3 -> 4 [style=solid];")
+	assert.Contains(t, out, "operators=\"mate_multipoint\"")
+}
+
+func TestWriteAncestryDOT_Write_Error(t *testing.T) {
+	a := buildAncestry(t)
+
+	errWriter := ErrorWriter(1)
+	err := WriteAncestryDOT(&errWriter, a)
+	assert.EqualError(t, err, alwaysErrorText)
+}
+
+func TestWriteAncestryCytoscapeJSON(t *testing.T) {
+	a := buildAncestry(t)
+
+	b := bytes.NewBufferString("")
+	err := WriteAncestryCytoscapeJSON(b, a)
+	require.NoError(t, err, "failed to encode")
+
+	var decoded cytoscapejs.GraphNodeEdge
+	err = json.Unmarshal(b.Bytes(), &decoded)
+	require.NoError(t, err, "failed to decode")
+	require.Len(t, decoded.Elements.Nodes, 4)
+	require.Len(t, decoded.Elements.Edges, 3)
+
+	root := decoded.Elements.Nodes[3]
+	assert.Equal(t, "4", root.Data.ID)
+	assert.Equal(t, "3:4", root.Data.Attributes[attrLabel])
+	assert.Equal(t, colorOutput, root.Data.Attributes[attrBackgroundColor])
+
+	edge := decoded.Elements.Edges[0]
+	assert.Equal(t, "1", edge.Data.Source)
+	assert.Equal(t, "3", edge.Data.Target)
+	assert.Equal(t, "dashed", edge.Data.Attributes[attrLineStyle])
+	edge = decoded.Elements.Edges[2]
+	assert.Equal(t, "3", edge.Data.Source)
+	assert.Equal(t, "4", edge.Data.Target)
+	assert.Equal(t, "solid", edge.Data.Attributes[attrLineStyle])
+}
+
+func TestWriteAncestryCytoscapeJSON_Write_Error(t *testing.T) {
+	a := buildAncestry(t)
+
+	errWriter := ErrorWriter(1)
+	err := WriteAncestryCytoscapeJSON(&errWriter, a)
+	assert.EqualError(t, err, alwaysErrorText)
+}
@@ -0,0 +1,460 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"go/token"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CNumericFormat defines the numeric representation of the network signals in the exported C code
+type CNumericFormat byte
+
+const (
+	// CFloat The signals are represented by single precision floating point numbers
+	CFloat CNumericFormat = iota
+	// CFixedPoint The signals are represented by signed 32-bit fixed point numbers with configured number of
+	// fraction bits, which is suitable for microcontrollers without FPU
+	CFixedPoint
+)
+
+// DefaultCFixedPointFractionBits The default number of fraction bits of the fixed point numbers
+const DefaultCFixedPointFractionBits = 16
+
+// CExportOptions The options of the C code export
+type CExportOptions struct {
+	// The prefix of all exported symbols, must be valid C identifier. The generated header expected to be saved
+	// into the file with name <Prefix>.h, because it's included by the generated source.
+	Prefix string
+	// The numeric representation of the network signals
+	Format CNumericFormat
+	// The number of fraction bits of the fixed point numbers [1, 30], if zero the DefaultCFixedPointFractionBits used
+	FractionBits int
+}
+
+// cActivationSources The C99 source code of the bodies of neuron activation functions with float argument x.
+// Must be in sync with activation functions defined in the neat/math package.
+var cActivationSources = map[neatmath.NodeActivationType]string{
+	neatmath.SigmoidPlainActivation:     "return 1.0f / (1.0f + expf(-x));",
+	neatmath.SigmoidReducedActivation:   "return 1.0f / (1.0f + expf(-0.5f * x));",
+	neatmath.SigmoidBipolarActivation:   "return (2.0f / (1.0f + expf(-4.924273f * x))) - 1.0f;",
+	neatmath.SigmoidSteepenedActivation: "return 1.0f / (1.0f + expf(-4.924273f * x));",
+	neatmath.SigmoidApproximationActivation: `if (x < -4.0f) return 0.0f;
+    if (x < 0.0f) return (x + 4.0f) * (x + 4.0f) * 0.03125f;
+    if (x < 4.0f) return 1.0f - (x - 4.0f) * (x - 4.0f) * 0.03125f;
+    return 1.0f;`,
+	neatmath.SigmoidSteepenedApproximationActivation: `if (x < -1.0f) return 0.0f;
+    if (x < 0.0f) return (x + 1.0f) * (x + 1.0f) * 0.5f;
+    if (x < 1.0f) return 1.0f - (x - 1.0f) * (x - 1.0f) * 0.5f;
+    return 1.0f;`,
+	neatmath.SigmoidInverseAbsoluteActivation:       "return 0.5f + (x / (1.0f + fabsf(x))) * 0.5f;",
+	neatmath.SigmoidLeftShiftedActivation:           "return 1.0f / (1.0f + expf(-x - 2.4621365f));",
+	neatmath.SigmoidLeftShiftedSteepenedActivation:  "return 1.0f / (1.0f + expf(-(4.924273f * x + 2.4621365f)));",
+	neatmath.SigmoidRightShiftedSteepenedActivation: "return 1.0f / (1.0f + expf(-(4.924273f * x - 2.4621365f)));",
+	neatmath.TanhActivation:                         "return tanhf(0.9f * x);",
+	neatmath.GaussianBipolarActivation:              "return 2.0f * expf(-powf(x * 2.5f, 2.0f)) - 1.0f;",
+	neatmath.LinearActivation:                       "return x;",
+	neatmath.LinearAbsActivation:                    "return fabsf(x);",
+	neatmath.LinearClippedActivation: `if (x < -1.0f) return -1.0f;
+    if (x > 1.0f) return 1.0f;
+    return x;`,
+	neatmath.NullActivation: "return 0.0f;",
+	neatmath.SignActivation: `if (isnan(x) || x == 0.0f) return 0.0f;
+    return signbit(x) ? -1.0f : 1.0f;`,
+	neatmath.SineActivation:      "return sinf(2.0f * x);",
+	neatmath.StepActivation:      "return signbit(x) ? 0.0f : 1.0f;",
+	neatmath.ReLUActivation:      "return x < 0.0f ? 0.0f : x;",
+	neatmath.LeakyReLUActivation: "return x < 0.0f ? 0.01f * x : x;",
+	neatmath.ELUActivation:       "return x < 0.0f ? expm1f(x) : x;",
+	neatmath.SoftplusActivation:  "return fmaxf(0.0f, x) + log1pf(expf(-fabsf(x)));",
+}
+
+// cParametricSources The C99 source code of the bodies of parametric activation functions with floating point argument
+// x and the shape parameters a and b resolved from the auxiliary parameters of the node, see parametricShapeParams.
+// Must be in sync with parametric activation functions defined in the neat/math package.
+var cParametricSources = map[neatmath.NodeActivationType]string{
+	neatmath.ParametricLeakyReLUActivation: "return x < 0.0f ? a * x : x;",
+	neatmath.PReLUActivation:               "return x < 0.0f ? a * x : x;",
+	neatmath.ParametricELUActivation:       "return x < 0.0f ? a * expm1f(x) : x;",
+	neatmath.ParametricGaussianActivation:  "return expf(-x * x / (2.0f * a * a));",
+	neatmath.ParametricSigmoidActivation:   "return 1.0f / (1.0f + expf(-(a * x + b)));",
+}
+
+// cFixedActivationSources The C99 source code of the bodies of neuron activation functions which can be calculated
+// directly with fixed point argument x. The {M} is the placeholder of macros prefix. Other activation functions are
+// calculated using floating point conversion.
+var cFixedActivationSources = map[neatmath.NodeActivationType]string{
+	neatmath.LinearActivation:    "return x;",
+	neatmath.LinearAbsActivation: "return x < 0 ? -x : x;",
+	neatmath.LinearClippedActivation: `if (x < -{M}_ONE) return -{M}_ONE;
+    if (x > {M}_ONE) return {M}_ONE;
+    return x;`,
+	neatmath.NullActivation: "return 0;",
+	neatmath.SignActivation: `if (x == 0) return 0;
+    return x < 0 ? -{M}_ONE : {M}_ONE;`,
+	neatmath.StepActivation: "return x < 0 ? 0 : {M}_ONE;",
+	neatmath.ReLUActivation: "return x < 0 ? 0 : x;",
+}
+
+// cModuleSources The C99 source code of the bodies of module activation functions with arguments: x - the array of
+// inputs, n - the number of inputs, and out - the array of outputs. The {M} and {P} are the placeholders of macros
+// and functions prefixes respectively.
+// Must be in sync with module activation functions defined in the neat/math package.
+var cModuleSources = map[neatmath.NodeActivationType]string{
+	neatmath.MultiplyModuleActivation: `{P}_value_t ret = {M}_ONE;
+    int i;
+    for (i = 0; i < n; i++) ret = {M}_MUL(ret, x[i]);
+    out[0] = ret;`,
+	neatmath.MaxModuleActivation: `{P}_value_t ret = {M}_MIN_VALUE;
+    int i;
+    for (i = 0; i < n; i++) if (x[i] > ret) ret = x[i];
+    out[0] = ret;`,
+	neatmath.MinModuleActivation: `{P}_value_t ret = {M}_MAX_VALUE;
+    int i;
+    for (i = 0; i < n; i++) if (x[i] < ret) ret = x[i];
+    out[0] = ret;`,
+	neatmath.SoftmaxModuleActivation: `float max = {M}_TO_FLOAT(x[0]), sum = 0.0f;
+    int i;
+    for (i = 1; i < n; i++) if ({M}_TO_FLOAT(x[i]) > max) max = {M}_TO_FLOAT(x[i]);
+    for (i = 0; i < n; i++) sum += expf({M}_TO_FLOAT(x[i]) - max);
+    for (i = 0; i < n; i++) out[i] = {M}_FROM_FLOAT(expf({M}_TO_FLOAT(x[i]) - max) / sum);`,
+	neatmath.LogSoftmaxModuleActivation: `float max = {M}_TO_FLOAT(x[0]), sum = 0.0f;
+    int i;
+    for (i = 1; i < n; i++) if ({M}_TO_FLOAT(x[i]) > max) max = {M}_TO_FLOAT(x[i]);
+    for (i = 0; i < n; i++) sum += expf({M}_TO_FLOAT(x[i]) - max);
+    sum = logf(sum);
+    for (i = 0; i < n; i++) out[i] = {M}_FROM_FLOAT({M}_TO_FLOAT(x[i]) - max - sum);`,
+	neatmath.NormalizeModuleActivation: `float sum = 0.0f;
+    int i;
+    for (i = 0; i < n; i++) sum += fabsf({M}_TO_FLOAT(x[i]));
+    for (i = 0; i < n; i++) out[i] = sum == 0.0f ? 0 : {M}_FROM_FLOAT({M}_TO_FLOAT(x[i]) / sum);`,
+	neatmath.WinnerTakeAllModuleActivation: `int i, winner = 0;
+    for (i = 1; i < n; i++) if (x[i] > x[winner]) winner = i;
+    for (i = 0; i < n; i++) out[i] = i == winner ? {M}_ONE : 0;`,
+}
+
+// WriteC is to write the dependency-free C99 header and source computing the outputs of the provided network. The
+// generated code declares the following API, where <prefix> is the prefix from options:
+//   - <prefix>_value_t - the type of the network signals (float or fixed point int32_t),
+//   - <prefix>_state_t - the structure holding the recurrent state of the network,
+//   - void <prefix>_flush(<prefix>_state_t *state) - resets the network state, must be called before first use,
+//   - void <prefix>_activate(<prefix>_state_t *state, const <prefix>_value_t *inputs, int steps,
+//     <prefix>_value_t *outputs) - loads inputs and propagates the activation wave given number of steps through
+//     the network storing its outputs.
+//
+// The fixed point variant also defines macros <PREFIX>_FROM_FLOAT and <PREFIX>_TO_FLOAT to convert values. The
+// activation functions which can not be calculated in fixed point directly (e.g. sigmoid) use floating point
+// conversion. The generated code has the same activation semantics as the network.FastModularNetworkSolver with
+// ForwardSteps and only depends on the standard math library.
+func WriteC(header, source io.Writer, n *network.Network, opts CExportOptions) error {
+	prefix := opts.Prefix
+	if !token.IsIdentifier(prefix) {
+		return fmt.Errorf("invalid prefix, it must be valid C identifier: %q", prefix)
+	}
+	fractionBits := opts.FractionBits
+	if fractionBits == 0 {
+		fractionBits = DefaultCFixedPointFractionBits
+	}
+	if opts.Format == CFixedPoint && (fractionBits < 1 || fractionBits > 30) {
+		return fmt.Errorf("invalid number of fraction bits: %d", fractionBits)
+	} else if opts.Format != CFloat && opts.Format != CFixedPoint {
+		return fmt.Errorf("unsupported numeric format: %d", opts.Format)
+	}
+	layout, err := newSolverLayout(n)
+	if err != nil {
+		return err
+	}
+	g := cGenerator{
+		prefix:       prefix,
+		macro:        strings.ToUpper(prefix),
+		fixed:        opts.Format == CFixedPoint,
+		fractionBits: fractionBits,
+		layout:       layout,
+	}
+
+	h := bytes.NewBufferString("")
+	g.writeHeader(h, n)
+	s := bytes.NewBufferString("")
+	if err = g.writeSource(s, n); err != nil {
+		return err
+	}
+	if _, err = header.Write(h.Bytes()); err != nil {
+		return err
+	}
+	_, err = source.Write(s.Bytes())
+	return err
+}
+
+// cGenerator is to generate C99 source code of the network
+type cGenerator struct {
+	// The prefix of functions and types
+	prefix string
+	// The prefix of macros
+	macro string
+	// Whether fixed point numbers used
+	fixed bool
+	// The number of fraction bits of fixed point numbers
+	fractionBits int
+	// The network layout
+	layout *solverLayout
+}
+
+func (g *cGenerator) writeHeader(b *bytes.Buffer, n *network.Network) {
+	l := g.layout
+	_, _ = fmt.Fprintf(b, "/* Code generated by goNEAT from network %q with id %d. DO NOT EDIT. */\n", n.Name, n.Id)
+	_, _ = fmt.Fprintf(b, "#ifndef %s_H\n#define %s_H\n\n", g.macro, g.macro)
+	if g.fixed {
+		_, _ = fmt.Fprintf(b, "#include <stdint.h>\n\n")
+	}
+	_, _ = fmt.Fprintf(b, "#ifdef __cplusplus\nextern \"C\" {\n#endif\n\n")
+	_, _ = fmt.Fprintf(b, "/* The number of the network inputs */\n#define %s_INPUTS %d\n", g.macro, l.inputCount)
+	_, _ = fmt.Fprintf(b, "/* The number of the network outputs */\n#define %s_OUTPUTS %d\n", g.macro, l.outputCount)
+	_, _ = fmt.Fprintf(b, "/* The number of the network neurons */\n#define %s_NEURONS %d\n\n", g.macro, len(l.neurons))
+
+	if g.fixed {
+		_, _ = fmt.Fprintf(b, "/* The signals are fixed point numbers with %d fraction bits */\n", g.fractionBits)
+		_, _ = fmt.Fprintf(b, "typedef int32_t %s_value_t;\n\n", g.prefix)
+		_, _ = fmt.Fprintf(b, "#define %s_FRACTION_BITS %d\n", g.macro, g.fractionBits)
+		_, _ = fmt.Fprintf(b, "#define %s_ONE ((%s_value_t)1 << %s_FRACTION_BITS)\n", g.macro, g.prefix, g.macro)
+		_, _ = fmt.Fprintf(b, "#define %s_FROM_FLOAT(v) ((%s_value_t)((v) * (float)%s_ONE + ((v) >= 0 ? 0.5f : -0.5f)))\n",
+			g.macro, g.prefix, g.macro)
+		_, _ = fmt.Fprintf(b, "#define %s_TO_FLOAT(v) ((float)(v) / (float)%s_ONE)\n\n", g.macro, g.macro)
+	} else {
+		_, _ = fmt.Fprintf(b, "/* The signals are single precision floating point numbers */\n")
+		_, _ = fmt.Fprintf(b, "typedef float %s_value_t;\n\n", g.prefix)
+	}
+
+	_, _ = fmt.Fprintf(b, "/* The recurrent state of the network */\ntypedef struct {\n")
+	_, _ = fmt.Fprintf(b, "    %s_value_t signals[%s_NEURONS];\n} %s_state_t;\n\n", g.prefix, g.macro, g.prefix)
+	_, _ = fmt.Fprintf(b, "/* Resets the network state, must be called before first activation */\n")
+	_, _ = fmt.Fprintf(b, "void %s_flush(%s_state_t *state);\n\n", g.prefix, g.prefix)
+	_, _ = fmt.Fprintf(b, "/* Loads inputs and propagates the activation wave given number of steps through the network */\n")
+	_, _ = fmt.Fprintf(b, "void %s_activate(%s_state_t *state, const %s_value_t *inputs, int steps, %s_value_t *outputs);\n\n",
+		g.prefix, g.prefix, g.prefix, g.prefix)
+	_, _ = fmt.Fprintf(b, "#ifdef __cplusplus\n}\n#endif\n\n#endif /* %s_H */\n", g.macro)
+}
+
+func (g *cGenerator) writeSource(b *bytes.Buffer, n *network.Network) error {
+	l := g.layout
+	sensors := l.sensorCount()
+	_, _ = fmt.Fprintf(b, "/* Code generated by goNEAT from network %q with id %d. DO NOT EDIT. */\n", n.Name, n.Id)
+	_, _ = fmt.Fprintf(b, "#include \"%s.h\"\n#include <math.h>\n\n", g.prefix)
+	if g.fixed {
+		_, _ = fmt.Fprintf(b, "#define %s_MUL(a, b) ((%s_value_t)(((int64_t)(a) * (b)) >> %s_FRACTION_BITS))\n",
+			g.macro, g.prefix, g.macro)
+		_, _ = fmt.Fprintf(b, "#define %s_MIN_VALUE INT32_MIN\n#define %s_MAX_VALUE INT32_MAX\n\n", g.macro, g.macro)
+		_, _ = fmt.Fprintf(b, "/* saturates the value to the range of the fixed point number */\n")
+		_, _ = fmt.Fprintf(b, "static %s_value_t %s_saturate(int64_t v) {\n", g.prefix, g.prefix)
+		_, _ = fmt.Fprintf(b, "    if (v > INT32_MAX) return INT32_MAX;\n    if (v < INT32_MIN) return INT32_MIN;\n")
+		_, _ = fmt.Fprintf(b, "    return (%s_value_t)v;\n}\n\n", g.prefix)
+	} else {
+		_, _ = fmt.Fprintf(b, "#define %s_ONE 1.0f\n#define %s_MUL(a, b) ((a) * (b))\n", g.macro, g.macro)
+		_, _ = fmt.Fprintf(b, "#define %s_FROM_FLOAT(v) (v)\n#define %s_TO_FLOAT(v) (v)\n", g.macro, g.macro)
+		_, _ = fmt.Fprintf(b, "#define %s_MIN_VALUE (-9.223372036854775808e18f)\n#define %s_MAX_VALUE 3.40282347e+38f\n\n",
+			g.macro, g.macro)
+	}
+
+	// activation functions
+	for _, aType := range l.activationTypes() {
+		if err := g.writeActivationFunction(b, aType); err != nil {
+			return err
+		}
+	}
+
+	// activation step
+	_, _ = fmt.Fprintf(b, "/* propagates the activation wave one step through the network */\n")
+	_, _ = fmt.Fprintf(b, "static void %s_step(%s_state_t *state) {\n", g.prefix, g.prefix)
+	_, _ = fmt.Fprintf(b, "    const %s_value_t *s = state->signals;\n", g.prefix)
+	_, _ = fmt.Fprintf(b, "    %s_value_t p[%s_NEURONS] = {0};\n    int i;\n", g.prefix, g.macro)
+	for i, neuron := range l.neurons[sensors:] {
+		name, _ := g.functionName(neuron.node.ActivationType)
+		sum, err := g.neuronSum(neuron)
+		if err != nil {
+			return err
+		}
+		args := []string{sum}
+		for _, param := range parametricShapeParams(neuron.node.ActivationType, neuron.node.Params) {
+			args = append(args, cFloat(param))
+		}
+		_, _ = fmt.Fprintf(b, "    p[%d] = %s(%s); /* neuron %d */\n", sensors+i, name, strings.Join(args, ", "), neuron.node.Id)
+	}
+	for _, module := range l.modules {
+		name, _ := g.functionName(module.node.ActivationType)
+		inputs := make([]string, len(module.inputs))
+		for i, index := range module.inputs {
+			inputs[i] = fmt.Sprintf("p[%d]", index)
+		}
+		_, _ = fmt.Fprintf(b, "    { /* module %d */\n", module.node.Id)
+		_, _ = fmt.Fprintf(b, "        const %s_value_t in[%d] = {%s};\n", g.prefix, len(inputs), strings.Join(inputs, ", "))
+		_, _ = fmt.Fprintf(b, "        %s_value_t out[%d];\n", g.prefix, len(module.outputs))
+		_, _ = fmt.Fprintf(b, "        %s(in, %d, out);\n", name, len(inputs))
+		for i, index := range module.outputs {
+			_, _ = fmt.Fprintf(b, "        p[%d] = out[%d];\n", index, i)
+		}
+		_, _ = fmt.Fprintf(b, "    }\n")
+	}
+	_, _ = fmt.Fprintf(b, "    for (i = %d; i < %s_NEURONS; i++) state->signals[i] = p[i];\n}\n\n", sensors, g.macro)
+
+	// public API
+	_, _ = fmt.Fprintf(b, "void %s_flush(%s_state_t *state) {\n    int i;\n", g.prefix, g.prefix)
+	_, _ = fmt.Fprintf(b, "    for (i = 0; i < %s_NEURONS; i++) state->signals[i] = 0;\n", g.macro)
+	for i := 0; i < l.biasCount; i++ {
+		_, _ = fmt.Fprintf(b, "    state->signals[%d] = %s_ONE; /* BIAS neuron signal */\n", i, g.macro)
+	}
+	_, _ = fmt.Fprintf(b, "}\n\n")
+	_, _ = fmt.Fprintf(b, "void %s_activate(%s_state_t *state, const %s_value_t *inputs, int steps, %s_value_t *outputs) {\n",
+		g.prefix, g.prefix, g.prefix, g.prefix)
+	_, _ = fmt.Fprintf(b, "    int i;\n")
+	_, _ = fmt.Fprintf(b, "    for (i = 0; i < %s_INPUTS; i++) state->signals[%d + i] = inputs[i];\n", g.macro, l.biasCount)
+	_, _ = fmt.Fprintf(b, "    for (i = 0; i < steps; i++) %s_step(state);\n", g.prefix)
+	_, _ = fmt.Fprintf(b, "    for (i = 0; i < %s_OUTPUTS; i++) outputs[i] = state->signals[%d + i];\n}\n", g.macro, sensors)
+	return nil
+}
+
+// writeActivationFunction writes the C function implementing provided activation type
+func (g *cGenerator) writeActivationFunction(b *bytes.Buffer, aType neatmath.NodeActivationType) error {
+	name, err := g.functionName(aType)
+	if err != nil {
+		return err
+	}
+	if body, ok := cModuleSources[aType]; ok {
+		_, _ = fmt.Fprintf(b, "static void %s(const %s_value_t *x, int n, %s_value_t *out) {\n    %s\n}\n\n",
+			name, g.prefix, g.prefix, g.expand(body))
+		return nil
+	}
+	if body, ok := cParametricSources[aType]; ok {
+		// the shape parameters are always passed as floating point numbers
+		params := make([]string, 0)
+		for _, arg := range parametricArgNames[:len(parametricShapeParams(aType, nil))] {
+			params = append(params, "float "+arg)
+		}
+		args := strings.Join(parametricArgNames[:len(params)], ", ")
+		if !g.fixed {
+			_, _ = fmt.Fprintf(b, "static float %s(float x, %s) {\n    %s\n}\n\n", name, strings.Join(params, ", "), body)
+		} else {
+			_, _ = fmt.Fprintf(b, "static float %s_f(float x, %s) {\n    %s\n}\n\n", name, strings.Join(params, ", "), body)
+			_, _ = fmt.Fprintf(b, "static %s_value_t %s(%s_value_t x, %s) {\n    return %s_FROM_FLOAT(%s_f(%s_TO_FLOAT(x), %s));\n}\n\n",
+				g.prefix, name, g.prefix, strings.Join(params, ", "), g.macro, name, g.macro, args)
+		}
+		return nil
+	}
+	body, ok := cActivationSources[aType]
+	if !ok {
+		return fmt.Errorf("unsupported activation type for C source generation: %d", aType)
+	}
+	if !g.fixed {
+		_, _ = fmt.Fprintf(b, "static float %s(float x) {\n    %s\n}\n\n", name, body)
+	} else if fixedBody, ok := cFixedActivationSources[aType]; ok {
+		_, _ = fmt.Fprintf(b, "static %s_value_t %s(%s_value_t x) {\n    %s\n}\n\n",
+			g.prefix, name, g.prefix, g.expand(fixedBody))
+	} else {
+		// calculate using floating point conversion
+		_, _ = fmt.Fprintf(b, "static float %s_f(float x) {\n    %s\n}\n\n", name, body)
+		_, _ = fmt.Fprintf(b, "static %s_value_t %s(%s_value_t x) {\n    return %s_FROM_FLOAT(%s_f(%s_TO_FLOAT(x)));\n}\n\n",
+			g.prefix, name, g.prefix, g.macro, name, g.macro)
+	}
+	return nil
+}
+
+// expand replaces placeholders of macros prefix {M} and functions prefix {P} in the source
+func (g *cGenerator) expand(source string) string {
+	return strings.NewReplacer("{M}", g.macro, "{P}", g.prefix).Replace(source)
+}
+
+// functionName returns the name of the C function implementing provided activation type
+func (g *cGenerator) functionName(aType neatmath.NodeActivationType) (string, error) {
+	name, err := g.layout.activators.ActivationNameFromType(aType)
+	if err != nil {
+		return "", err
+	}
+	return g.prefix + "_" + strings.TrimSuffix(toSnakeCase(name), "_activation"), nil
+}
+
+// neuronSum returns the C expression of the weighted sum of incoming signals of the neuron
+func (g *cGenerator) neuronSum(neuron *solverNeuron) (string, error) {
+	hasBias := neuron.bias != 0
+	if !g.fixed {
+		terms := make([]string, 0, len(neuron.incoming)+1)
+		for _, conn := range neuron.incoming {
+			terms = append(terms, fmt.Sprintf("s[%d] * %s", conn.source, cFloat(conn.weight)))
+		}
+		if hasBias {
+			terms = append(terms, cFloat(neuron.bias))
+		}
+		if len(terms) == 0 {
+			return "0.0f", nil
+		}
+		return strings.Join(terms, " + "), nil
+	}
+
+	terms := make([]string, 0, len(neuron.incoming))
+	for _, conn := range neuron.incoming {
+		w, err := g.fixedValue(conn.weight)
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, fmt.Sprintf("(int64_t)s[%d] * %d", conn.source, w))
+	}
+	sum := "0"
+	if len(terms) > 0 {
+		sum = fmt.Sprintf("((%s) >> %s_FRACTION_BITS)", strings.Join(terms, " + "), g.macro)
+	}
+	if hasBias {
+		bias, err := g.fixedValue(neuron.bias)
+		if err != nil {
+			return "", err
+		}
+		sum = fmt.Sprintf("%s + %d", sum, bias)
+	}
+	return fmt.Sprintf("%s_saturate(%s)", g.prefix, sum), nil
+}
+
+// fixedValue returns the fixed point representation of the value
+func (g *cGenerator) fixedValue(v float64) (int64, error) {
+	fixed := math.Round(v * float64(int64(1)<<g.fractionBits))
+	if math.IsNaN(fixed) || fixed > math.MaxInt32 || fixed < math.MinInt32 {
+		return 0, fmt.Errorf("value %f can not be represented by fixed point number with %d fraction bits",
+			v, g.fractionBits)
+	}
+	return int64(fixed), nil
+}
+
+// cFloat returns the C literal of provided float value
+func cFloat(v float64) string {
+	if math.IsNaN(v) {
+		return "NAN"
+	} else if math.IsInf(v, 1) {
+		return "INFINITY"
+	} else if math.IsInf(v, -1) {
+		return "(-INFINITY)"
+	}
+	str := strconv.FormatFloat(v, 'g', -1, 32)
+	if !strings.ContainsAny(str, ".e") {
+		str += ".0"
+	}
+	str += "f"
+	if v < 0 {
+		return "(" + str + ")"
+	}
+	return str
+}
+
+// toSnakeCase converts the CamelCase name to the snake_case
+func toSnakeCase(name string) string {
+	b := strings.Builder{}
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
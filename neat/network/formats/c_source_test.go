@@ -0,0 +1,223 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const cSourceHarnessMain = `#include <stdio.h>
+#include "net.h"
+
+int main(void) {
+    net_state_t state;
+    net_value_t inputs[NET_INPUTS];
+    net_value_t outputs[NET_OUTPUTS];
+    int i, j;
+    net_flush(&state);
+    for (i = 0; i < SAMPLES; i++) {
+        for (j = 0; j < NET_INPUTS; j++) inputs[j] = TO_VALUE(samples[i][j]);
+        net_activate(&state, inputs, STEPS, outputs);
+        for (j = 0; j < NET_OUTPUTS; j++) printf("%.9g ", (double)TO_FLOAT(outputs[j]));
+        printf("\n");
+    }
+    return 0;
+}
+`
+
+// runGeneratedCSource generates C source of the network, compiles it along with the harness and runs it with
+// provided samples. Each sample is activated given number of steps without flushing the network state between
+// samples. Returns the outputs per sample.
+func runGeneratedCSource(t *testing.T, net *network.Network, opts CExportOptions, samples [][]float64, steps int) [][]float64 {
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("C compiler is not available")
+	}
+	dir := t.TempDir()
+
+	header, source := bytes.NewBufferString(""), bytes.NewBufferString("")
+	opts.Prefix = "net"
+	err = WriteC(header, source, net, opts)
+	require.NoError(t, err, "failed to generate C source")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net.h"), header.Bytes(), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net.c"), source.Bytes(), 0644))
+
+	data := bytes.NewBufferString("")
+	if opts.Format == CFixedPoint {
+		_, _ = fmt.Fprintf(data, "#define TO_VALUE(v) NET_FROM_FLOAT(v)\n#define TO_FLOAT(v) NET_TO_FLOAT(v)\n")
+	} else {
+		_, _ = fmt.Fprintf(data, "#define TO_VALUE(v) (v)\n#define TO_FLOAT(v) (v)\n")
+	}
+	_, _ = fmt.Fprintf(data, "#define STEPS %d\n#define SAMPLES %d\n", steps, len(samples))
+	_, _ = fmt.Fprintf(data, "static const float samples[SAMPLES][%d] = {\n", len(samples[0]))
+	for _, sample := range samples {
+		values := make([]string, len(sample))
+		for i, v := range sample {
+			values[i] = cFloat(v)
+		}
+		_, _ = fmt.Fprintf(data, "    {%s},\n", strings.Join(values, ", "))
+	}
+	_, _ = fmt.Fprintf(data, "};\n")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.c"), append(data.Bytes(), cSourceHarnessMain...), 0644))
+
+	binary := filepath.Join(dir, "harness")
+	out, err := exec.Command(cc, "-std=c99", "-Wall", "-Werror", "-pedantic", "-o", binary,
+		filepath.Join(dir, "main.c"), filepath.Join(dir, "net.c"), "-lm").CombinedOutput()
+	require.NoError(t, err, "failed to compile generated C source: %s", out)
+
+	out, err = exec.Command(binary).Output()
+	require.NoError(t, err, "failed to run generated C source")
+
+	results := make([][]float64, 0)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		values := make([]float64, len(fields))
+		for i, field := range fields {
+			values[i], err = strconv.ParseFloat(field, 64)
+			require.NoError(t, err, "failed to parse output")
+		}
+		results = append(results, values)
+	}
+	return results
+}
+
+func TestWriteC_harness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compilation of generated C source in short mode")
+	}
+	samples := [][]float64{{0.5, 1.1}, {-0.3, 2.0}, {0.0, 0.0}, {1.0, -1.0}}
+	cases := map[string]*network.Network{
+		"plain":           buildNetwork(),
+		"modular":         buildModularNetwork(),
+		"recurrent":       buildRecurrentNetwork(),
+		"bias genes":      buildBiasGenesNetwork(),
+		"ReLU family":     buildReLUFamilyNetwork(),
+		"parametric":      buildParametricNetwork(),
+		"softmax":         buildOutputModuleNetwork(math.SoftmaxModuleActivation),
+		"log softmax":     buildOutputModuleNetwork(math.LogSoftmaxModuleActivation),
+		"normalize":       buildOutputModuleNetwork(math.NormalizeModuleActivation),
+		"winner-take-all": buildOutputModuleNetwork(math.WinnerTakeAllModuleActivation),
+	}
+	formats := map[string]struct {
+		opts  CExportOptions
+		delta float64
+	}{
+		"float": {opts: CExportOptions{Format: CFloat}, delta: 1e-5},
+		"fixed": {opts: CExportOptions{Format: CFixedPoint}, delta: 1e-2},
+	}
+	for name, net := range cases {
+		for format, params := range formats {
+			t.Run(name+"_"+format, func(t *testing.T) {
+				expected := runFastSolver(t, net, samples, 3)
+				actual := runGeneratedCSource(t, net, params.opts, samples, 3)
+				require.Len(t, actual, len(expected))
+				for i := range expected {
+					assert.InDeltaSlice(t, expected[i], actual[i], params.delta, "wrong outputs at: %d", i)
+				}
+			})
+		}
+	}
+}
+
+func TestWriteC(t *testing.T) {
+	net := buildModularNetwork()
+	header, source := bytes.NewBufferString(""), bytes.NewBufferString("")
+	err := WriteC(header, source, net, CExportOptions{Prefix: "champion", Format: CFixedPoint, FractionBits: 12})
+	require.NoError(t, err, "failed to generate C source")
+
+	h := header.String()
+	assert.Contains(t, h, "#ifndef CHAMPION_H")
+	assert.Contains(t, h, "#define CHAMPION_INPUTS 2")
+	assert.Contains(t, h, "#define CHAMPION_OUTPUTS 2")
+	assert.Contains(t, h, "#define CHAMPION_FRACTION_BITS 12")
+	assert.Contains(t, h, "typedef int32_t champion_value_t;")
+	assert.Contains(t, h, "void champion_flush(champion_state_t *state);")
+
+	s := source.String()
+	assert.Contains(t, s, "#include \"champion.h\"")
+	assert.Contains(t, s, "static void champion_multiply_module(const champion_value_t *x, int n, champion_value_t *out)")
+	assert.Contains(t, s, "static champion_value_t champion_linear(champion_value_t x)")
+	assert.Contains(t, s, "(int64_t)s[1] * 61440")
+}
+
+func TestWriteC_wrongOptions(t *testing.T) {
+	net := buildNetwork()
+	header, source := bytes.NewBufferString(""), bytes.NewBufferString("")
+	err := WriteC(header, source, net, CExportOptions{Prefix: "1net"})
+	assert.EqualError(t, err, "invalid prefix, it must be valid C identifier: \"1net\"")
+	err = WriteC(header, source, net, CExportOptions{Prefix: "net", Format: CFixedPoint, FractionBits: 31})
+	assert.EqualError(t, err, "invalid number of fraction bits: 31")
+	err = WriteC(header, source, net, CExportOptions{Prefix: "net", Format: 3})
+	assert.EqualError(t, err, "unsupported numeric format: 3")
+
+	// weight is out of fixed point range
+	net.BaseNodes()[3].Incoming[0].ConnectionWeight = 1e6
+	err = WriteC(header, source, net, CExportOptions{Prefix: "net", Format: CFixedPoint})
+	assert.EqualError(t, err, "value 1000000.000000 can not be represented by fixed point number with 16 fraction bits")
+	assert.Zero(t, header.Len())
+	assert.Zero(t, source.Len())
+}
+
+func TestWriteC_memoryNeuron(t *testing.T) {
+	net := buildNetwork()
+	net.BaseNodes()[4].NeuronType = network.MemoryNeuron
+	header, source := bytes.NewBufferString(""), bytes.NewBufferString("")
+	err := WriteC(header, source, net, CExportOptions{Prefix: "net"})
+	assert.EqualError(t, err, "memory cell neuron is not supported by the source code generators: 5")
+	assert.Zero(t, header.Len())
+	assert.Zero(t, source.Len())
+}
+
+func TestWriteC_modulatoryNeuron(t *testing.T) {
+	net := buildNetwork()
+	net.BaseNodes()[4].NeuronType = network.ModulatoryNeuron
+	header, source := bytes.NewBufferString(""), bytes.NewBufferString("")
+	err := WriteC(header, source, net, CExportOptions{Prefix: "net"})
+	assert.EqualError(t, err, "modulatory neuron is not supported by the source code generators: 5")
+	assert.Zero(t, header.Len())
+	assert.Zero(t, source.Len())
+}
+
+func TestWriteC_parametricActivation(t *testing.T) {
+	net := buildParametricNetwork()
+	header, source := bytes.NewBufferString(""), bytes.NewBufferString("")
+	err := WriteC(header, source, net, CExportOptions{Prefix: "net"})
+	require.NoError(t, err, "failed to generate C source")
+
+	s := source.String()
+	assert.Contains(t, s, "static float net_p_re_l_u(float x, float a)")
+	assert.Contains(t, s, "static float net_parametric_sigmoid(float x, float a, float b)")
+	// the default slope of PReLU is used when node has no parameters
+	assert.Regexp(t, `net_p_re_l_u\(.+, 0\.25f\); /\* neuron 5 \*/`, s)
+}
+
+func TestWriteC_unsupportedActivation(t *testing.T) {
+	net := buildNetwork()
+	net.BaseNodes()[3].ActivationType = math.FirstCustomActivation
+	header, source := bytes.NewBufferString(""), bytes.NewBufferString("")
+	err := WriteC(header, source, net, CExportOptions{})
+	assert.Error(t, err, "activation type is not registered")
+	assert.Zero(t, source.Len())
+}
+
+func TestWriteC_Write_Error(t *testing.T) {
+	errWriter := ErrorWriter(1)
+	err := WriteC(&errWriter, bytes.NewBufferString(""), buildNetwork(), CExportOptions{Prefix: "net"})
+	assert.EqualError(t, err, alwaysErrorText)
+	err = WriteC(bytes.NewBufferString(""), &errWriter, buildNetwork(), CExportOptions{Prefix: "net"})
+	assert.EqualError(t, err, alwaysErrorText)
+}
+
+func TestToSnakeCase(t *testing.T) {
+	assert.Equal(t, "sigmoid_plain_activation", toSnakeCase("SigmoidPlainActivation"))
+	assert.Equal(t, "linear", toSnakeCase("linear"))
+}
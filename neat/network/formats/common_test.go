@@ -86,3 +86,108 @@ func buildModularNetwork() *network.Network {
 
 	return network.NewModularNetwork(allNodes[0:3], allNodes[6:8], allNodes, controlNodes, 0)
 }
+
+func buildRecurrentNetwork() *network.Network {
+	net := buildNetwork()
+	allNodes := net.BaseNodes()
+	allNodes[3].ActivationType = math.TanhActivation
+	allNodes[5].ActivationType = math.SigmoidBipolarActivation
+	// recurrent link from OUTPUT 7 to HIDDEN 4
+	allNodes[3].ConnectFrom(allNodes[6], -0.5).IsRecurrent = true
+	// self-recurrent link of HIDDEN 6
+	allNodes[5].ConnectFrom(allNodes[5], 0.25).IsRecurrent = true
+	return net
+}
+
+func buildBiasGenesNetwork() *network.Network {
+	net := buildRecurrentNetwork()
+	allNodes := net.BaseNodes()
+	// HIDDEN 4
+	allNodes[3].Bias = -0.3
+	allNodes[3].SetResponse(0.5)
+	// HIDDEN 5
+	allNodes[4].SetResponse(1.5)
+	// OUTPUT 8
+	allNodes[7].Bias = 0.2
+	return net
+}
+
+// buildReLUFamilyNetwork returns network with neurons activated by the ReLU family and softplus activation functions,
+// the negative link to HIDDEN 5 produces negative net inputs for some samples. The weights are scaled down to keep the
+// unbounded outputs within the single precision of the generated C source.
+func buildReLUFamilyNetwork() *network.Network {
+	net := buildNetwork()
+	allNodes := net.BaseNodes()
+	allNodes[4].ConnectFrom(allNodes[0], -8.0)
+	for _, node := range allNodes {
+		for _, link := range node.Incoming {
+			link.ConnectionWeight *= 0.1
+		}
+	}
+	allNodes[3].ActivationType = math.ReLUActivation
+	allNodes[4].ActivationType = math.LeakyReLUActivation
+	allNodes[5].ActivationType = math.ELUActivation
+	allNodes[6].ActivationType = math.SoftplusActivation
+	allNodes[7].ActivationType = math.ReLUActivation
+	return net
+}
+
+// buildParametricNetwork returns network with neurons having parametric activation functions, the shape parameters
+// of the neurons are resolved from their auxiliary parameters or set to defaults if parameters are missing
+func buildParametricNetwork() *network.Network {
+	net := buildReLUFamilyNetwork()
+	allNodes := net.BaseNodes()
+	allNodes[3].ActivationType = math.ParametricLeakyReLUActivation
+	allNodes[3].Params = []float64{0.3}
+	allNodes[4].ActivationType = math.PReLUActivation
+	allNodes[5].ActivationType = math.ParametricELUActivation
+	allNodes[5].Params = []float64{0.8}
+	allNodes[6].ActivationType = math.ParametricGaussianActivation
+	allNodes[6].Params = []float64{0.5}
+	allNodes[7].ActivationType = math.ParametricSigmoidActivation
+	allNodes[7].Params = []float64{0.2, 0.7}
+	return net
+}
+
+// buildCustomActivatorNetwork returns network with output neurons activated by the custom activation function
+// registered only in the factory of the network
+func buildCustomActivatorNetwork() (*network.Network, *math.NodeActivatorsFactory) {
+	factory := math.NodeActivators.Clone()
+	square, err := factory.RegisterExpression("SquareTestActivation", "x^2")
+	if err != nil {
+		panic(err)
+	}
+	net := buildNetwork()
+	for _, node := range net.Outputs {
+		node.ActivationType = square
+	}
+	net.SetActivators(factory)
+	return net, factory
+}
+
+// buildOutputModuleNetwork returns network with the group of three linear outputs decoded by the module with given
+// activation type, which replaces the outputs values by its own outputs
+func buildOutputModuleNetwork(aType math.NodeActivationType) *network.Network {
+	allNodes := []*network.NNode{
+		network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(2, network.InputNeuron),
+		network.NewNNode(3, network.BiasNeuron),
+		network.NewNNode(4, network.OutputNeuron),
+		network.NewNNode(5, network.OutputNeuron),
+		network.NewNNode(6, network.OutputNeuron),
+	}
+	weights := [][]float64{{1.5, -0.5, 0.1}, {-1.0, 2.0, 0.3}, {0.5, 0.5, -0.2}}
+	controlNodes := []*network.NNode{
+		network.NewNNode(7, network.HiddenNeuron),
+	}
+	controlNodes[0].ActivationType = aType
+	for i, output := range allNodes[3:] {
+		output.ActivationType = math.LinearActivation
+		for j, input := range allNodes[:3] {
+			output.ConnectFrom(input, weights[i][j])
+		}
+		controlNodes[0].AddIncoming(output, 1.0)
+		controlNodes[0].AddOutgoing(output, 1.0)
+	}
+	return network.NewModularNetwork(allNodes[0:3], allNodes[3:6], allNodes, controlNodes, 0)
+}
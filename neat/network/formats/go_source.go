@@ -0,0 +1,325 @@
+package formats
+
+import (
+	"bytes"
+	"fmt"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"go/format"
+	"go/token"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// goActivationSources The Go source code of the bodies of neuron activation functions with input argument x.
+// Must be in sync with activation functions defined in the neat/math package.
+var goActivationSources = map[neatmath.NodeActivationType]string{
+	neatmath.SigmoidPlainActivation:     "return 1 / (1 + math.Exp(-x))",
+	neatmath.SigmoidReducedActivation:   "return 1 / (1 + math.Exp(-0.5*x))",
+	neatmath.SigmoidBipolarActivation:   "return (2.0 / (1.0 + math.Exp(-4.924273*x))) - 1.0",
+	neatmath.SigmoidSteepenedActivation: "return 1.0 / (1.0 + math.Exp(-4.924273*x))",
+	neatmath.SigmoidApproximationActivation: `if x < -4.0 {
+		return 0.0
+	} else if x < 0.0 {
+		return (x + 4.0) * (x + 4.0) * 0.03125
+	} else if x < 4.0 {
+		return 1.0 - (x-4.0)*(x-4.0)*0.03125
+	}
+	return 1.0`,
+	neatmath.SigmoidSteepenedApproximationActivation: `if x < -1.0 {
+		return 0.0
+	} else if x < 0.0 {
+		return (x + 1.0) * (x + 1.0) * 0.5
+	} else if x < 1.0 {
+		return 1.0 - (x-1.0)*(x-1.0)*0.5
+	}
+	return 1.0`,
+	neatmath.SigmoidInverseAbsoluteActivation:       "return 0.5 + (x/(1.0+math.Abs(x)))*0.5",
+	neatmath.SigmoidLeftShiftedActivation:           "return 1.0 / (1.0 + math.Exp(-x-2.4621365))",
+	neatmath.SigmoidLeftShiftedSteepenedActivation:  "return 1.0 / (1.0 + math.Exp(-(4.924273*x + 2.4621365)))",
+	neatmath.SigmoidRightShiftedSteepenedActivation: "return 1.0 / (1.0 + math.Exp(-(4.924273*x - 2.4621365)))",
+	neatmath.TanhActivation:                         "return math.Tanh(0.9 * x)",
+	neatmath.GaussianBipolarActivation:              "return 2.0*math.Exp(-math.Pow(x*2.5, 2.0)) - 1.0",
+	neatmath.LinearActivation:                       "return x",
+	neatmath.LinearAbsActivation:                    "return math.Abs(x)",
+	neatmath.LinearClippedActivation: `if x < -1.0 {
+		return -1.0
+	}
+	if x > 1.0 {
+		return 1.0
+	}
+	return x`,
+	neatmath.NullActivation: "return 0.0",
+	neatmath.SignActivation: `if math.IsNaN(x) || x == 0.0 {
+		return 0.0
+	} else if math.Signbit(x) {
+		return -1.0
+	}
+	return 1.0`,
+	neatmath.SineActivation: "return math.Sin(2.0 * x)",
+	neatmath.StepActivation: `if math.Signbit(x) {
+		return 0.0
+	}
+	return 1.0`,
+	neatmath.ReLUActivation: "return math.Max(0.0, x)",
+	neatmath.LeakyReLUActivation: `if x < 0.0 {
+		return 0.01 * x
+	}
+	return x`,
+	neatmath.ELUActivation: `if x < 0.0 {
+		return math.Expm1(x)
+	}
+	return x`,
+	neatmath.SoftplusActivation: "return math.Max(0.0, x) + math.Log1p(math.Exp(-math.Abs(x)))",
+}
+
+// goParametricSources The Go source code of the bodies of parametric activation functions with input argument
+// x float64 and the shape parameters a and b resolved from the auxiliary parameters of the node, see
+// parametricShapeParams. Must be in sync with parametric activation functions defined in the neat/math package.
+var goParametricSources = map[neatmath.NodeActivationType]string{
+	neatmath.ParametricLeakyReLUActivation: `if x < 0.0 {
+		return a * x
+	}
+	return x`,
+	neatmath.PReLUActivation: `if x < 0.0 {
+		return a * x
+	}
+	return x`,
+	neatmath.ParametricELUActivation: `if x < 0.0 {
+		return a * math.Expm1(x)
+	}
+	return x`,
+	neatmath.ParametricGaussianActivation: "return math.Exp(-x * x / (2.0 * a * a))",
+	neatmath.ParametricSigmoidActivation:  "return 1.0 / (1.0 + math.Exp(-(a*x + b)))",
+}
+
+// goModuleSources The Go source code of the bodies of module activation functions with input argument x []float64.
+// Must be in sync with module activation functions defined in the neat/math package.
+var goModuleSources = map[neatmath.NodeActivationType]string{
+	neatmath.MultiplyModuleActivation: `ret := 1.0
+	for _, v := range x {
+		ret *= v
+	}
+	return []float64{ret}`,
+	neatmath.MaxModuleActivation: `max := float64(math.MinInt64)
+	for _, v := range x {
+		max = math.Max(max, v)
+	}
+	return []float64{max}`,
+	neatmath.MinModuleActivation: `min := math.MaxFloat64
+	for _, v := range x {
+		min = math.Min(min, v)
+	}
+	return []float64{min}`,
+	neatmath.SoftmaxModuleActivation: `ret := make([]float64, len(x))
+	if len(x) == 0 {
+		return ret
+	}
+	max := x[0]
+	for _, v := range x[1:] {
+		max = math.Max(max, v)
+	}
+	sum := 0.0
+	for i, v := range x {
+		ret[i] = math.Exp(v - max)
+		sum += ret[i]
+	}
+	for i := range ret {
+		ret[i] /= sum
+	}
+	return ret`,
+	neatmath.LogSoftmaxModuleActivation: `ret := make([]float64, len(x))
+	if len(x) == 0 {
+		return ret
+	}
+	max := x[0]
+	for _, v := range x[1:] {
+		max = math.Max(max, v)
+	}
+	sum := 0.0
+	for _, v := range x {
+		sum += math.Exp(v - max)
+	}
+	logSum := math.Log(sum)
+	for i, v := range x {
+		ret[i] = v - max - logSum
+	}
+	return ret`,
+	neatmath.NormalizeModuleActivation: `ret := make([]float64, len(x))
+	sum := 0.0
+	for _, v := range x {
+		sum += math.Abs(v)
+	}
+	if sum == 0 {
+		return ret
+	}
+	for i, v := range x {
+		ret[i] = v / sum
+	}
+	return ret`,
+	neatmath.WinnerTakeAllModuleActivation: `ret := make([]float64, len(x))
+	if len(x) == 0 {
+		return ret
+	}
+	winner := 0
+	for i, v := range x {
+		if v > x[winner] {
+			winner = i
+		}
+	}
+	ret[winner] = 1.0
+	return ret`,
+}
+
+// WriteGoSource is to write the self-contained Go source code computing the outputs of the provided network. The
+// generated code declares the type with given name in the given package, which holds the recurrent state of the
+// network, and has the following API:
+//   - New<typeName>() *<typeName> creates the network with flushed state,
+//   - Flush() resets the network state,
+//   - Activate(inputs [<N>]float64, steps int) [<M>]float64 loads inputs and propagates the activation wave given
+//     number of steps through the network returning its outputs.
+//
+// The activation functions are inlined into the generated code, thus it doesn't depend on goNEAT. The shape
+// parameters of the parametric activation functions are resolved from the auxiliary parameters of the nodes and passed
+// as constant arguments of the calls. The generated code has the same activation semantics as the network.FastModularNetworkSolver with ForwardSteps.
+func WriteGoSource(w io.Writer, n *network.Network, packageName, typeName string) error {
+	if !token.IsIdentifier(packageName) {
+		return fmt.Errorf("invalid package name: %q", packageName)
+	}
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return fmt.Errorf("invalid type name, it must be exported identifier: %q", typeName)
+	}
+	layout, err := newSolverLayout(n)
+	if err != nil {
+		return err
+	}
+
+	b := bytes.NewBufferString("")
+	_, _ = fmt.Fprintf(b, "// Code generated by goNEAT from network %q with id %d. DO NOT EDIT.\n\n", n.Name, n.Id)
+	_, _ = fmt.Fprintf(b, "package %s\n\n", packageName)
+
+	neuronsCount, sensors := len(layout.neurons), layout.sensorCount()
+	_, _ = fmt.Fprintf(b, "// %sInputs is the number of the network inputs\nconst %sInputs = %d\n\n", typeName, typeName, layout.inputCount)
+	_, _ = fmt.Fprintf(b, "// %sOutputs is the number of the network outputs\nconst %sOutputs = %d\n\n", typeName, typeName, layout.outputCount)
+	_, _ = fmt.Fprintf(b, "// %s is the neural network with %d neurons and %d modules\n", typeName, neuronsCount, len(layout.modules))
+	_, _ = fmt.Fprintf(b, "type %s struct {\n\t// the current activation values per each neuron\n\tsignals [%d]float64\n}\n\n", typeName, neuronsCount)
+
+	_, _ = fmt.Fprintf(b, "// New%s creates the network with flushed state\nfunc New%s() *%s {\n\tn := &%s{}\n\tn.Flush()\n\treturn n\n}\n\n",
+		typeName, typeName, typeName, typeName)
+
+	_, _ = fmt.Fprintf(b, "// Flush resets the network state\nfunc (n *%s) Flush() {\n\tn.signals = [%d]float64{}\n", typeName, neuronsCount)
+	for i := 0; i < layout.biasCount; i++ {
+		_, _ = fmt.Fprintf(b, "\tn.signals[%d] = 1.0 // BIAS neuron signal\n", i)
+	}
+	_, _ = fmt.Fprintf(b, "}\n\n")
+
+	_, _ = fmt.Fprintf(b, "// Activate loads inputs and propagates the activation wave given number of steps through the network\n")
+	_, _ = fmt.Fprintf(b, "func (n *%s) Activate(inputs [%d]float64, steps int) (outputs [%d]float64) {\n",
+		typeName, layout.inputCount, layout.outputCount)
+	_, _ = fmt.Fprintf(b, "\tcopy(n.signals[%d:%d], inputs[:])\n", layout.biasCount, sensors)
+	_, _ = fmt.Fprintf(b, "\tfor i := 0; i < steps; i++ {\n\t\tn.step()\n\t}\n")
+	_, _ = fmt.Fprintf(b, "\tcopy(outputs[:], n.signals[%d:%d])\n\treturn outputs\n}\n\n", sensors, sensors+layout.outputCount)
+
+	_, _ = fmt.Fprintf(b, "// step propagates the activation wave one step through the network\nfunc (n *%s) step() {\n", typeName)
+	_, _ = fmt.Fprintf(b, "\ts := &n.signals\n\tvar p [%d]float64\n", neuronsCount)
+	for i, neuron := range layout.neurons[sensors:] {
+		name, err := goFunctionName(typeName, neuron.node.ActivationType, layout.activators)
+		if err != nil {
+			return err
+		}
+		args := []string{goNeuronSum(neuron, layout.biasCount > 0 || neuron.bias != 0)}
+		for _, param := range parametricShapeParams(neuron.node.ActivationType, neuron.node.Params) {
+			args = append(args, goFloat(param))
+		}
+		_, _ = fmt.Fprintf(b, "\tp[%d] = %s(%s) // neuron %d\n", sensors+i, name, strings.Join(args, ", "), neuron.node.Id)
+	}
+	for _, module := range layout.modules {
+		name, err := goFunctionName(typeName, module.node.ActivationType, layout.activators)
+		if err != nil {
+			return err
+		}
+		inputs := make([]string, len(module.inputs))
+		for i, index := range module.inputs {
+			inputs[i] = fmt.Sprintf("p[%d]", index)
+		}
+		_, _ = fmt.Fprintf(b, "\t// module %d\n\t{\n\t\tout := %s([]float64{%s})\n",
+			module.node.Id, name, strings.Join(inputs, ", "))
+		for i, index := range module.outputs {
+			_, _ = fmt.Fprintf(b, "\t\tp[%d] = out[%d]\n", index, i)
+		}
+		_, _ = fmt.Fprintf(b, "\t}\n")
+	}
+	_, _ = fmt.Fprintf(b, "\tcopy(s[%d:], p[%d:])\n}\n", sensors, sensors)
+
+	for _, aType := range layout.activationTypes() {
+		name, _ := goFunctionName(typeName, aType, layout.activators)
+		if body, ok := goActivationSources[aType]; ok {
+			_, _ = fmt.Fprintf(b, "\nfunc %s(x float64) float64 {\n\t%s\n}\n", name, body)
+		} else if body, ok = goParametricSources[aType]; ok {
+			args := parametricArgNames[:len(parametricShapeParams(aType, nil))]
+			_, _ = fmt.Fprintf(b, "\nfunc %s(x, %s float64) float64 {\n\t%s\n}\n", name, strings.Join(args, ", "), body)
+		} else if body, ok = goModuleSources[aType]; ok {
+			_, _ = fmt.Fprintf(b, "\nfunc %s(x []float64) []float64 {\n\t%s\n}\n", name, body)
+		} else {
+			return fmt.Errorf("unsupported activation type for Go source generation: %d", aType)
+		}
+	}
+
+	// insert import of math package if needed
+	code := b.String()
+	if strings.Contains(code, "math.") {
+		code = strings.Replace(code, "package "+packageName+"\n", "package "+packageName+"\n\nimport \"math\"\n", 1)
+	}
+	source, err := format.Source([]byte(code))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+// goFunctionName returns the name of the Go function implementing provided activation type. The name is prefixed
+// with the type name to allow generation of multiple networks into the same package.
+func goFunctionName(typeName string, aType neatmath.NodeActivationType, activators *neatmath.NodeActivatorsFactory) (string, error) {
+	name, err := activators.ActivationNameFromType(aType)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(typeName[:1]) + typeName[1:] + name, nil
+}
+
+// goNeuronSum returns the Go expression of the weighted sum of incoming signals of the neuron
+func goNeuronSum(neuron *solverNeuron, hasBias bool) string {
+	terms := make([]string, 0, len(neuron.incoming)+1)
+	for _, conn := range neuron.incoming {
+		terms = append(terms, fmt.Sprintf("s[%d]*%s", conn.source, goFloat(conn.weight)))
+	}
+	if hasBias && (neuron.bias != 0 || len(terms) == 0) {
+		terms = append(terms, goFloat(neuron.bias))
+	}
+	if len(terms) == 0 {
+		return "0"
+	}
+	return strings.Join(terms, " + ")
+}
+
+// goFloat returns the Go literal of provided float value which can be exactly parsed back
+func goFloat(v float64) string {
+	if math.IsNaN(v) {
+		return "math.NaN()"
+	} else if math.IsInf(v, 1) {
+		return "math.Inf(1)"
+	} else if math.IsInf(v, -1) {
+		return "math.Inf(-1)"
+	}
+	str := strconv.FormatFloat(v, 'g', -1, 64)
+	if !strings.ContainsAny(str, ".e") {
+		str += ".0"
+	}
+	if v < 0 {
+		return "(" + str + ")"
+	}
+	return str
+}
@@ -0,0 +1,219 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const goSourceHarnessMain = `package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+func main() {
+	net := NewTestNet()
+	results := make([][TestNetOutputs]float64, 0)
+	for _, in := range samples {
+		out := net.Activate(in, steps)
+		results = append(results, out)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(results); err != nil {
+		panic(err)
+	}
+}
+`
+
+// runGeneratedGoSource generates Go source of the network, compiles it along with the harness and runs it with
+// provided samples. Each sample is activated given number of steps without flushing the network state between
+// samples. Returns the outputs per sample.
+func runGeneratedGoSource(t *testing.T, net *network.Network, samples [][]float64, steps int) [][]float64 {
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool is not available")
+	}
+	dir := t.TempDir()
+
+	b := bytes.NewBufferString("")
+	err = WriteGoSource(b, net, "main", "TestNet")
+	require.NoError(t, err, "failed to generate Go source")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "network.go"), b.Bytes(), 0644))
+
+	data := bytes.NewBufferString("package main\n\n")
+	_, _ = fmt.Fprintf(data, "const steps = %d\n\nvar samples = [][TestNetInputs]float64{\n", steps)
+	for _, sample := range samples {
+		values := make([]string, len(sample))
+		for i, v := range sample {
+			values[i] = fmt.Sprintf("%v", v)
+		}
+		_, _ = fmt.Fprintf(data, "\t{%s},\n", strings.Join(values, ", "))
+	}
+	_, _ = fmt.Fprintf(data, "}\n")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.go"), data.Bytes(), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(goSourceHarnessMain), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module harness\n\ngo 1.17\n"), 0644))
+
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		t.Log(string(exitErr.Stderr))
+	}
+	require.NoError(t, err, "failed to run generated Go source")
+
+	results := make([][]float64, 0)
+	err = json.Unmarshal(out, &results)
+	require.NoError(t, err, "failed to parse outputs")
+	return results
+}
+
+// runFastSolver activates the fast network solver created from the network with provided samples. Each sample is
+// activated given number of steps without flushing the network state between samples.
+func runFastSolver(t *testing.T, net *network.Network, samples [][]float64, steps int) [][]float64 {
+	solver, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast network solver")
+	results := make([][]float64, len(samples))
+	for i, sample := range samples {
+		require.NoError(t, solver.LoadSensors(sample), "failed to load sensors")
+		_, err = solver.ForwardSteps(steps)
+		require.NoError(t, err, "failed to activate")
+		results[i] = solver.ReadOutputs()
+	}
+	return results
+}
+
+func TestWriteGoSource_harness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compilation of generated Go source in short mode")
+	}
+	samples := [][]float64{{0.5, 1.1}, {-0.3, 2.0}, {0.0, 0.0}, {1.0, -1.0}}
+	cases := map[string]*network.Network{
+		"plain":           buildNetwork(),
+		"modular":         buildModularNetwork(),
+		"recurrent":       buildRecurrentNetwork(),
+		"bias genes":      buildBiasGenesNetwork(),
+		"ReLU family":     buildReLUFamilyNetwork(),
+		"parametric":      buildParametricNetwork(),
+		"softmax":         buildOutputModuleNetwork(math.SoftmaxModuleActivation),
+		"log softmax":     buildOutputModuleNetwork(math.LogSoftmaxModuleActivation),
+		"normalize":       buildOutputModuleNetwork(math.NormalizeModuleActivation),
+		"winner-take-all": buildOutputModuleNetwork(math.WinnerTakeAllModuleActivation),
+	}
+	for name, net := range cases {
+		t.Run(name, func(t *testing.T) {
+			expected := runFastSolver(t, net, samples, 3)
+			actual := runGeneratedGoSource(t, net, samples, 3)
+			require.Len(t, actual, len(expected))
+			for i := range expected {
+				assert.InDeltaSlice(t, expected[i], actual[i], 1e-12, "wrong outputs at: %d", i)
+			}
+		})
+	}
+}
+
+func TestWriteGoSource(t *testing.T) {
+	net := buildModularNetwork()
+	net.Name = "modular"
+	b := bytes.NewBufferString("")
+	err := WriteGoSource(b, net, "champion", "Champion")
+	require.NoError(t, err, "failed to generate Go source")
+
+	source := b.String()
+	assert.Contains(t, source, "// Code generated by goNEAT from network \"modular\" with id 0. DO NOT EDIT.")
+	assert.Contains(t, source, "package champion")
+	assert.Contains(t, source, "const ChampionInputs = 2")
+	assert.Contains(t, source, "const ChampionOutputs = 2")
+	assert.Contains(t, source, "func (n *Champion) Activate(inputs [2]float64, steps int) (outputs [2]float64)")
+	assert.Contains(t, source, "func championMultiplyModuleActivation(x []float64) []float64")
+	assert.Contains(t, source, "func championLinearActivation(x float64) float64")
+	assert.NotContains(t, source, "SigmoidSteepenedActivation", "unused activation functions must be omitted")
+	assert.NotContains(t, source, "import \"math\"", "math package is not used")
+}
+
+func TestWriteGoSource_wrongNames(t *testing.T) {
+	net := buildNetwork()
+	b := bytes.NewBufferString("")
+	err := WriteGoSource(b, net, "1main", "Network")
+	assert.EqualError(t, err, "invalid package name: \"1main\"")
+	err = WriteGoSource(b, net, "main", "network")
+	assert.EqualError(t, err, "invalid type name, it must be exported identifier: \"network\"")
+	assert.Zero(t, b.Len())
+}
+
+func TestWriteGoSource_delayedLink(t *testing.T) {
+	net := buildNetwork()
+	net.BaseNodes()[3].Incoming[0].Delay = 1
+	b := bytes.NewBufferString("")
+	err := WriteGoSource(b, net, "main", "Network")
+	assert.Error(t, err, "time-delayed links are not supported")
+	assert.Zero(t, b.Len())
+}
+
+func TestWriteGoSource_memoryNeuron(t *testing.T) {
+	net := buildNetwork()
+	net.BaseNodes()[4].NeuronType = network.MemoryNeuron
+	b := bytes.NewBufferString("")
+	err := WriteGoSource(b, net, "main", "Network")
+	assert.EqualError(t, err, "memory cell neuron is not supported by the source code generators: 5")
+	assert.Zero(t, b.Len())
+}
+
+func TestWriteGoSource_modulatoryNeuron(t *testing.T) {
+	net := buildNetwork()
+	net.BaseNodes()[4].NeuronType = network.ModulatoryNeuron
+	b := bytes.NewBufferString("")
+	err := WriteGoSource(b, net, "main", "Network")
+	assert.EqualError(t, err, "modulatory neuron is not supported by the source code generators: 5")
+	assert.Zero(t, b.Len())
+}
+
+func TestWriteGoSource_parametricActivation(t *testing.T) {
+	net := buildParametricNetwork()
+	b := bytes.NewBufferString("")
+	err := WriteGoSource(b, net, "main", "Network")
+	require.NoError(t, err, "failed to generate Go source")
+
+	source := b.String()
+	assert.Contains(t, source, "func networkPReLUActivation(x, a float64) float64")
+	assert.Contains(t, source, "func networkParametricSigmoidActivation(x, a, b float64) float64")
+	// the default slope of PReLU is used when node has no parameters
+	assert.Regexp(t, `networkPReLUActivation\(.+, 0\.25\)\s+// neuron 5`, source)
+	assert.Regexp(t, `networkParametricSigmoidActivation\(.+, 2\.4\d*, 0\.98\d+\)\s+// neuron 8`, source)
+}
+
+func TestWriteGoSource_customActivator(t *testing.T) {
+	net, _ := buildCustomActivatorNetwork()
+	b := bytes.NewBufferString("")
+	err := WriteGoSource(b, net, "main", "Network")
+	assert.EqualError(t, err, fmt.Sprintf("unsupported activation type for Go source generation: %d",
+		net.Outputs[0].ActivationType))
+	assert.Zero(t, b.Len())
+}
+
+func TestWriteGoSource_Write_Error(t *testing.T) {
+	errWriter := ErrorWriter(1)
+	err := WriteGoSource(&errWriter, buildNetwork(), "main", "Network")
+	assert.EqualError(t, err, alwaysErrorText)
+}
+
+func TestGoFloat(t *testing.T) {
+	assert.Equal(t, "1.0", goFloat(1))
+	assert.Equal(t, "(-1.5)", goFloat(-1.5))
+	assert.Equal(t, "1e-10", goFloat(1e-10))
+	assert.Equal(t, "math.Inf(1)", goFloat(1/zero()))
+}
+
+func zero() float64 {
+	return 0
+}
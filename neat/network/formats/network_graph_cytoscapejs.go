@@ -51,7 +51,7 @@ func WriteCytoscapeJSONWithStyle(w io.Writer, n *network.Network, style *Cytosca
 	// add all ordinary nodes
 	for _, node := range n.BaseNodes() {
 		// populate Nodes data
-		elements.Nodes = append(elements.Nodes, nodeToCyJsNode(node, false))
+		elements.Nodes = append(elements.Nodes, nodeToCyJsNode(node, false, n.Activators()))
 		// populate edges data from incoming side
 		for _, e := range node.Incoming {
 			elements.Edges = append(elements.Edges, linkToCyJsEdge(e))
@@ -61,7 +61,7 @@ func WriteCytoscapeJSONWithStyle(w io.Writer, n *network.Network, style *Cytosca
 	// add all control nodes
 	for _, node := range n.ControlNodes() {
 		// populate Nodes data
-		elements.Nodes = append(elements.Nodes, nodeToCyJsNode(node, true))
+		elements.Nodes = append(elements.Nodes, nodeToCyJsNode(node, true, n.Activators()))
 
 		// populate edges data from the incoming side
 		for _, e := range node.Incoming {
@@ -111,8 +111,8 @@ const (
 	attrTrait                  = "trait"
 )
 
-func nodeToCyJsNode(node *network.NNode, control bool) cytoscapejs.Node {
-	actName, err := math.NodeActivators.ActivationNameFromType(node.ActivationType)
+func nodeToCyJsNode(node *network.NNode, control bool, activators *math.NodeActivatorsFactory) cytoscapejs.Node {
+	actName, err := activators.ActivationNameFromType(node.ActivationType)
 	if err != nil {
 		actName = "unknown"
 	}
@@ -154,6 +154,9 @@ func linkToCyJsEdge(link *network.Link) cytoscapejs.Edge {
 		},
 		Selectable: true,
 	}
+	if link.Delay > 0 {
+		edgeJS.Data.Attributes["delay"] = link.Delay
+	}
 	if link.Trait != nil {
 		edgeJS.Data.Attributes["trait"] = link.Trait.String()
 	}
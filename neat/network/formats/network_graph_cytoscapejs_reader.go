@@ -0,0 +1,341 @@
+package formats
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/genetics"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"gonum.org/v1/gonum/graph/formats/cytoscapejs"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReadCytoscapeJSON is to read the network graph from the Cytoscape JSON encoding produced by WriteCytoscapeJSON,
+// possibly modified afterwards with the Cytoscape application. The network is restored from the following
+// attributes of the graph elements:
+//   - nodes: neuron_type, activation_function, control_node, and trait,
+//   - edges: weight, recurrent, time_delayed, and trait.
+//
+// The nodes without neuron type are considered as hidden neurons and the nodes without activation function get the
+// default activation function. All edges must have the weight attribute. The styling attributes are ignored.
+func ReadCytoscapeJSON(r io.Reader, netId int) (*network.Network, error) {
+	return ReadCytoscapeJSONWithActivators(r, netId, nil)
+}
+
+// ReadCytoscapeJSONWithActivators is to read the network graph from the Cytoscape JSON encoding (see ReadCytoscapeJSON)
+// resolving the activation functions by name with provided factory, which allows to read the custom activation
+// functions of the experiment. The read network keeps the factory. If activators is nil the default
+// math.NodeActivators is used.
+func ReadCytoscapeJSONWithActivators(r io.Reader, netId int, activators *math.NodeActivatorsFactory) (*network.Network, error) {
+	graph, err := readCytoscapeGraph(r, activators)
+	if err != nil {
+		return nil, err
+	}
+	return graph.network(netId), nil
+}
+
+// ReadCytoscapeJSONGenome is to read the network graph from the Cytoscape JSON encoding (see ReadCytoscapeJSON) and
+// to create the genome encoding it. The innovation numbers are assigned to the genes sequentially starting from one
+// in order of edges appearance, followed by the control genes of the modules. If the graph has no traits, the genome
+// gets the default trait with zero parameters, similar to the randomly created genomes. The returned genome can be
+// used to seed a new population.
+func ReadCytoscapeJSONGenome(r io.Reader, genomeId int) (*genetics.Genome, error) {
+	return ReadCytoscapeJSONGenomeWithActivators(r, genomeId, nil)
+}
+
+// ReadCytoscapeJSONGenomeWithActivators is to read the genome from the Cytoscape JSON encoding (see
+// ReadCytoscapeJSONGenome) resolving the activation functions by name with provided factory. The read genome keeps
+// the factory. If activators is nil the default math.NodeActivators is used.
+func ReadCytoscapeJSONGenomeWithActivators(r io.Reader, genomeId int, activators *math.NodeActivatorsFactory) (*genetics.Genome, error) {
+	graph, err := readCytoscapeGraph(r, activators)
+	if err != nil {
+		return nil, err
+	}
+	return graph.genome(genomeId), nil
+}
+
+// cytoscapeGraph is the network graph decoded from Cytoscape JSON
+type cytoscapeGraph struct {
+	// The nodes in order of appearance
+	nodes []*network.NNode
+	// The control nodes flags per node ID
+	control map[int]bool
+	// The links in order of appearance
+	links []*network.Link
+	// The traits sorted by ID
+	traits []*neat.Trait
+	// The factory of activation functions, if nil the default math.NodeActivators is used
+	activators *math.NodeActivatorsFactory
+}
+
+// readCytoscapeGraph decodes the network graph from the Cytoscape JSON
+func readCytoscapeGraph(r io.Reader, activators *math.NodeActivatorsFactory) (*cytoscapeGraph, error) {
+	var graphNodeEdge cytoscapejs.GraphNodeEdge
+	if err := json.NewDecoder(r).Decode(&graphNodeEdge); err != nil {
+		return nil, err
+	}
+	graph := &cytoscapeGraph{control: make(map[int]bool), activators: activators}
+	if activators == nil {
+		activators = math.NodeActivators
+	}
+	traits := make(map[int]*neat.Trait)
+
+	nodes := make(map[int]*network.NNode)
+	for _, cyNode := range graphNodeEdge.Elements.Nodes {
+		node, control, err := cyJsNodeToNode(cyNode, traits, activators)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := nodes[node.Id]; ok {
+			return nil, fmt.Errorf("duplicate node ID: %d", node.Id)
+		}
+		nodes[node.Id] = node
+		graph.nodes = append(graph.nodes, node)
+		graph.control[node.Id] = control
+	}
+
+	for _, cyEdge := range graphNodeEdge.Elements.Edges {
+		link, err := cyJsEdgeToLink(cyEdge, nodes, traits)
+		if err != nil {
+			return nil, err
+		}
+		if graph.control[link.InNode.Id] && graph.control[link.OutNode.Id] {
+			return nil, fmt.Errorf("edge: %s connects two control nodes", cyEdge.Data.ID)
+		}
+		graph.links = append(graph.links, link)
+	}
+
+	for _, trait := range traits {
+		graph.traits = append(graph.traits, trait)
+	}
+	sort.Slice(graph.traits, func(i, j int) bool {
+		return graph.traits[i].Id < graph.traits[j].Id
+	})
+	return graph, nil
+}
+
+// network creates the network with given ID from the decoded graph
+func (g *cytoscapeGraph) network(netId int) *network.Network {
+	inputs, outputs, all, control := make([]*network.NNode, 0), make([]*network.NNode, 0), make([]*network.NNode, 0), make([]*network.NNode, 0)
+	for _, node := range g.nodes {
+		if g.control[node.Id] {
+			control = append(control, node)
+			continue
+		}
+		all = append(all, node)
+		switch node.NeuronType {
+		case network.InputNeuron, network.BiasNeuron:
+			inputs = append(inputs, node)
+		case network.OutputNeuron:
+			outputs = append(outputs, node)
+		}
+	}
+	for _, link := range g.links {
+		if g.control[link.OutNode.Id] {
+			link.OutNode.Incoming = append(link.OutNode.Incoming, link)
+		} else if g.control[link.InNode.Id] {
+			link.InNode.Outgoing = append(link.InNode.Outgoing, link)
+		} else {
+			link.OutNode.Incoming = append(link.OutNode.Incoming, link)
+			link.InNode.Outgoing = append(link.InNode.Outgoing, link)
+		}
+	}
+	var net *network.Network
+	if len(control) > 0 {
+		net = network.NewModularNetwork(inputs, outputs, all, control, netId)
+	} else {
+		net = network.NewNetwork(inputs, outputs, all, netId)
+	}
+	net.SetActivators(g.activators)
+	return net
+}
+
+// genome creates the genome with given ID from the decoded graph
+func (g *cytoscapeGraph) genome(genomeId int) *genetics.Genome {
+	traits := g.traits
+	if len(traits) == 0 {
+		trait := neat.NewTrait()
+		trait.Id = 1
+		traits = []*neat.Trait{trait}
+	}
+
+	nodes := make([]*network.NNode, 0, len(g.nodes))
+	controlNodes := make(map[int]*network.NNode)
+	lookup := make(map[int]*network.NNode)
+	for _, node := range g.nodes {
+		nodeCopy := network.NewNNodeCopy(node, node.Trait)
+		lookup[node.Id] = nodeCopy
+		if g.control[node.Id] {
+			controlNodes[node.Id] = nodeCopy
+		} else {
+			nodes = append(nodes, nodeCopy)
+		}
+	}
+
+	innovation := int64(1)
+	genes := make([]*genetics.Gene, 0, len(g.links))
+	for _, link := range g.links {
+		inNode, outNode := lookup[link.InNode.Id], lookup[link.OutNode.Id]
+		if g.control[outNode.Id] {
+			outNode.Incoming = append(outNode.Incoming, network.NewLink(1.0, inNode, outNode, false))
+		} else if g.control[inNode.Id] {
+			inNode.Outgoing = append(inNode.Outgoing, network.NewLink(1.0, inNode, outNode, false))
+		} else {
+			linkCopy := network.NewLinkCopy(link, inNode, outNode)
+			linkCopy.IsTimeDelayed = link.IsTimeDelayed
+			genes = append(genes, genetics.NewConnectionGene(linkCopy, innovation, 0, true))
+			innovation++
+		}
+	}
+
+	controlGenes := make([]*genetics.MIMOControlGene, 0, len(controlNodes))
+	for _, node := range g.nodes {
+		if controlNode, ok := controlNodes[node.Id]; ok {
+			controlGenes = append(controlGenes, genetics.NewMIMOGene(controlNode, innovation, 0, true))
+			innovation++
+		}
+	}
+	var genome *genetics.Genome
+	if len(controlGenes) > 0 {
+		genome = genetics.NewModularGenome(genomeId, traits, nodes, genes, controlGenes)
+	} else {
+		genome = genetics.NewGenome(genomeId, traits, nodes, genes)
+	}
+	genome.SetActivators(g.activators)
+	return genome
+}
+
+// cyJsNodeToNode creates the network node from the Cytoscape JSON node and returns it along with control node flag
+func cyJsNodeToNode(cyNode cytoscapejs.Node, traits map[int]*neat.Trait, activators *math.NodeActivatorsFactory) (*network.NNode, bool, error) {
+	id, err := strconv.Atoi(cyNode.Data.ID)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "invalid node ID: %q", cyNode.Data.ID)
+	}
+	node := network.NewNetworkNode()
+	node.Id = id
+	attrs := cyNode.Data.Attributes
+	if name, ok := attrs[attrNeuronType]; ok {
+		if node.NeuronType, err = network.NeuronTypeByName(cast.ToString(name)); err != nil {
+			return nil, false, errors.Wrapf(err, "node: %d", id)
+		}
+	}
+	if name, ok := attrs[attrActivationFunc]; ok {
+		if node.ActivationType, err = activators.ActivationTypeFromName(cast.ToString(name)); err != nil {
+			return nil, false, errors.Wrapf(err, "node: %d", id)
+		}
+	}
+	control := false
+	if value, ok := attrs[attrControlNode]; ok {
+		if control, err = cast.ToBoolE(value); err != nil {
+			return nil, false, errors.Wrapf(err, "node: %d, invalid %s", id, attrControlNode)
+		}
+	}
+	if value, ok := attrs[attrTrait]; ok {
+		if node.Trait, err = readTrait(cast.ToString(value), traits); err != nil {
+			return nil, false, errors.Wrapf(err, "node: %d", id)
+		}
+	}
+	return node, control, nil
+}
+
+// cyJsEdgeToLink creates the network link from the Cytoscape JSON edge. The created link is not attached to the nodes.
+func cyJsEdgeToLink(cyEdge cytoscapejs.Edge, nodes map[int]*network.NNode, traits map[int]*neat.Trait) (*network.Link, error) {
+	lookupNode := func(id string) (*network.NNode, error) {
+		nodeId, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "edge: %s, invalid node ID: %q", cyEdge.Data.ID, id)
+		}
+		node, ok := nodes[nodeId]
+		if !ok {
+			return nil, fmt.Errorf("edge: %s, node with ID: %d not found", cyEdge.Data.ID, nodeId)
+		}
+		return node, nil
+	}
+	inNode, err := lookupNode(cyEdge.Data.Source)
+	if err != nil {
+		return nil, err
+	}
+	outNode, err := lookupNode(cyEdge.Data.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := cyEdge.Data.Attributes
+	value, ok := attrs["weight"]
+	if !ok {
+		return nil, fmt.Errorf("edge: %s has no weight", cyEdge.Data.ID)
+	}
+	weight, err := cast.ToFloat64E(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "edge: %s, invalid weight", cyEdge.Data.ID)
+	}
+	recurrent, err := cast.ToBoolE(attrs["recurrent"])
+	if err != nil {
+		return nil, errors.Wrapf(err, "edge: %s, invalid recurrent flag", cyEdge.Data.ID)
+	}
+	var trait *neat.Trait
+	if value, ok = attrs[attrTrait]; ok {
+		if trait, err = readTrait(cast.ToString(value), traits); err != nil {
+			return nil, errors.Wrapf(err, "edge: %s", cyEdge.Data.ID)
+		}
+	}
+	link := network.NewLinkWithTrait(trait, weight, inNode, outNode, recurrent)
+	if link.IsTimeDelayed, err = cast.ToBoolE(attrs["time_delayed"]); err != nil {
+		return nil, errors.Wrapf(err, "edge: %s, invalid time delayed flag", cyEdge.Data.ID)
+	}
+	if value, ok = attrs["delay"]; ok {
+		if link.Delay, err = cast.ToIntE(value); err != nil || link.Delay < 0 {
+			return nil, errors.Errorf("edge: %s, invalid delay: %v", cyEdge.Data.ID, value)
+		}
+	}
+	return link, nil
+}
+
+// readTrait parses the trait from its string representation produced by neat.Trait String. The parsed traits are
+// cached by ID, and the traits with the same ID must have the same parameters.
+func readTrait(str string, traits map[int]*neat.Trait) (*neat.Trait, error) {
+	var id int
+	if _, err := fmt.Sscanf(str, "Trait #%d (", &id); err != nil {
+		return nil, errors.Wrapf(err, "invalid trait: %q", str)
+	}
+	start, end := strings.Index(str, "("), strings.LastIndex(str, ")")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("invalid trait: %q", str)
+	}
+	fields := strings.Fields(str[start+1 : end])
+	trait := &neat.Trait{Id: id, Params: make([]float64, len(fields))}
+	for i, f := range fields {
+		p, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trait: %q", str)
+		}
+		trait.Params[i] = p
+	}
+	if existing, ok := traits[id]; ok {
+		if !equalParams(existing.Params, trait.Params) {
+			return nil, fmt.Errorf("trait with ID: %d has different parameters: %q", id, str)
+		}
+		return existing, nil
+	}
+	traits[id] = trait
+	return trait, nil
+}
+
+// equalParams checks whether provided parameters are equal
+func equalParams(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
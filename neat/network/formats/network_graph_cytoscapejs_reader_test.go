@@ -0,0 +1,189 @@
+package formats
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"strings"
+	"testing"
+)
+
+func TestReadCytoscapeJSON(t *testing.T) {
+	samples := [][]float64{{0.5, 1.1}, {-0.3, 2.0}, {1.0, -1.0}}
+	cases := map[string]*network.Network{
+		"plain":     buildNetwork(),
+		"modular":   buildModularNetwork(),
+		"recurrent": buildRecurrentNetwork(),
+		"softmax":   buildOutputModuleNetwork(math.SoftmaxModuleActivation),
+	}
+	for name, net := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := bytes.NewBufferString("")
+			err := WriteCytoscapeJSON(b, net)
+			require.NoError(t, err, "failed to write Cytoscape JSON")
+
+			readNet, err := ReadCytoscapeJSON(b, 10)
+			require.NoError(t, err, "failed to read Cytoscape JSON")
+			assert.Equal(t, 10, readNet.Id)
+			assert.Equal(t, net.NodeCount(), readNet.NodeCount())
+			assert.Equal(t, net.LinkCount(), readNet.LinkCount())
+			assert.Len(t, readNet.ControlNodes(), len(net.ControlNodes()))
+			assert.Len(t, readNet.Outputs, len(net.Outputs))
+
+			expected := runFastSolver(t, net, samples, 3)
+			actual := runFastSolver(t, readNet, samples, 3)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestReadCytoscapeJSONWithActivators(t *testing.T) {
+	net, factory := buildCustomActivatorNetwork()
+	b := bytes.NewBufferString("")
+	err := WriteCytoscapeJSON(b, net)
+	require.NoError(t, err, "failed to write Cytoscape JSON")
+	assert.Contains(t, b.String(), "SquareTestActivation")
+
+	_, err = ReadCytoscapeJSON(bytes.NewReader(b.Bytes()), 10)
+	assert.Error(t, err, "custom activation function is unknown to the default factory")
+
+	readNet, err := ReadCytoscapeJSONWithActivators(bytes.NewReader(b.Bytes()), 10, factory)
+	require.NoError(t, err, "failed to read Cytoscape JSON")
+	assert.Same(t, factory, readNet.Activators())
+	assert.Equal(t, net.Outputs[0].ActivationType, readNet.Outputs[0].ActivationType)
+	samples := [][]float64{{0.5, 1.1}, {-0.3, 2.0}}
+	assert.Equal(t, runFastSolver(t, net, samples, 3), runFastSolver(t, readNet, samples, 3))
+
+	genome, err := ReadCytoscapeJSONGenomeWithActivators(bytes.NewReader(b.Bytes()), 10, factory)
+	require.NoError(t, err, "failed to read Cytoscape JSON genome")
+	assert.Same(t, factory, genome.Activators())
+}
+
+func TestReadCytoscapeJSON_attributes(t *testing.T) {
+	net := buildRecurrentNetwork()
+	trait := &neat.Trait{Id: 2, Params: []float64{0.1, 0.2, 0.3}}
+	nodes := net.BaseNodes()
+	nodes[3].Trait = trait
+	nodes[3].Incoming[0].Trait = trait
+	nodes[5].Incoming[0].IsTimeDelayed = true
+	nodes[4].Incoming[0].Delay = 2
+
+	b := bytes.NewBufferString("")
+	err := WriteCytoscapeJSON(b, net)
+	require.NoError(t, err, "failed to write Cytoscape JSON")
+
+	readNet, err := ReadCytoscapeJSON(b, 0)
+	require.NoError(t, err, "failed to read Cytoscape JSON")
+	readNodes := readNet.BaseNodes()
+	require.Len(t, readNodes, len(nodes))
+	for i, node := range nodes {
+		assert.Equal(t, node.Id, readNodes[i].Id)
+		assert.Equal(t, node.NeuronType, readNodes[i].NeuronType)
+		assert.Equal(t, node.ActivationType, readNodes[i].ActivationType)
+		require.Len(t, readNodes[i].Incoming, len(node.Incoming))
+		for j, link := range node.Incoming {
+			readLink := readNodes[i].Incoming[j]
+			assert.Equal(t, link.InNode.Id, readLink.InNode.Id)
+			assert.Equal(t, link.ConnectionWeight, readLink.ConnectionWeight)
+			assert.Equal(t, link.IsRecurrent, readLink.IsRecurrent)
+			assert.Equal(t, link.IsTimeDelayed, readLink.IsTimeDelayed)
+			assert.Equal(t, link.Delay, readLink.Delay)
+		}
+	}
+	require.NotNil(t, readNodes[3].Trait)
+	assert.Equal(t, trait.Id, readNodes[3].Trait.Id)
+	assert.InDeltaSlice(t, trait.Params, readNodes[3].Trait.Params, 1e-6)
+	// the same trait instance is shared
+	assert.True(t, readNodes[3].Trait == readNodes[3].Incoming[0].Trait)
+	assert.InDeltaSlice(t, trait.Params, readNodes[3].Incoming[0].Params, 1e-6)
+}
+
+func TestReadCytoscapeJSONGenome(t *testing.T) {
+	samples := [][]float64{{0.5, 1.1}, {-0.3, 2.0}, {1.0, -1.0}}
+	cases := map[string]*network.Network{
+		"plain":     buildNetwork(),
+		"modular":   buildModularNetwork(),
+		"recurrent": buildRecurrentNetwork(),
+	}
+	for name, net := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := bytes.NewBufferString("")
+			err := WriteCytoscapeJSON(b, net)
+			require.NoError(t, err, "failed to write Cytoscape JSON")
+
+			genome, err := ReadCytoscapeJSONGenome(b, 5)
+			require.NoError(t, err, "failed to read genome from Cytoscape JSON")
+			assert.Equal(t, 5, genome.Id)
+			require.Len(t, genome.Traits, 1, "default trait expected")
+			assert.Len(t, genome.Nodes, len(net.BaseNodes()))
+			assert.Len(t, genome.ControlGenes, len(net.ControlNodes()))
+
+			// check innovation numbers
+			innovation := int64(1)
+			for _, gene := range genome.Genes {
+				assert.Equal(t, innovation, gene.InnovationNum)
+				assert.True(t, gene.IsEnabled)
+				innovation++
+			}
+			for _, gene := range genome.ControlGenes {
+				assert.Equal(t, innovation, gene.InnovationNum)
+				innovation++
+			}
+
+			phenotype, err := genome.Genesis(genome.Id)
+			require.NoError(t, err, "failed to create phenotype")
+			expected := runFastSolver(t, net, samples, 3)
+			actual := runFastSolver(t, phenotype, samples, 3)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestReadCytoscapeJSON_hand_edited(t *testing.T) {
+	data := `{"elements":{"nodes":[
+		{"data":{"id":"1","neuron_type":"INPT"}},
+		{"data":{"id":"2","neuron_type":"BIAS"}},
+		{"data":{"id":"3"}},
+		{"data":{"id":"4","neuron_type":"OUTP","activation_function":"LinearActivation"}}
+	],"edges":[
+		{"data":{"id":"1-3","source":"1","target":"3","weight":1.5}},
+		{"data":{"id":"2-3","source":"2","target":"3","weight":-0.5}},
+		{"data":{"id":"3-4","source":"3","target":"4","weight":2}}
+	]}}`
+	net, err := ReadCytoscapeJSON(strings.NewReader(data), 1)
+	require.NoError(t, err, "failed to read Cytoscape JSON")
+	nodes := net.BaseNodes()
+	require.Len(t, nodes, 4)
+	assert.Equal(t, network.HiddenNeuron, nodes[2].NeuronType)
+	assert.Equal(t, math.SigmoidSteepenedActivation, nodes[2].ActivationType)
+	assert.Equal(t, math.LinearActivation, nodes[3].ActivationType)
+	assert.Equal(t, 3, net.LinkCount())
+	assert.Len(t, net.Outputs, 1)
+}
+
+func TestReadCytoscapeJSON_errors(t *testing.T) {
+	cases := map[string]string{
+		"malformed JSON":     `{"elements":`,
+		"invalid node ID":    `{"elements":{"nodes":[{"data":{"id":"a"}}]}}`,
+		"duplicate node":     `{"elements":{"nodes":[{"data":{"id":"1"}},{"data":{"id":"1"}}]}}`,
+		"neuron type":        `{"elements":{"nodes":[{"data":{"id":"1","neuron_type":"UNKNOWN"}}]}}`,
+		"activation":         `{"elements":{"nodes":[{"data":{"id":"1","activation_function":"unknown"}}]}}`,
+		"trait":              `{"elements":{"nodes":[{"data":{"id":"1","trait":"Trait"}}]}}`,
+		"missing node":       `{"elements":{"nodes":[{"data":{"id":"1"}}],"edges":[{"data":{"id":"1-2","source":"1","target":"2","weight":1}}]}}`,
+		"missing weight":     `{"elements":{"nodes":[{"data":{"id":"1"}},{"data":{"id":"2"}}],"edges":[{"data":{"id":"1-2","source":"1","target":"2"}}]}}`,
+		"invalid weight":     `{"elements":{"nodes":[{"data":{"id":"1"}},{"data":{"id":"2"}}],"edges":[{"data":{"id":"1-2","source":"1","target":"2","weight":"w"}}]}}`,
+		"control to control": `{"elements":{"nodes":[{"data":{"id":"1","control_node":true}},{"data":{"id":"2","control_node":true}}],"edges":[{"data":{"id":"1-2","source":"1","target":"2","weight":1}}]}}`,
+		"trait mismatch":     `{"elements":{"nodes":[{"data":{"id":"1","trait":"Trait #1 ( 0.100000 )"}},{"data":{"id":"2","trait":"Trait #1 ( 0.200000 )"}}]}}`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadCytoscapeJSON(strings.NewReader(data), 0)
+			assert.Error(t, err)
+			_, err = ReadCytoscapeJSONGenome(strings.NewReader(data), 0)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -179,7 +179,7 @@ func TestWriteCytoscapeJSON_nodeToCyJsNode(t *testing.T) {
 		t.Logf("Test case: %d", i)
 
 		node.ActivationType = math.SigmoidApproximationActivation
-		nodeJS := nodeToCyJsNode(node, tc.control)
+		nodeJS := nodeToCyJsNode(node, tc.control, math.NodeActivators)
 		require.NotNil(t, nodeJS)
 		require.NotEmpty(t, nodeJS.Data.Attributes)
 
@@ -201,8 +201,8 @@ func TestWriteCytoscapeJSON_nodeToCyJsNode(t *testing.T) {
 		assert.Equal(t, attrs, nodeJS.Data.Attributes)
 
 		// check unknown activation type
-		node.ActivationType = math.MinModuleActivation + 1
-		nodeJS = nodeToCyJsNode(node, tc.control)
+		node.ActivationType = math.ParametricSigmoidActivation + 1
+		nodeJS = nodeToCyJsNode(node, tc.control, math.NodeActivators)
 		require.NotNil(t, nodeJS)
 		require.NotEmpty(t, nodeJS.Data.Attributes)
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
 	"testing"
 )
 
@@ -18,6 +19,22 @@ func TestWriteDOT(t *testing.T) {
 	assert.NotEmpty(t, b)
 }
 
+func TestWriteDOT_outputModule(t *testing.T) {
+	net := buildOutputModuleNetwork(math.SoftmaxModuleActivation)
+	net.Name = "TestNN"
+
+	b := bytes.NewBufferString("")
+	err := WriteDOT(b, net)
+	require.NoError(t, err, "failed to DOT encode")
+	dot := b.String()
+	assert.Contains(t, dot, "SoftmaxModuleActivation")
+	for _, id := range []string{"4", "5", "6"} {
+		// the outputs are both inputs and outputs of the module
+		assert.Contains(t, dot, id+" -> 7")
+		assert.Contains(t, dot, "7 -> "+id)
+	}
+}
+
 func TestWriteDOT_Write_Error(t *testing.T) {
 	net := buildNetwork()
 	net.Name = "TestNN"
@@ -0,0 +1,126 @@
+package formats
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"io"
+)
+
+// The GEXF XML elements
+
+type gexfDocument struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Meta    gexfMeta  `xml:"meta"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfMeta struct {
+	Creator     string `xml:"creator"`
+	Description string `xml:"description"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Mode            string           `xml:"mode,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Weight    float64        `xml:"weight,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// WriteGEXF is to write this network graph using GEXF 1.3 encoding (https://gexf.net), which is native format of
+// the Gephi graph visualization software. The nodes of the graph have neuron type, activation type, trait parameters,
+// and control flag attributes. The edges have weight, recurrent, time delayed, enabled flags, and trait parameters
+// attributes. The control nodes (modules) and their links are included into the graph.
+func WriteGEXF(w io.Writer, n *network.Network) error {
+	elements := collectGraphElements(n)
+	doc := gexfDocument{
+		XMLNS:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Meta: gexfMeta{
+			Creator:     "goNEAT",
+			Description: fmt.Sprintf("Network %s with id %d", n.Name, n.Id),
+		},
+		Graph: gexfGraph{
+			DefaultEdgeType: "directed",
+			Mode:            "static",
+			Attributes: []gexfAttributes{
+				{Class: "node", Attributes: gexfAttributesOf(elements.nodeKeys)},
+				{Class: "edge", Attributes: gexfAttributesOf(elements.edgeKeys)},
+			},
+		},
+	}
+	for _, node := range elements.nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+			ID:        node.id,
+			Label:     node.id,
+			AttValues: gexfAttValuesOf(node, elements.nodeKeys),
+		})
+	}
+	for _, edge := range elements.edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			ID:        edge.id,
+			Source:    edge.source,
+			Target:    edge.target,
+			Weight:    edge.weight,
+			AttValues: gexfAttValuesOf(edge, elements.edgeKeys),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// gexfAttributesOf returns the GEXF declarations of provided attribute keys
+func gexfAttributesOf(keys []graphAttributeKey) []gexfAttribute {
+	attributes := make([]gexfAttribute, len(keys))
+	for i, k := range keys {
+		attributes[i] = gexfAttribute{ID: k.id, Title: k.name, Type: k.attrType}
+	}
+	return attributes
+}
+
+// gexfAttValuesOf returns the GEXF attribute values of provided graph element
+func gexfAttValuesOf(element graphElement, keys []graphAttributeKey) []gexfAttValue {
+	values := make([]gexfAttValue, len(element.attributes))
+	for i, attr := range element.attributes {
+		values[i] = gexfAttValue{For: keyID(keys, attr.Key), Value: attr.Value}
+	}
+	return values
+}
@@ -0,0 +1,106 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestWriteGEXF(t *testing.T) {
+	net := buildRecurrentNetwork()
+	net.Name = "Test <NN>"
+
+	b := bytes.NewBufferString("")
+	err := WriteGEXF(b, net)
+	require.NoError(t, err, "failed to write GEXF")
+	assertWellFormedXML(t, b.Bytes())
+
+	doc := gexfDocument{}
+	err = xml.Unmarshal(b.Bytes(), &doc)
+	require.NoError(t, err, "failed to decode GEXF")
+	assert.Equal(t, "1.3", doc.Version)
+	assert.Equal(t, "Network Test <NN> with id 0", doc.Meta.Description)
+	assert.Equal(t, "directed", doc.Graph.DefaultEdgeType)
+	assert.Len(t, doc.Graph.Nodes, len(net.BaseNodes()))
+	assert.Len(t, doc.Graph.Edges, net.LinkCount())
+
+	require.Len(t, doc.Graph.Attributes, 2)
+	titles := make(map[string]string)
+	for _, class := range doc.Graph.Attributes {
+		for _, a := range class.Attributes {
+			titles[class.Class+a.ID] = a.Title
+		}
+	}
+
+	// check node attributes
+	var node *gexfNode
+	for i := range doc.Graph.Nodes {
+		if doc.Graph.Nodes[i].ID == "4" {
+			node = &doc.Graph.Nodes[i]
+		}
+	}
+	require.NotNil(t, node, "hidden node not found")
+	values := make(map[string]string)
+	for _, v := range node.AttValues {
+		title, ok := titles["node"+v.For]
+		require.True(t, ok, "undeclared attribute: %s", v.For)
+		values[title] = v.Value
+	}
+	assert.Equal(t, "HIDN", values["neuron_type"])
+	assert.Equal(t, "TanhActivation", values["activation_type"])
+	assert.Equal(t, "false", values[attrControl])
+
+	// check self-recurrent edge
+	var edge *gexfEdge
+	for i := range doc.Graph.Edges {
+		if doc.Graph.Edges[i].ID == "6-6" {
+			edge = &doc.Graph.Edges[i]
+		}
+	}
+	require.NotNil(t, edge, "self-recurrent edge not found")
+	assert.Equal(t, 0.25, edge.Weight)
+	values = make(map[string]string)
+	for _, v := range edge.AttValues {
+		title, ok := titles["edge"+v.For]
+		require.True(t, ok, "undeclared attribute: %s", v.For)
+		values[title] = v.Value
+	}
+	assert.Equal(t, "true", values["recurrent"])
+	assert.Equal(t, "true", values[attrEnabled])
+	assert.Equal(t, "false", values[attrTimeDelayed])
+}
+
+func TestWriteGEXF_Modular(t *testing.T) {
+	net := buildModularNetwork()
+
+	b := bytes.NewBufferString("")
+	err := WriteGEXF(b, net)
+	require.NoError(t, err, "failed to write GEXF")
+	assertWellFormedXML(t, b.Bytes())
+
+	doc := gexfDocument{}
+	err = xml.Unmarshal(b.Bytes(), &doc)
+	require.NoError(t, err, "failed to decode GEXF")
+	assert.Len(t, doc.Graph.Nodes, len(net.AllNodes()))
+	assert.Len(t, doc.Graph.Edges, net.LinkCount())
+	assert.Contains(t, b.String(), `value="MultiplyModuleActivation"`)
+}
+
+func TestWriteGEXF_customActivator(t *testing.T) {
+	net, _ := buildCustomActivatorNetwork()
+
+	b := bytes.NewBufferString("")
+	err := WriteGEXF(b, net)
+	require.NoError(t, err, "failed to write GEXF")
+	assert.Contains(t, b.String(), `value="SquareTestActivation"`)
+}
+
+func TestWriteGEXF_Write_Error(t *testing.T) {
+	net := buildNetwork()
+
+	errWriter := ErrorWriter(1)
+	err := WriteGEXF(&errWriter, net)
+	assert.EqualError(t, err, alwaysErrorText)
+}
@@ -0,0 +1,99 @@
+package formats
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"io"
+)
+
+// The GraphML XML elements
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML is to write this network graph using GraphML encoding (http://graphml.graphdrawing.org).
+// The nodes of the graph have neuron type, activation type, trait parameters, and control flag attributes. The edges
+// have weight, recurrent, time delayed, enabled flags, and trait parameters attributes. The control nodes (modules)
+// and their links are included into the graph.
+func WriteGraphML(w io.Writer, n *network.Network) error {
+	elements := collectGraphElements(n)
+	doc := graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphMLGraph{
+			ID:          fmt.Sprintf("%s-%d", n.Name, n.Id),
+			EdgeDefault: "directed",
+		},
+	}
+	for _, k := range elements.nodeKeys {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: k.id, For: "node", AttrName: k.name, AttrType: k.attrType})
+	}
+	for _, k := range elements.edgeKeys {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: k.id, For: "edge", AttrName: k.name, AttrType: k.attrType})
+	}
+	for _, node := range elements.nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID:   node.id,
+			Data: graphMLDataOf(node, elements.nodeKeys),
+		})
+	}
+	for _, edge := range elements.edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     edge.id,
+			Source: edge.source,
+			Target: edge.target,
+			Data:   graphMLDataOf(edge, elements.edgeKeys),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// graphMLDataOf returns the GraphML data elements holding attributes of provided graph element
+func graphMLDataOf(element graphElement, keys []graphAttributeKey) []graphMLData {
+	data := make([]graphMLData, len(element.attributes))
+	for i, attr := range element.attributes {
+		data[i] = graphMLData{Key: keyID(keys, attr.Key), Value: attr.Value}
+	}
+	return data
+}
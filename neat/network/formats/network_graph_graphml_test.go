@@ -0,0 +1,123 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestWriteGraphML(t *testing.T) {
+	net := buildRecurrentNetwork()
+	net.Name = "Test <NN>"
+
+	b := bytes.NewBufferString("")
+	err := WriteGraphML(b, net)
+	require.NoError(t, err, "failed to write GraphML")
+	assertWellFormedXML(t, b.Bytes())
+
+	doc := graphMLDocument{}
+	err = xml.Unmarshal(b.Bytes(), &doc)
+	require.NoError(t, err, "failed to decode GraphML")
+	assert.Equal(t, "directed", doc.Graph.EdgeDefault)
+	assert.Equal(t, "Test <NN>-0", doc.Graph.ID)
+	assert.Len(t, doc.Graph.Nodes, len(net.BaseNodes()))
+	assert.Len(t, doc.Graph.Edges, net.LinkCount())
+
+	keys := make(map[string]graphMLKey)
+	for _, k := range doc.Keys {
+		keys[k.ID] = k
+	}
+	// check node attributes
+	node := doc.Graph.Nodes[0]
+	assert.Equal(t, "1", node.ID)
+	values := make(map[string]string)
+	for _, d := range node.Data {
+		k, ok := keys[d.Key]
+		require.True(t, ok, "undeclared key: %s", d.Key)
+		assert.Equal(t, "node", k.For)
+		values[k.AttrName] = d.Value
+	}
+	assert.Equal(t, "INPT", values["neuron_type"])
+	assert.Equal(t, "SigmoidSteepenedActivation", values["activation_type"])
+	assert.Equal(t, "false", values[attrControl])
+
+	// check recurrent edge attributes
+	var edge *graphMLEdge
+	for i := range doc.Graph.Edges {
+		if doc.Graph.Edges[i].ID == "7-4" {
+			edge = &doc.Graph.Edges[i]
+		}
+	}
+	require.NotNil(t, edge, "recurrent edge not found")
+	assert.Equal(t, "7", edge.Source)
+	assert.Equal(t, "4", edge.Target)
+	values = make(map[string]string)
+	for _, d := range edge.Data {
+		k, ok := keys[d.Key]
+		require.True(t, ok, "undeclared key: %s", d.Key)
+		assert.Equal(t, "edge", k.For)
+		values[k.AttrName] = d.Value
+	}
+	assert.Equal(t, "-0.500000", values["weight"])
+	assert.Equal(t, "true", values["recurrent"])
+	assert.Equal(t, "false", values[attrTimeDelayed])
+	assert.Equal(t, "true", values[attrEnabled])
+	assert.Equal(t, "double", keys[keyIDByName(doc.Keys, "weight")].AttrType)
+}
+
+func TestWriteGraphML_Modular(t *testing.T) {
+	net := buildModularNetwork()
+
+	b := bytes.NewBufferString("")
+	err := WriteGraphML(b, net)
+	require.NoError(t, err, "failed to write GraphML")
+	assertWellFormedXML(t, b.Bytes())
+
+	doc := graphMLDocument{}
+	err = xml.Unmarshal(b.Bytes(), &doc)
+	require.NoError(t, err, "failed to decode GraphML")
+	assert.Len(t, doc.Graph.Nodes, len(net.AllNodes()))
+	assert.Contains(t, b.String(), ">MultiplyModuleActivation<")
+	controlKey := keyIDByName(doc.Keys, attrControl)
+	controls := 0
+	for _, node := range doc.Graph.Nodes {
+		for _, d := range node.Data {
+			if d.Key == controlKey && d.Value == "true" {
+				controls++
+			}
+		}
+	}
+	assert.Equal(t, len(net.ControlNodes()), controls)
+	// all links of the control node are included
+	assert.Len(t, doc.Graph.Edges, net.LinkCount())
+	assert.Equal(t, net.LinkCount(), strings.Count(b.String(), "<edge "))
+}
+
+func TestWriteGraphML_customActivator(t *testing.T) {
+	net, _ := buildCustomActivatorNetwork()
+
+	b := bytes.NewBufferString("")
+	err := WriteGraphML(b, net)
+	require.NoError(t, err, "failed to write GraphML")
+	assert.Contains(t, b.String(), ">SquareTestActivation<")
+}
+
+func TestWriteGraphML_Write_Error(t *testing.T) {
+	net := buildNetwork()
+
+	errWriter := ErrorWriter(1)
+	err := WriteGraphML(&errWriter, net)
+	assert.EqualError(t, err, alwaysErrorText)
+}
+
+func keyIDByName(keys []graphMLKey, name string) string {
+	for _, k := range keys {
+		if k.AttrName == name {
+			return k.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package formats
+
+import (
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/encoding"
+	"gonum.org/v1/gonum/graph/encoding/dot"
+	"gonum.org/v1/gonum/graph/iterator"
+	"io"
+	"math"
+	"strconv"
+)
+
+// WriteDOTSnapshot is to write provided network graph using the GraphViz DOT encoding with nodes colored by their
+// activation values recorded by the activation recorder at the given step. The positive activations are shaded by
+// blue and negative by red color, with saturation proportional to the activation magnitude clipped to one.
+func WriteDOTSnapshot(w io.Writer, n *network.Network, recorder *network.ActivationRecorder, step int) error {
+	activations, err := recorder.Activations(step)
+	if err != nil {
+		return err
+	}
+	opts := DefaultSVGOptions()
+	g := &snapshotGraph{
+		Network:       n,
+		activations:   activations,
+		positiveColor: opts.PositiveColor,
+		negativeColor: opts.NegativeColor,
+	}
+	data, err := dot.Marshal(g, n.Name, "", "")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// WriteSVGSnapshot is to render provided network graph into SVG with nodes colored by their activation values
+// recorded by the activation recorder at the given step. See WriteSVG and SVGOptions.NodeActivations for details.
+func WriteSVGSnapshot(w io.Writer, n *network.Network, recorder *network.ActivationRecorder, step int) error {
+	activations, err := recorder.Activations(step)
+	if err != nil {
+		return err
+	}
+	opts := DefaultSVGOptions()
+	opts.NodeActivations = activations
+	return WriteSVGWithOptions(w, n, opts)
+}
+
+// snapshotGraph is the network graph with nodes attributes extended by the activation values
+type snapshotGraph struct {
+	*network.Network
+	// the activations per node ID
+	activations map[int]float64
+	// the colors of positive and negative activations
+	positiveColor, negativeColor string
+}
+
+// Nodes returns all the nodes in the graph wrapped to provide the activation attributes
+func (g *snapshotGraph) Nodes() graph.Nodes {
+	all := g.AllNodes()
+	nodes := make([]graph.Node, len(all))
+	for i, node := range all {
+		nodes[i] = &snapshotNode{NNode: node, graph: g}
+	}
+	return iterator.NewOrderedNodes(nodes)
+}
+
+// snapshotNode is the network node with attributes extended by the activation value
+type snapshotNode struct {
+	*network.NNode
+	graph *snapshotGraph
+}
+
+// Attributes returns list of standard node attributes extended by the activation value and the fill color
+func (n *snapshotNode) Attributes() []encoding.Attribute {
+	attrs := n.NNode.Attributes()
+	if activation, ok := n.graph.activations[n.Id]; ok {
+		attrs = append(attrs,
+			encoding.Attribute{Key: "activation", Value: strconv.FormatFloat(activation, 'g', -1, 64)},
+			encoding.Attribute{Key: "style", Value: "filled"},
+			encoding.Attribute{Key: "fillcolor", Value: fmt.Sprintf("%q",
+				activationColor(activation, n.graph.positiveColor, n.graph.negativeColor))},
+		)
+	}
+	return attrs
+}
+
+// activationColor returns the color of the node with given activation value. The color is interpolated between white
+// and the positive or negative color depending on the activation sign, with saturation proportional to the activation
+// magnitude clipped to one.
+func activationColor(activation float64, positiveColor, negativeColor string) string {
+	color := positiveColor
+	if activation < 0 {
+		color = negativeColor
+	}
+	r, g, b, ok := parseHexColor(color)
+	if !ok || math.IsNaN(activation) {
+		return colorDefault
+	}
+	t := math.Min(math.Abs(activation), 1)
+	mix := func(c uint8) uint8 {
+		return uint8(math.Round(255 - t*(255-float64(c))))
+	}
+	return fmt.Sprintf("#%02X%02X%02X", mix(r), mix(g), mix(b))
+}
+
+// parseHexColor parses the color in the #RGB or #RRGGBB format
+func parseHexColor(color string) (r, g, b uint8, ok bool) {
+	if len(color) == 4 {
+		color = string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]})
+	}
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+}
@@ -0,0 +1,81 @@
+package formats
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"testing"
+)
+
+func recordActivations(t *testing.T, net *network.Network, steps int) *network.ActivationRecorder {
+	recorder := network.NewActivationRecorder()
+	net.SetActivationRecorder(recorder)
+	err := net.LoadSensors([]float64{0.5, -1.1})
+	require.NoError(t, err, "failed to load sensors")
+	_, err = net.ForwardSteps(steps)
+	require.NoError(t, err, "failed to activate")
+	return recorder
+}
+
+func TestWriteDOTSnapshot(t *testing.T) {
+	net := buildRecurrentNetwork()
+	net.Name = "TestNN"
+	recorder := recordActivations(t, net, 3)
+	activations, err := recorder.Activations(1)
+	require.NoError(t, err)
+
+	b := bytes.NewBufferString("")
+	err = WriteDOTSnapshot(b, net, recorder, 1)
+	require.NoError(t, err, "failed to write DOT snapshot")
+	dot := b.String()
+	assert.Contains(t, dot, "digraph TestNN")
+	assert.Contains(t, dot, "style=filled")
+	expectedColor := activationColor(activations[4], "#339FDC", "#EA1E53")
+	assert.Contains(t, dot, `fillcolor="`+expectedColor+`"`)
+}
+
+func TestWriteDOTSnapshot_errors(t *testing.T) {
+	net := buildNetwork()
+	recorder := recordActivations(t, net, 1)
+
+	err := WriteDOTSnapshot(bytes.NewBufferString(""), net, recorder, recorder.StepsCount())
+	assert.Error(t, err, "out of range step")
+
+	errWriter := ErrorWriter(1)
+	err = WriteDOTSnapshot(&errWriter, net, recorder, 0)
+	assert.EqualError(t, err, alwaysErrorText)
+}
+
+func TestWriteSVGSnapshot(t *testing.T) {
+	net := buildRecurrentNetwork()
+	recorder := recordActivations(t, net, 3)
+	activations, err := recorder.Activations(2)
+	require.NoError(t, err)
+
+	b := bytes.NewBufferString("")
+	err = WriteSVGSnapshot(b, net, recorder, 2)
+	require.NoError(t, err, "failed to write SVG snapshot")
+	assertWellFormedXML(t, b.Bytes())
+	svg := b.String()
+	opts := DefaultSVGOptions()
+	for _, node := range net.BaseNodes() {
+		color := activationColor(activations[node.Id], opts.PositiveColor, opts.NegativeColor)
+		assert.Contains(t, svg, `fill="`+color+`"`)
+	}
+	assert.Contains(t, svg, "activation: ")
+
+	err = WriteSVGSnapshot(b, net, recorder, -1)
+	assert.Error(t, err, "out of range step")
+}
+
+func TestActivationColor(t *testing.T) {
+	assert.Equal(t, "#FFFFFF", activationColor(0, "#339FDC", "#EA1E53"))
+	assert.Equal(t, "#339FDC", activationColor(1, "#339FDC", "#EA1E53"))
+	assert.Equal(t, "#339FDC", activationColor(5, "#339FDC", "#EA1E53"))
+	assert.Equal(t, "#EA1E53", activationColor(-1, "#339FDC", "#EA1E53"))
+	assert.Equal(t, "#F09090", activationColor(-0.5, "#339FDC", "#E02020"))
+	assert.Equal(t, "#AAAAAA", activationColor(2, "#AAA", "#EA1E53"))
+	assert.Equal(t, colorDefault, activationColor(1, "blue", "#EA1E53"))
+	assert.Equal(t, colorDefault, activationColor(1, "#GGGGGG", "#EA1E53"))
+}
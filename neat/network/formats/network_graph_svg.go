@@ -0,0 +1,308 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"io"
+	gomath "math"
+	"strings"
+)
+
+// SVGOptions is to hold options of the network graph rendering into SVG
+type SVGOptions struct {
+	// NodeRadius the radius of the circle enclosing node shape
+	NodeRadius float64
+	// ColumnSpacing the horizontal distance between layers of nodes
+	ColumnSpacing float64
+	// RowSpacing the vertical distance between nodes in the layer
+	RowSpacing float64
+	// MaxEdgeWidth the width of the edge with maximal absolute weight
+	MaxEdgeWidth float64
+	// PositiveColor the color of edges with positive weight
+	PositiveColor string
+	// NegativeColor the color of edges with negative weight
+	NegativeColor string
+	// NodeActivations the activation values of nodes per node ID to color nodes in the snapshot mode, where
+	// positive and negative activations are shaded by PositiveColor and NegativeColor respectively.
+	// If nil, the nodes are colored by their type.
+	NodeActivations map[int]float64
+}
+
+// DefaultSVGOptions returns the default options of the network graph rendering into SVG
+func DefaultSVGOptions() *SVGOptions {
+	return &SVGOptions{
+		NodeRadius:    16,
+		ColumnSpacing: 140,
+		RowSpacing:    60,
+		MaxEdgeWidth:  6,
+		PositiveColor: "#339FDC",
+		NegativeColor: "#EA1E53",
+	}
+}
+
+// WriteSVG is to render provided network graph into SVG using default options. The nodes are arranged into
+// layers: inputs at the left, outputs at the right, and hidden nodes in between by their activation depth. The edges
+// thickness is proportional to the weight magnitude and color depends on the weight sign. The recurrent edges
+// are drawn by dashed curves.
+func WriteSVG(w io.Writer, n *network.Network) error {
+	return WriteSVGWithOptions(w, n, DefaultSVGOptions())
+}
+
+// WriteSVGWithOptions is to render provided network graph into SVG using provided options.
+// See WriteSVG for details.
+func WriteSVGWithOptions(w io.Writer, n *network.Network, opts *SVGOptions) error {
+	if opts == nil {
+		opts = DefaultSVGOptions()
+	}
+	layout := newSVGLayout(n, opts)
+
+	b := bytes.NewBufferString("")
+	_, _ = fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n",
+		layout.width, layout.height, layout.width, layout.height)
+	_, _ = fmt.Fprintf(b, "<title>%s</title>\n", svgEscape(fmt.Sprintf("Network %s with id %d", n.Name, n.Id)))
+	_, _ = fmt.Fprintf(b, "<defs>\n")
+	for _, marker := range [][2]string{{"positive", opts.PositiveColor}, {"negative", opts.NegativeColor}} {
+		_, _ = fmt.Fprintf(b, `<marker id="arrow-%s" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" `+
+			`markerHeight="6" markerUnits="userSpaceOnUse" orient="auto"><path d="M 0 0 L 10 5 L 0 10 z" fill="%s"/></marker>`+"\n",
+			marker[0], svgEscape(marker[1]))
+	}
+	_, _ = fmt.Fprintf(b, "</defs>\n")
+
+	// edges are drawn below the nodes
+	_, _ = fmt.Fprintf(b, "<g class=\"edges\" fill=\"none\">\n")
+	for _, link := range layout.links {
+		writeSVGEdge(b, link, layout, opts)
+	}
+	_, _ = fmt.Fprintf(b, "</g>\n")
+
+	_, _ = fmt.Fprintf(b, "<g class=\"nodes\" font-family=\"sans-serif\" font-size=\"%.0f\" text-anchor=\"middle\">\n",
+		opts.NodeRadius*0.75)
+	for _, node := range layout.nodes {
+		writeSVGNode(b, node, layout.positions[node], layout.control[node], n.Activators(), opts)
+	}
+	_, _ = fmt.Fprintf(b, "</g>\n</svg>\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// svgPoint is the point on the SVG canvas
+type svgPoint struct {
+	x, y float64
+}
+
+// svgLayout is the layered layout of the network graph
+type svgLayout struct {
+	// all nodes in order of drawing
+	nodes []*network.NNode
+	// all links
+	links []*network.Link
+	// the nodes positions
+	positions map[*network.NNode]svgPoint
+	// the control nodes
+	control map[*network.NNode]bool
+	// the maximal absolute weight of links
+	maxWeight float64
+	// the size of canvas
+	width, height float64
+}
+
+func newSVGLayout(n *network.Network, opts *SVGOptions) *svgLayout {
+	layout := &svgLayout{
+		positions: make(map[*network.NNode]svgPoint),
+		control:   make(map[*network.NNode]bool),
+	}
+	// the control nodes feeding each module output node
+	modulesOf := make(map[*network.NNode][]*network.NNode)
+	for _, cn := range n.ControlNodes() {
+		layout.control[cn] = true
+		for _, l := range cn.Outgoing {
+			modulesOf[l.OutNode] = append(modulesOf[l.OutNode], cn)
+		}
+	}
+
+	// calculate depth of nodes as the longest path from sensors ignoring recurrent links
+	depths := make(map[*network.NNode]int)
+	visiting := make(map[*network.NNode]bool)
+	var depthOf func(node *network.NNode) int
+	depthOf = func(node *network.NNode) int {
+		if d, ok := depths[node]; ok {
+			return d
+		}
+		if visiting[node] {
+			// the back edge of cycle
+			return -1
+		}
+		if node.IsSensor() {
+			depths[node] = 0
+			return 0
+		}
+		visiting[node] = true
+		depth := 1
+		for _, l := range node.Incoming {
+			if l.IsRecurrent || l.IsTimeDelayed || l.InNode == node {
+				continue
+			}
+			if d := depthOf(l.InNode); d >= 0 && d+1 > depth {
+				depth = d + 1
+			}
+		}
+		for _, cn := range modulesOf[node] {
+			if d := depthOf(cn); d >= 0 && d+1 > depth {
+				depth = d + 1
+			}
+		}
+		delete(visiting, node)
+		depths[node] = depth
+		return depth
+	}
+
+	// arrange nodes into columns
+	layout.nodes = append(layout.nodes, n.BaseNodes()...)
+	layout.nodes = append(layout.nodes, n.ControlNodes()...)
+	maxColumn := 0
+	for _, node := range layout.nodes {
+		if d := depthOf(node); node.NeuronType != network.OutputNeuron && d > maxColumn {
+			maxColumn = d
+		}
+	}
+	outputsColumn := maxColumn + 1
+	columns := make([][]*network.NNode, outputsColumn+1)
+	for _, node := range layout.nodes {
+		column := depths[node]
+		if node.NeuronType == network.OutputNeuron {
+			column = outputsColumn
+		}
+		columns[column] = append(columns[column], node)
+	}
+
+	maxRows := 1
+	for _, column := range columns {
+		if len(column) > maxRows {
+			maxRows = len(column)
+		}
+	}
+	padding := opts.NodeRadius * 2
+	layout.width = 2*padding + float64(len(columns)-1)*opts.ColumnSpacing
+	layout.height = 2*padding + float64(maxRows-1)*opts.RowSpacing
+	for c, column := range columns {
+		// center nodes of the column vertically
+		offset := padding + float64(maxRows-len(column))*opts.RowSpacing/2
+		for r, node := range column {
+			layout.positions[node] = svgPoint{
+				x: padding + float64(c)*opts.ColumnSpacing,
+				y: offset + float64(r)*opts.RowSpacing,
+			}
+		}
+	}
+
+	// collect links
+	for _, node := range n.BaseNodes() {
+		layout.links = append(layout.links, node.Incoming...)
+	}
+	for _, cn := range n.ControlNodes() {
+		layout.links = append(layout.links, cn.Incoming...)
+		layout.links = append(layout.links, cn.Outgoing...)
+	}
+	for _, link := range layout.links {
+		layout.maxWeight = gomath.Max(layout.maxWeight, gomath.Abs(link.ConnectionWeight))
+	}
+	return layout
+}
+
+func writeSVGEdge(b *bytes.Buffer, link *network.Link, layout *svgLayout, opts *SVGOptions) {
+	from, to := layout.positions[link.InNode], layout.positions[link.OutNode]
+	color, marker := opts.PositiveColor, "positive"
+	if link.ConnectionWeight < 0 {
+		color, marker = opts.NegativeColor, "negative"
+	}
+	width := 1.0
+	if layout.maxWeight > 0 {
+		width = gomath.Max(1, opts.MaxEdgeWidth*gomath.Abs(link.ConnectionWeight)/layout.maxWeight)
+	}
+	dash := ""
+	if link.IsRecurrent || link.IsTimeDelayed {
+		dash = ` stroke-dasharray="6,4"`
+	}
+
+	r := opts.NodeRadius
+	var path string
+	if link.InNode == link.OutNode {
+		// self loop above the node
+		path = fmt.Sprintf("M %.2f %.2f C %.2f %.2f %.2f %.2f %.2f %.2f",
+			from.x-r/2, from.y-r, from.x-r*2, from.y-r*3, from.x+r*2, from.y-r*3, from.x+r/2, from.y-r)
+	} else {
+		dx, dy := to.x-from.x, to.y-from.y
+		length := gomath.Hypot(dx, dy)
+		ux, uy := dx/length, dy/length
+		start := svgPoint{x: from.x + ux*r, y: from.y + uy*r}
+		end := svgPoint{x: to.x - ux*r, y: to.y - uy*r}
+		if link.IsRecurrent || link.IsTimeDelayed || dx <= 0 {
+			// backward links are curved to not overlap with forward links
+			cx, cy := (start.x+end.x)/2-uy*length/4, (start.y+end.y)/2+ux*length/4
+			path = fmt.Sprintf("M %.2f %.2f Q %.2f %.2f %.2f %.2f", start.x, start.y, cx, cy, end.x, end.y)
+		} else {
+			path = fmt.Sprintf("M %.2f %.2f L %.2f %.2f", start.x, start.y, end.x, end.y)
+		}
+	}
+	_, _ = fmt.Fprintf(b, `<path d="%s" stroke="%s" stroke-width="%.2f"%s marker-end="url(#arrow-%s)">`+
+		`<title>%s</title></path>`+"\n", path, svgEscape(color), width, dash, marker,
+		svgEscape(fmt.Sprintf("%d -> %d, weight: %g", link.InNode.Id, link.OutNode.Id, link.ConnectionWeight)))
+}
+
+func writeSVGNode(b *bytes.Buffer, node *network.NNode, p svgPoint, control bool,
+	activators *math.NodeActivatorsFactory, opts *SVGOptions) {
+	r := opts.NodeRadius
+	fill, stroke := nodeBgColor(node, control), nodeBorderColor(node, control)
+	var shape string
+	switch nodeShape(node, control) {
+	case shapeInput:
+		shape = svgPolygon(p, r, 4, 0)
+	case shapeBias:
+		shape = svgPolygon(p, r, 5, -gomath.Pi/2)
+	case shapeHidden:
+		shape = svgPolygon(p, r, 6, 0)
+	case shapeControl:
+		shape = svgPolygon(p, r, 8, gomath.Pi/8)
+	case shapeOutput:
+		shape = fmt.Sprintf(`<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" rx="%.2f"`, p.x-r, p.y-r*0.8, 2*r, 1.6*r, r/3)
+	default:
+		shape = fmt.Sprintf(`<circle cx="%.2f" cy="%.2f" r="%.2f"`, p.x, p.y, r)
+	}
+	actName, err := activators.ActivationNameFromType(node.ActivationType)
+	if err != nil {
+		actName = "unknown"
+	}
+	kind := network.NeuronTypeName(node.NeuronType)
+	if control {
+		kind = "CONTROL"
+	}
+	title := fmt.Sprintf("%s %d, %s", kind, node.Id, actName)
+	if activation, ok := opts.NodeActivations[node.Id]; ok {
+		fill = activationColor(activation, opts.PositiveColor, opts.NegativeColor)
+		title = fmt.Sprintf("%s, activation: %g", title, activation)
+	}
+	_, _ = fmt.Fprintf(b, `<g id="node-%d">%s fill="%s" stroke="%s" stroke-width="2"/>`, node.Id, shape, svgEscape(fill), stroke)
+	_, _ = fmt.Fprintf(b, `<text x="%.2f" y="%.2f" dy="0.35em">%d</text>`, p.x, p.y, node.Id)
+	_, _ = fmt.Fprintf(b, "<title>%s</title></g>\n", svgEscape(title))
+}
+
+// svgPolygon returns the opening of SVG polygon element with given number of sides inscribed into circle
+func svgPolygon(center svgPoint, r float64, sides int, rotation float64) string {
+	points := make([]string, sides)
+	for i := 0; i < sides; i++ {
+		angle := rotation + 2*gomath.Pi*float64(i)/float64(sides)
+		points[i] = fmt.Sprintf("%.2f,%.2f", center.x+r*gomath.Cos(angle), center.y+r*gomath.Sin(angle))
+	}
+	return fmt.Sprintf(`<polygon points="%s"`, strings.Join(points, " "))
+}
+
+// svgEscape escapes the text to be embedded into SVG
+func svgEscape(text string) string {
+	b := bytes.NewBufferString("")
+	_ = xml.EscapeText(b, []byte(text))
+	return b.String()
+}
@@ -0,0 +1,131 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"io"
+	"strings"
+	"testing"
+)
+
+// assertWellFormedXML checks that provided data is well-formed XML document
+func assertWellFormedXML(t *testing.T, data []byte) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "malformed XML")
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	net := buildNetwork()
+	net.Name = "Test <NN>"
+	// negative weight
+	net.BaseNodes()[6].Incoming[1].ConnectionWeight = -4.5
+
+	b := bytes.NewBufferString("")
+	err := WriteSVG(b, net)
+	require.NoError(t, err, "failed to write SVG")
+	assertWellFormedXML(t, b.Bytes())
+
+	svg := b.String()
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg"`))
+	assert.Contains(t, svg, "<title>Network Test &lt;NN&gt; with id 0</title>")
+	for _, node := range net.BaseNodes() {
+		assert.Contains(t, svg, fmt.Sprintf(`<g id="node-%d">`, node.Id))
+	}
+	assert.Equal(t, net.LinkCount(), strings.Count(svg, "marker-end="))
+	assert.Contains(t, svg, `stroke="#EA1E53"`, "negative edge color")
+	assert.Contains(t, svg, `stroke-width="6.00"`, "maximal edge width")
+	assert.Contains(t, svg, "<title>6 -&gt; 7, weight: -4.5</title>")
+	assert.NotContains(t, svg, "stroke-dasharray")
+}
+
+func TestWriteSVG_layout(t *testing.T) {
+	net := buildNetwork()
+	opts := DefaultSVGOptions()
+	layout := newSVGLayout(net, opts)
+
+	column := func(id int) float64 {
+		for node, p := range layout.positions {
+			if node.Id == id {
+				return (p.x - opts.NodeRadius*2) / opts.ColumnSpacing
+			}
+		}
+		t.Fatalf("node not found: %d", id)
+		return -1
+	}
+	// inputs at the left
+	assert.Equal(t, 0.0, column(1))
+	assert.Equal(t, 0.0, column(2))
+	assert.Equal(t, 0.0, column(3))
+	// hidden by depth
+	assert.Equal(t, 1.0, column(4))
+	assert.Equal(t, 1.0, column(5))
+	assert.Equal(t, 2.0, column(6))
+	// outputs at the right
+	assert.Equal(t, 3.0, column(7))
+	assert.Equal(t, 3.0, column(8))
+
+	assert.Equal(t, opts.NodeRadius*4+3*opts.ColumnSpacing, layout.width)
+	assert.Equal(t, opts.NodeRadius*4+2*opts.RowSpacing, layout.height)
+}
+
+func TestWriteSVG_modularRecurrent(t *testing.T) {
+	net := buildModularNetwork()
+	allNodes := net.BaseNodes()
+	allNodes[3].ConnectFrom(allNodes[6], 0.5).IsRecurrent = true
+	allNodes[4].ConnectFrom(allNodes[4], 0.5).IsRecurrent = true
+
+	b := bytes.NewBufferString("")
+	err := WriteSVGWithOptions(b, net, nil)
+	require.NoError(t, err, "failed to write SVG")
+	assertWellFormedXML(t, b.Bytes())
+
+	svg := b.String()
+	assert.Contains(t, svg, `<g id="node-6"><polygon`, "control node")
+	assert.Contains(t, svg, "<title>CONTROL 6, MultiplyModuleActivation</title>")
+	assert.Contains(t, svg, "<title>BIAS 3, SigmoidSteepenedActivation</title>")
+	assert.Equal(t, 2, strings.Count(svg, "stroke-dasharray"), "recurrent links must be dashed")
+	assert.Equal(t, net.LinkCount(), strings.Count(svg, "marker-end="))
+
+	layout := newSVGLayout(net, DefaultSVGOptions())
+	var control, moduleOut, output svgPoint
+	for node, p := range layout.positions {
+		switch node.Id {
+		case 6:
+			control = p
+		case 7:
+			moduleOut = p
+		case 8:
+			output = p
+		}
+	}
+	assert.Less(t, control.x, moduleOut.x)
+	assert.Less(t, moduleOut.x, output.x)
+}
+
+func TestWriteSVG_noHidden(t *testing.T) {
+	allNodes := []*network.NNode{
+		network.NewNNode(1, network.InputNeuron),
+		network.NewNNode(2, network.OutputNeuron),
+	}
+	allNodes[1].ConnectFrom(allNodes[0], 1.0)
+	net := network.NewNetwork(allNodes[0:1], allNodes[1:2], allNodes, 1)
+
+	layout := newSVGLayout(net, DefaultSVGOptions())
+	assert.Equal(t, layout.positions[allNodes[0]].x+DefaultSVGOptions().ColumnSpacing, layout.positions[allNodes[1]].x)
+}
+
+func TestWriteSVG_Write_Error(t *testing.T) {
+	errWriter := ErrorWriter(1)
+	err := WriteSVG(&errWriter, buildNetwork())
+	assert.EqualError(t, err, alwaysErrorText)
+}
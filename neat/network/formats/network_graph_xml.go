@@ -0,0 +1,139 @@
+package formats
+
+import (
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+	"gonum.org/v1/gonum/graph/encoding"
+	"strconv"
+)
+
+const (
+	// attrControl is the node attribute indicating whether node is a control node (module)
+	attrControl = "control"
+	// attrEnabled is the edge attribute indicating whether link is enabled
+	attrEnabled = "enabled"
+	// attrTimeDelayed is the edge attribute indicating whether link is time delayed
+	attrTimeDelayed = "time_delayed"
+)
+
+// graphAttributeTypes The data types of the known graph attributes. The attributes not listed here are of string type.
+// The type names are shared by the GraphML and GEXF formats.
+var graphAttributeTypes = map[string]string{
+	"weight":        "double",
+	"recurrent":     "boolean",
+	attrControl:     "boolean",
+	attrEnabled:     "boolean",
+	attrTimeDelayed: "boolean",
+	"delay":         "long",
+}
+
+// graphAttributeKey is the declaration of the attribute used by graph elements
+type graphAttributeKey struct {
+	// The ID of the attribute
+	id string
+	// The name of the attribute
+	name string
+	// The data type of the attribute
+	attrType string
+}
+
+// graphElement is the node or edge of the network graph with its attributes
+type graphElement struct {
+	// The ID of the element
+	id string
+	// The source node ID of the edge
+	source string
+	// The target node ID of the edge
+	target string
+	// The weight of the edge
+	weight float64
+	// The attributes of the element
+	attributes []encoding.Attribute
+}
+
+// graphElements holds all nodes and edges of the network graph along with declarations of their attributes
+type graphElements struct {
+	nodes    []graphElement
+	edges    []graphElement
+	nodeKeys []graphAttributeKey
+	edgeKeys []graphAttributeKey
+}
+
+// collectGraphElements collects all nodes and edges of the network graph including control nodes and their links.
+// The attributes of the elements are taken from network.NNode.Attributes and network.Link.Attributes and extended
+// with the control, enabled, and time_delayed flags. The phenotype network is built only from the expressed genes,
+// thus all its links are enabled.
+func collectGraphElements(n *network.Network) *graphElements {
+	elements := &graphElements{}
+	nodeKeys, edgeKeys := make(map[string]bool), make(map[string]bool)
+	addNode := func(node *network.NNode, control bool) {
+		attrs := append(node.Attributes(), encoding.Attribute{Key: attrControl, Value: strconv.FormatBool(control)})
+		elements.nodeKeys = appendGraphAttributeKeys(elements.nodeKeys, nodeKeys, "n", attrs)
+		elements.nodes = append(elements.nodes, graphElement{
+			id:         strconv.Itoa(node.Id),
+			attributes: attrs,
+		})
+	}
+	addEdge := func(link *network.Link) {
+		attrs := append(link.Attributes(),
+			encoding.Attribute{Key: attrTimeDelayed, Value: strconv.FormatBool(link.IsTimeDelayed)},
+			encoding.Attribute{Key: attrEnabled, Value: strconv.FormatBool(true)})
+		elements.edgeKeys = appendGraphAttributeKeys(elements.edgeKeys, edgeKeys, "e", attrs)
+		elements.edges = append(elements.edges, graphElement{
+			id:         link.IDString(),
+			source:     strconv.Itoa(link.InNode.Id),
+			target:     strconv.Itoa(link.OutNode.Id),
+			weight:     link.ConnectionWeight,
+			attributes: attrs,
+		})
+	}
+
+	// add all ordinary nodes with their incoming links
+	for _, node := range n.BaseNodes() {
+		addNode(node, false)
+		for _, e := range node.Incoming {
+			addEdge(e)
+		}
+	}
+	// add all control nodes with their incoming and outgoing links
+	for _, node := range n.ControlNodes() {
+		addNode(node, true)
+		for _, e := range node.Incoming {
+			addEdge(e)
+		}
+		for _, e := range node.Outgoing {
+			addEdge(e)
+		}
+	}
+	return elements
+}
+
+// keyID returns the ID of the attribute key with given name or empty string if not found
+func keyID(keys []graphAttributeKey, name string) string {
+	for _, k := range keys {
+		if k.name == name {
+			return k.id
+		}
+	}
+	return ""
+}
+
+// appendGraphAttributeKeys appends declarations of the attributes not seen before to the provided list of keys
+func appendGraphAttributeKeys(keys []graphAttributeKey, seen map[string]bool, prefix string, attrs []encoding.Attribute) []graphAttributeKey {
+	for _, attr := range attrs {
+		if seen[attr.Key] {
+			continue
+		}
+		seen[attr.Key] = true
+		attrType, ok := graphAttributeTypes[attr.Key]
+		if !ok {
+			attrType = "string"
+		}
+		keys = append(keys, graphAttributeKey{
+			id:       fmt.Sprintf("%s%d", prefix, len(keys)),
+			name:     attr.Key,
+			attrType: attrType,
+		})
+	}
+	return keys
+}
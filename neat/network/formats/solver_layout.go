@@ -0,0 +1,185 @@
+package formats
+
+import (
+	"fmt"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
+	"github.com/yaricom/goNEAT/v3/neat/network"
+)
+
+// solverConnection is the incoming connection of the neuron in the solver layout
+type solverConnection struct {
+	// The index of source neuron
+	source int
+	// The weight of connection
+	weight float64
+}
+
+// solverNeuron is the neuron in the solver layout
+type solverNeuron struct {
+	// The original network node
+	node *network.NNode
+	// The incoming connections from non-bias neurons, the weights are scaled by the response of the neuron
+	incoming []solverConnection
+	// The bias of the neuron and the sum of weights of connections from bias neurons scaled by the response
+	bias float64
+}
+
+// solverModule is the control node (module) in the solver layout
+type solverModule struct {
+	// The original control node
+	node *network.NNode
+	// The indexes of the input neurons
+	inputs []int
+	// The indexes of the output neurons
+	outputs []int
+}
+
+// solverLayout is the layout of the network neurons matching the network.FastModularNetworkSolver, i.e. neurons are
+// ordered as following: bias, input, output, and hidden. It's used by the source code generators to produce code
+// which has the same activation semantics as the fast network solver.
+type solverLayout struct {
+	// The neurons in the layout order
+	neurons []*solverNeuron
+	// The control nodes
+	modules []*solverModule
+	// The number of bias neurons
+	biasCount int
+	// The number of input neurons
+	inputCount int
+	// The number of output neurons
+	outputCount int
+	// The factory of activation functions of the network
+	activators *neatmath.NodeActivatorsFactory
+}
+
+// sensorCount returns the total number of sensors (bias + input), which is also the index of the first output neuron
+func (l *solverLayout) sensorCount() int {
+	return l.biasCount + l.inputCount
+}
+
+// activationTypes returns the list of unique activation types used by neurons and modules of the layout in order of
+// their first appearance
+func (l *solverLayout) activationTypes() []neatmath.NodeActivationType {
+	seen := make(map[neatmath.NodeActivationType]bool)
+	types := make([]neatmath.NodeActivationType, 0)
+	add := func(t neatmath.NodeActivationType) {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	for _, n := range l.neurons[l.sensorCount():] {
+		add(n.node.ActivationType)
+	}
+	for _, m := range l.modules {
+		add(m.node.ActivationType)
+	}
+	return types
+}
+
+// parametricShapeParams returns the shape parameters of the parametric activation function resolved from the auxiliary
+// parameters of the node in the same way as by the activators of the neat/math package. Returns nil if activation type
+// is not parametric.
+func parametricShapeParams(aType neatmath.NodeActivationType, auxParams []float64) []float64 {
+	switch aType {
+	case neatmath.ParametricLeakyReLUActivation:
+		return []float64{neatmath.AuxParamInRange(auxParams, 0,
+			neatmath.ParametricLeakyReLUMinSlope, neatmath.ParametricLeakyReLUMaxSlope, neatmath.DefaultLeakyReLUSlope)}
+	case neatmath.PReLUActivation:
+		return []float64{neatmath.AuxParamInRange(auxParams, 0,
+			neatmath.PReLUMinSlope, neatmath.PReLUMaxSlope, neatmath.DefaultPReLUSlope)}
+	case neatmath.ParametricELUActivation:
+		return []float64{neatmath.AuxParamInRange(auxParams, 0,
+			neatmath.ParametricELUMinAlpha, neatmath.ParametricELUMaxAlpha, neatmath.DefaultELUAlpha)}
+	case neatmath.ParametricGaussianActivation:
+		return []float64{neatmath.AuxParamInRange(auxParams, 0,
+			neatmath.ParametricGaussianMinWidth, neatmath.ParametricGaussianMaxWidth, neatmath.DefaultGaussianWidth)}
+	case neatmath.ParametricSigmoidActivation:
+		return []float64{
+			neatmath.AuxParamInRange(auxParams, 0,
+				neatmath.ParametricSigmoidMinSlope, neatmath.ParametricSigmoidMaxSlope, neatmath.DefaultSigmoidSlope),
+			neatmath.AuxParamInRange(auxParams, 1,
+				neatmath.ParametricSigmoidMinShift, neatmath.ParametricSigmoidMaxShift, neatmath.DefaultSigmoidShift),
+		}
+	}
+	return nil
+}
+
+// parametricArgNames the names of the arguments of generated parametric activation functions per shape parameter
+var parametricArgNames = []string{"a", "b"}
+
+// newSolverLayout creates the solver layout of the provided network
+func newSolverLayout(n *network.Network) (*solverLayout, error) {
+	biasList, inList, hiddenList := make([]*network.NNode, 0), make([]*network.NNode, 0), make([]*network.NNode, 0)
+	for _, ne := range n.BaseNodes() {
+		switch ne.NeuronType {
+		case network.BiasNeuron:
+			biasList = append(biasList, ne)
+		case network.InputNeuron:
+			inList = append(inList, ne)
+		case network.HiddenNeuron:
+			hiddenList = append(hiddenList, ne)
+		case network.MemoryNeuron:
+			return nil, fmt.Errorf("memory cell neuron is not supported by the source code generators: %d", ne.Id)
+		case network.ModulatoryNeuron:
+			return nil, fmt.Errorf("modulatory neuron is not supported by the source code generators: %d", ne.Id)
+		}
+	}
+	layout := &solverLayout{
+		biasCount:   len(biasList),
+		inputCount:  len(inList),
+		outputCount: len(n.Outputs),
+		activators:  n.Activators(),
+	}
+
+	lookup := make(map[int]int)
+	for _, list := range [][]*network.NNode{biasList, inList, n.Outputs, hiddenList} {
+		for _, ne := range list {
+			lookup[ne.Id] = len(layout.neurons)
+			layout.neurons = append(layout.neurons, &solverNeuron{node: ne})
+		}
+	}
+	for _, neuron := range layout.neurons {
+		response := neuron.node.ActivationResponse()
+		if !neuron.node.IsSensor() {
+			neuron.bias = neuron.node.Bias
+		}
+		for _, in := range neuron.node.Incoming {
+			if in.Delay > 0 {
+				return nil, fmt.Errorf("time-delayed link is not supported by the source code generators: %s",
+					in.IDString())
+			}
+			source, ok := lookup[in.InNode.Id]
+			if !ok {
+				return nil, fmt.Errorf("failed to lookup for source neuron with id: %d", in.InNode.Id)
+			}
+			if in.InNode.NeuronType == network.BiasNeuron {
+				neuron.bias += in.ConnectionWeight * response
+			} else {
+				neuron.incoming = append(neuron.incoming, solverConnection{source: source, weight: in.ConnectionWeight * response})
+			}
+		}
+	}
+
+	for _, cn := range n.ControlNodes() {
+		module := &solverModule{node: cn}
+		for _, in := range cn.Incoming {
+			index, ok := lookup[in.InNode.Id]
+			if !ok {
+				return nil, fmt.Errorf("failed to lookup for input neuron with id: %d at control neuron: %d",
+					in.InNode.Id, cn.Id)
+			}
+			module.inputs = append(module.inputs, index)
+		}
+		for _, out := range cn.Outgoing {
+			index, ok := lookup[out.OutNode.Id]
+			if !ok {
+				return nil, fmt.Errorf("failed to lookup for output neuron with id: %d at control neuron: %d",
+					out.OutNode.Id, cn.Id)
+			}
+			module.outputs = append(module.outputs, index)
+		}
+		layout.modules = append(layout.modules, module)
+	}
+	return layout, nil
+}
@@ -0,0 +1,442 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
+)
+
+// ErrNetworkIsRecurrent The error to be raised when network with recurrent or time delayed links (cycles) can not be
+// processed by the network solver which requires acyclic network graph
+var ErrNetworkIsRecurrent = errors.New("network has recurrent connections and can not be compiled into layers")
+
+// LayeredNeuron The descriptor of the neuron evaluated by the layered network solver
+type LayeredNeuron struct {
+	// The index of the neuron signal
+	Index int
+	// The activation function of the neuron
+	ActivationType neatmath.NodeActivationType
+	// The auxiliary parameters of the activation function
+	Params []float64
+	// The indexes of the source neurons of the incoming connections
+	SourceIndexes []int
+	// The weights of the incoming connections, must be in the same order as SourceIndexes
+	Weights []float64
+	// The bias added to the net input of the neuron
+	Bias float64
+	// The response (gain) of the neuron which scales the weighted sum of incoming signals
+	Response float64
+}
+
+// netInput Returns the net input of the neuron from the weighted sum of its incoming signals
+func (n *LayeredNeuron) netInput(sum float64) float64 {
+	return n.Bias + n.Response*sum
+}
+
+// NetworkLayer The layer of the layered network solver. All neurons and modules of the layer depend only on the signals
+// of the previous layers and can be evaluated in any order.
+type NetworkLayer struct {
+	// The neurons of this layer
+	Neurons []*LayeredNeuron
+	// The control nodes (modules) of this layer
+	Modules []*FastControlNode
+	// The auxiliary parameters of the modules activation functions, must be in the same order as Modules
+	ModulesParams [][]float64
+}
+
+// LayeredNetworkSolver is the network solver implementation for acyclic (feed-forward) networks. The network graph
+// compiled into topologically sorted layers which allows to calculate outputs in a single pass without
+// repeating activation waves by the depth of the network and flushing the network state between samples.
+type LayeredNetworkSolver struct {
+	// A network id
+	Id int
+	// Is a name of this network
+	Name string
+
+	// The current activation values per each neuron
+	neuronSignals []float64
+	// The indexes of the input neurons
+	inputIndexes []int
+	// The indexes of the bias neurons
+	biasIndexes []int
+	// The indexes of the output neurons
+	outputIndexes []int
+	// The topologically sorted layers to evaluate
+	layers []*NetworkLayer
+	// The factory of activation functions
+	activators *neatmath.NodeActivatorsFactory
+
+	// The number of links
+	linkCount int
+	// The number of control nodes
+	modulesCount int
+}
+
+// LayeredNetworkSolver Creates the layered network solver for this network. Returns ErrNetworkIsRecurrent if network
+// has recurrent or time delayed links, cycles in its graph, or memory cell neurons, in this case FastNetworkSolver
+// should be used instead. The modulatory neurons are not supported, because the weights of the compiled layers
+// are immutable. The output modules, which decode the values of their input neurons in place, are evaluated
+// at the layer following the layers of these neurons.
+func (n *Network) LayeredNetworkSolver() (*LayeredNetworkSolver, error) {
+	// assign signal indexes
+	neuronLookup := make(map[*NNode]int)
+	inputIndexes, biasIndexes := make([]int, 0), make([]int, 0)
+	for i, node := range n.allNodes {
+		neuronLookup[node] = i
+		switch node.NeuronType {
+		case InputNeuron:
+			inputIndexes = append(inputIndexes, i)
+		case BiasNeuron:
+			biasIndexes = append(biasIndexes, i)
+		case MemoryNeuron:
+			// the memory cell holds its state between activations similar to recurrent link
+			return nil, ErrNetworkIsRecurrent
+		case ModulatoryNeuron:
+			return nil, errors.New("layered network solver doesn't support modulatory neurons")
+		}
+	}
+	outputIndexes := make([]int, len(n.Outputs))
+	for i, node := range n.Outputs {
+		if index, ok := neuronLookup[node]; ok {
+			outputIndexes[i] = index
+		} else {
+			return nil, fmt.Errorf("failed to lookup for output neuron with id: %d", node.Id)
+		}
+	}
+
+	// the outputs of the modules are set by the control nodes, the output modules decode their inputs in place
+	moduleOf, controls, inPlace := make(map[*NNode]*NNode), make(map[*NNode]bool), make(map[*NNode]bool)
+	for _, cn := range n.controlNodes {
+		controls[cn] = true
+		for _, l := range cn.Outgoing {
+			moduleOf[l.OutNode] = cn
+		}
+		for _, l := range cn.Incoming {
+			if moduleOf[l.InNode] == cn {
+				inPlace[l.InNode] = true
+			}
+		}
+	}
+
+	c := layersCompiler{
+		moduleOf:     moduleOf,
+		controls:     controls,
+		inPlace:      inPlace,
+		layers:       make(map[*NNode]int),
+		neuronLayers: make(map[*NNode]int),
+		visiting:     make(map[*NNode]bool),
+	}
+	for _, node := range n.allNodes {
+		if _, err := c.layerOf(node); err != nil {
+			return nil, err
+		}
+	}
+	for _, cn := range n.controlNodes {
+		if _, err := c.layerOf(cn); err != nil {
+			return nil, err
+		}
+	}
+
+	// build layers
+	layers := make([]*NetworkLayer, c.depth)
+	for i := range layers {
+		layers[i] = &NetworkLayer{}
+	}
+	linkCount := 0
+	for _, node := range n.allNodes {
+		layer := c.layers[node]
+		if inPlace[node] {
+			// the neuron is evaluated before its value is replaced by the output module
+			layer = c.neuronLayers[node]
+		} else if moduleOf[node] != nil {
+			// outputs of the modules are not evaluated
+			continue
+		}
+		if layer <= 0 {
+			// sensors and inactive neurons are not evaluated
+			continue
+		}
+		neuron := &LayeredNeuron{
+			Index:          neuronLookup[node],
+			ActivationType: node.ActivationType,
+			Params:         node.Params,
+			SourceIndexes:  make([]int, 0, len(node.Incoming)),
+			Weights:        make([]float64, 0, len(node.Incoming)),
+			Bias:           node.Bias,
+			Response:       node.ActivationResponse(),
+		}
+		for _, l := range node.Incoming {
+			if c.layers[l.InNode] < 0 {
+				// inactive source always produces zero signal
+				continue
+			}
+			neuron.SourceIndexes = append(neuron.SourceIndexes, neuronLookup[l.InNode])
+			neuron.Weights = append(neuron.Weights, l.ConnectionWeight)
+		}
+		linkCount += len(node.Incoming)
+		layers[layer-1].Neurons = append(layers[layer-1].Neurons, neuron)
+	}
+	for _, cn := range n.controlNodes {
+		module, err := fastControlNode(cn, neuronLookup)
+		if err != nil {
+			return nil, err
+		}
+		linkCount += len(cn.Incoming) + len(cn.Outgoing)
+		layer := layers[c.layers[cn]-1]
+		layer.Modules = append(layer.Modules, module)
+		layer.ModulesParams = append(layer.ModulesParams, cn.Params)
+	}
+
+	solver := &LayeredNetworkSolver{
+		Id:            n.Id,
+		Name:          n.Name,
+		neuronSignals: make([]float64, len(n.allNodes)),
+		inputIndexes:  inputIndexes,
+		biasIndexes:   biasIndexes,
+		outputIndexes: outputIndexes,
+		layers:        layers,
+		activators:    n.Activators(),
+		linkCount:     linkCount,
+		modulesCount:  len(n.controlNodes),
+	}
+	_, _ = solver.Flush()
+	return solver, nil
+}
+
+// fastControlNode creates descriptor of the control node with indexes of its IO neurons
+func fastControlNode(cn *NNode, neuronLookup map[*NNode]int) (*FastControlNode, error) {
+	inputs := make([]int, len(cn.Incoming))
+	for i, in := range cn.Incoming {
+		if inIndex, ok := neuronLookup[in.InNode]; ok {
+			inputs[i] = inIndex
+		} else {
+			return nil, fmt.Errorf("failed to lookup for input neuron with id: %d at control neuron: %d",
+				in.InNode.Id, cn.Id)
+		}
+	}
+	outputs := make([]int, len(cn.Outgoing))
+	for i, out := range cn.Outgoing {
+		if outIndex, ok := neuronLookup[out.OutNode]; ok {
+			outputs[i] = outIndex
+		} else {
+			return nil, fmt.Errorf("failed to lookup for output neuron with id: %d at control neuron: %d",
+				out.OutNode.Id, cn.Id)
+		}
+	}
+	return &FastControlNode{InputIndexes: inputs, OutputIndexes: outputs, ActivationType: cn.ActivationType}, nil
+}
+
+// layersCompiler assigns the layer indexes to the network nodes by depth first search
+type layersCompiler struct {
+	// The map of the module output neurons to their control nodes
+	moduleOf map[*NNode]*NNode
+	// The set of the control nodes
+	controls map[*NNode]bool
+	// The set of the neurons which values are decoded in place by the output modules
+	inPlace map[*NNode]bool
+	// The layer index per node, the sensors have layer 0 and the neurons not activated by the network have layer -1
+	layers map[*NNode]int
+	// The layer index per neuron decoded in place by the output module, i.e., the layer where neuron is evaluated
+	// before the module replaces its value
+	neuronLayers map[*NNode]int
+	// The nodes currently being visited to detect cycles
+	visiting map[*NNode]bool
+	// The number of evaluated layers
+	depth int
+}
+
+// layerOf returns the layer of the given node calculating it if needed
+func (c *layersCompiler) layerOf(node *NNode) (int, error) {
+	if layer, ok := c.layers[node]; ok {
+		return layer, nil
+	}
+	if c.visiting[node] {
+		return 0, ErrNetworkIsRecurrent
+	}
+	if node.IsSensor() {
+		c.layers[node] = 0
+		return 0, nil
+	}
+	c.visiting[node] = true
+	defer delete(c.visiting, node)
+
+	if cn, ok := c.moduleOf[node]; ok {
+		// the outputs of the module are available at the same layer as the module
+		layer, err := c.layerOf(cn)
+		if err != nil {
+			return 0, err
+		}
+		c.layers[node] = layer
+		return layer, nil
+	}
+
+	// the neuron is activated only if it has active inputs, while the modules are always activated
+	layer := -1
+	if c.controls[node] {
+		layer = 1
+	}
+	layer, err := c.incomingLayer(node, layer)
+	if err != nil {
+		return 0, err
+	}
+	c.layers[node] = layer
+	return layer, nil
+}
+
+// neuronLayerOf returns the layer where the neuron decoded in place by the output module is evaluated, i.e., the layer
+// preceding the layer of the module
+func (c *layersCompiler) neuronLayerOf(node *NNode) (int, error) {
+	if layer, ok := c.neuronLayers[node]; ok {
+		return layer, nil
+	}
+	layer, err := c.incomingLayer(node, -1)
+	if err != nil {
+		return 0, err
+	}
+	c.neuronLayers[node] = layer
+	return layer, nil
+}
+
+// incomingLayer returns the layer of the node following the layers of its incoming links, starting from the given one
+func (c *layersCompiler) incomingLayer(node *NNode, layer int) (int, error) {
+	for _, l := range node.Incoming {
+		if l.IsRecurrent || l.IsTimeDelayed || l.Delay > 0 {
+			return 0, ErrNetworkIsRecurrent
+		}
+		var inLayer int
+		var err error
+		if c.controls[node] && c.inPlace[l.InNode] && c.moduleOf[l.InNode] == node {
+			// the module takes the value of the neuron before replacing it
+			inLayer, err = c.neuronLayerOf(l.InNode)
+		} else {
+			inLayer, err = c.layerOf(l.InNode)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if inLayer >= 0 && inLayer+1 > layer {
+			layer = inLayer + 1
+		}
+	}
+	if layer > c.depth {
+		c.depth = layer
+	}
+	return layer, nil
+}
+
+// ForwardSteps Propagates activation wave through all network layers. The single step is enough to calculate outputs
+// of the acyclic network, thus the network is evaluated only once regardless of the number of steps.
+// Returns true if activation wave passed from all inputs to the outputs.
+func (s *LayeredNetworkSolver) ForwardSteps(steps int) (bool, error) {
+	if steps == 0 {
+		return false, ErrZeroActivationStepsRequested
+	}
+	return s.Evaluate()
+}
+
+// RecursiveSteps Propagates activation wave through all network layers.
+// Returns true if activation wave passed from all inputs to the outputs.
+func (s *LayeredNetworkSolver) RecursiveSteps() (bool, error) {
+	return s.Evaluate()
+}
+
+// Relax Propagates activation wave through all network layers. The acyclic network is always relaxed after single pass,
+// thus maxSteps and maxAllowedSignalDelta are ignored.
+func (s *LayeredNetworkSolver) Relax(_ int, _ float64) (bool, error) {
+	return s.Evaluate()
+}
+
+// Evaluate Calculates signals of all neurons layer by layer in a single pass.
+// Returns true if activation wave passed from all inputs to the outputs.
+func (s *LayeredNetworkSolver) Evaluate() (bool, error) {
+	var err error
+	for _, layer := range s.layers {
+		for _, neuron := range layer.Neurons {
+			sum := 0.0
+			for i, source := range neuron.SourceIndexes {
+				sum += s.neuronSignals[source] * neuron.Weights[i]
+			}
+			if s.neuronSignals[neuron.Index], err = s.activators.ActivateByType(
+				neuron.netInput(sum), neuron.Params, neuron.ActivationType); err != nil {
+				return false, err
+			}
+		}
+		for i, module := range layer.Modules {
+			inputs := make([]float64, len(module.InputIndexes))
+			for j, inIndex := range module.InputIndexes {
+				inputs[j] = s.neuronSignals[inIndex]
+			}
+			outputs, err := s.activators.ActivateModuleByType(inputs, layer.ModulesParams[i], module.ActivationType)
+			if err != nil {
+				return false, err
+			}
+			if len(outputs) != len(module.OutputIndexes) {
+				return false, fmt.Errorf(
+					"number of output parameters [%d] returned by module activator doesn't match "+
+						"the number of output neurons of the module [%d]", len(outputs), len(module.OutputIndexes))
+			}
+			for j, outIndex := range module.OutputIndexes {
+				s.neuronSignals[outIndex] = outputs[j]
+			}
+		}
+	}
+	return true, nil
+}
+
+// Flush Flushes network state by removing all current activations. Returns true if network flushed successfully or
+// false in case of error.
+func (s *LayeredNetworkSolver) Flush() (bool, error) {
+	for i := range s.neuronSignals {
+		s.neuronSignals[i] = 0.0
+	}
+	for _, index := range s.biasIndexes {
+		s.neuronSignals[index] = 1.0 // BIAS neuron signal
+	}
+	return true, nil
+}
+
+// LoadSensors Set sensors values to the input nodes of the network
+func (s *LayeredNetworkSolver) LoadSensors(inputs []float64) error {
+	if len(inputs) != len(s.inputIndexes) {
+		return ErrNetUnsupportedSensorsArraySize
+	}
+	for i, index := range s.inputIndexes {
+		s.neuronSignals[index] = inputs[i]
+	}
+	return nil
+}
+
+// ReadOutputs Read output values from the output nodes of the network
+func (s *LayeredNetworkSolver) ReadOutputs() []float64 {
+	outs := make([]float64, len(s.outputIndexes))
+	for i, index := range s.outputIndexes {
+		outs[i] = s.neuronSignals[index]
+	}
+	return outs
+}
+
+// NodeCount Returns the total number of neural units in the network
+func (s *LayeredNetworkSolver) NodeCount() int {
+	return len(s.neuronSignals) + s.modulesCount
+}
+
+// LinkCount Returns the total number of links between nodes in the network
+func (s *LayeredNetworkSolver) LinkCount() int {
+	return s.linkCount
+}
+
+// Depth Returns the number of evaluated layers, i.e. the activation depth of the network
+func (s *LayeredNetworkSolver) Depth() int {
+	return len(s.layers)
+}
+
+// Layers Returns the topologically sorted layers of the network
+func (s *LayeredNetworkSolver) Layers() []*NetworkLayer {
+	return s.layers
+}
+
+// Stringer
+func (s *LayeredNetworkSolver) String() string {
+	return fmt.Sprintf("LayeredNetwork, id: %d, name: [%s], neurons: %d,\n\tinputs: %d,\tbias: %d,\toutputs:%d,\t layers: %d",
+		s.Id, s.Name, len(s.neuronSignals), len(s.inputIndexes), len(s.biasIndexes), len(s.outputIndexes), len(s.layers))
+}
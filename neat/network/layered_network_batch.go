@@ -0,0 +1,89 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// EvaluateBatch Calculates outputs of the network for the batch of samples in a single pass. The inputs matrix holds
+// one sample per row with values of the input neurons (without BIAS) in columns. Returns matrix with outputs of
+// the network per each sample in the corresponding row. The signals of the whole batch are propagated layer by layer
+// using vectorized operations over the columns of samples. The current state of the solver is not affected.
+func (s *LayeredNetworkSolver) EvaluateBatch(inputs mat.Matrix) (*mat.Dense, error) {
+	rows, cols := inputs.Dims()
+	if rows == 0 {
+		return nil, errors.New("the batch of inputs is empty")
+	}
+	if cols != len(s.inputIndexes) {
+		return nil, ErrNetUnsupportedSensorsArraySize
+	}
+
+	// the signals of each neuron for all samples
+	signals := make([][]float64, len(s.neuronSignals))
+	for i := range signals {
+		signals[i] = make([]float64, rows)
+	}
+	for j, index := range s.inputIndexes {
+		mat.Col(signals[index], j, inputs)
+	}
+	for _, index := range s.biasIndexes {
+		for i := range signals[index] {
+			signals[index][i] = 1.0 // BIAS neuron signal
+		}
+	}
+
+	var err error
+	for _, layer := range s.layers {
+		for _, neuron := range layer.Neurons {
+			sums := signals[neuron.Index]
+			for i, source := range neuron.SourceIndexes {
+				floats.AddScaled(sums, neuron.Weights[i], signals[source])
+			}
+			for i, sum := range sums {
+				if sums[i], err = s.activators.ActivateByType(
+					neuron.netInput(sum), neuron.Params, neuron.ActivationType); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for m, module := range layer.Modules {
+			in := make([]float64, len(module.InputIndexes))
+			for i := 0; i < rows; i++ {
+				for j, inIndex := range module.InputIndexes {
+					in[j] = signals[inIndex][i]
+				}
+				outputs, err := s.activators.ActivateModuleByType(in, layer.ModulesParams[m], module.ActivationType)
+				if err != nil {
+					return nil, err
+				}
+				if len(outputs) != len(module.OutputIndexes) {
+					return nil, fmt.Errorf(
+						"number of output parameters [%d] returned by module activator doesn't match "+
+							"the number of output neurons of the module [%d]", len(outputs), len(module.OutputIndexes))
+				}
+				for j, outIndex := range module.OutputIndexes {
+					signals[outIndex][i] = outputs[j]
+				}
+			}
+		}
+	}
+
+	outputs := mat.NewDense(rows, len(s.outputIndexes), nil)
+	for j, index := range s.outputIndexes {
+		outputs.SetCol(j, signals[index])
+	}
+	return outputs, nil
+}
+
+// EvaluateBatch Calculates outputs of this feed-forward network for the batch of samples, where each row of inputs
+// matrix holds values of the input neurons (without BIAS). Returns matrix with outputs of the network per each sample
+// in the corresponding row. Returns ErrNetworkIsRecurrent if this network is not acyclic.
+func (n *Network) EvaluateBatch(inputs mat.Matrix) (*mat.Dense, error) {
+	solver, err := n.LayeredNetworkSolver()
+	if err != nil {
+		return nil, err
+	}
+	return solver.EvaluateBatch(inputs)
+}
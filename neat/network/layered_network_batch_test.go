@@ -0,0 +1,96 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mat"
+	"testing"
+)
+
+func TestLayeredNetworkSolver_EvaluateBatch(t *testing.T) {
+	net := buildNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+
+	inputs := mat.NewDense(3, 2, []float64{
+		0.5, 1.1,
+		-0.3, 2.0,
+		0.0, 0.0,
+	})
+	outputs, err := solver.EvaluateBatch(inputs)
+	require.NoError(t, err, "failed to evaluate batch")
+	rows, cols := outputs.Dims()
+	require.Equal(t, 3, rows)
+	require.Equal(t, 2, cols)
+
+	for i := 0; i < rows; i++ {
+		expected := activateForOutputs(t, net, inputs.RawRowView(i), 3)
+		assert.Equal(t, expected, outputs.RawRowView(i), "wrong outputs at row: %d", i)
+	}
+}
+
+func TestLayeredNetworkSolver_EvaluateBatch_modular(t *testing.T) {
+	net := buildModularNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+
+	inputs := mat.NewDense(2, 2, []float64{
+		1.0, 2.0,
+		-1.0, 0.5,
+	})
+	outputs, err := solver.EvaluateBatch(inputs)
+	require.NoError(t, err, "failed to evaluate batch")
+
+	for i := 0; i < 2; i++ {
+		err = solver.LoadSensors(inputs.RawRowView(i))
+		require.NoError(t, err, "failed to load sensors")
+		_, err = solver.ForwardSteps(1)
+		require.NoError(t, err, "failed to activate")
+		assert.Equal(t, solver.ReadOutputs(), outputs.RawRowView(i), "wrong outputs at row: %d", i)
+	}
+}
+
+func TestLayeredNetworkSolver_EvaluateBatch_wrongInputs(t *testing.T) {
+	solver, err := buildNetwork().LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+
+	outputs, err := solver.EvaluateBatch(mat.NewDense(2, 3, nil))
+	assert.EqualError(t, err, ErrNetUnsupportedSensorsArraySize.Error())
+	assert.Nil(t, outputs)
+
+	outputs, err = solver.EvaluateBatch(&mat.Dense{})
+	assert.EqualError(t, err, "the batch of inputs is empty")
+	assert.Nil(t, outputs)
+}
+
+func TestNetwork_EvaluateBatch(t *testing.T) {
+	net := buildNetwork()
+	inputs := mat.NewDense(1, 2, []float64{0.5, 1.1})
+	outputs, err := net.EvaluateBatch(inputs)
+	require.NoError(t, err, "failed to evaluate batch")
+	expected := activateForOutputs(t, net, inputs.RawRowView(0), 3)
+	assert.Equal(t, expected, outputs.RawRowView(0))
+
+	// recurrent network
+	net.allNodes[3].ConnectFrom(net.allNodes[6], 1.0).IsRecurrent = true
+	outputs, err = net.EvaluateBatch(inputs)
+	assert.EqualError(t, err, ErrNetworkIsRecurrent.Error())
+	assert.Nil(t, outputs)
+}
+
+func BenchmarkLayeredNetworkSolver_EvaluateBatch(b *testing.B) {
+	solver, err := buildNetwork().LayeredNetworkSolver()
+	require.NoError(b, err, "failed to create layered network solver")
+	data := make([]float64, 10000*2)
+	for i := range data {
+		data[i] = float64(i%7) / 7.0
+	}
+	inputs := mat.NewDense(10000, 2, data)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err = solver.EvaluateBatch(inputs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
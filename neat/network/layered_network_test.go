@@ -0,0 +1,190 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNetwork_LayeredNetworkSolver(t *testing.T) {
+	net := buildNetwork()
+
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+	assert.Equal(t, 3, solver.Depth())
+	assert.Equal(t, net.NodeCount(), solver.NodeCount())
+	assert.Equal(t, net.LinkCount(), solver.LinkCount())
+
+	layers := solver.Layers()
+	require.Len(t, layers, 3)
+	expected := [][]int{{3, 4}, {5}, {6, 7}}
+	for i, layer := range layers {
+		indexes := make([]int, len(layer.Neurons))
+		for j, neuron := range layer.Neurons {
+			indexes[j] = neuron.Index
+		}
+		assert.Equal(t, expected[i], indexes, "wrong neurons at layer: %d", i)
+	}
+}
+
+func TestLayeredNetworkSolver_ForwardSteps(t *testing.T) {
+	net := buildNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+
+	data := []float64{0.5, 1.1} // BIAS is 1.0 by definition
+	expected := activateForOutputs(t, net, data, 3)
+
+	err = solver.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	res, err := solver.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate")
+	require.True(t, res, "failed to activate")
+	assert.Equal(t, expected, solver.ReadOutputs())
+
+	// the new sample can be evaluated without flushing
+	data = []float64{-0.3, 2.0}
+	expected = activateForOutputs(t, net, data, 3)
+	err = solver.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	res, err = solver.RecursiveSteps()
+	require.NoError(t, err, "failed to activate")
+	require.True(t, res, "failed to activate")
+	assert.Equal(t, expected, solver.ReadOutputs())
+
+	// zero steps
+	res, err = solver.ForwardSteps(0)
+	assert.EqualError(t, err, ErrZeroActivationStepsRequested.Error())
+	assert.False(t, res)
+}
+
+func TestLayeredNetworkSolver_biasGenes(t *testing.T) {
+	net := buildBiasGenesNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+
+	data := []float64{0.5, 1.1}
+	expected := activateForOutputs(t, net, append(data, 1.0), 3)
+
+	err = solver.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	res, err := solver.Evaluate()
+	require.NoError(t, err, "failed to evaluate")
+	require.True(t, res)
+	assert.InDeltaSlice(t, expected, solver.ReadOutputs(), 1e-12)
+}
+
+func TestLayeredNetworkSolver_modular(t *testing.T) {
+	net := buildModularNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+	assert.Equal(t, net.NodeCount(), solver.NodeCount())
+	assert.Equal(t, net.LinkCount(), solver.LinkCount())
+
+	fmm, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast network solver")
+
+	data := []float64{1.0, 2.0} // bias inherent
+	err = fmm.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	_, err = fmm.ForwardSteps(5)
+	require.NoError(t, err, "failed to activate fast network solver")
+
+	err = solver.LoadSensors(data)
+	require.NoError(t, err, "failed to load sensors")
+	res, err := solver.Relax(5, 0.1)
+	require.NoError(t, err, "failed to activate")
+	require.True(t, res, "failed to activate")
+	assert.Equal(t, fmm.ReadOutputs(), solver.ReadOutputs())
+}
+
+func TestLayeredNetworkSolver_LoadSensors(t *testing.T) {
+	net := buildNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+
+	err = solver.LoadSensors([]float64{0.5, 1.1, 1.0})
+	assert.EqualError(t, err, ErrNetUnsupportedSensorsArraySize.Error())
+}
+
+func TestLayeredNetworkSolver_Flush(t *testing.T) {
+	net := buildNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+
+	err = solver.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+	_, err = solver.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate")
+
+	res, err := solver.Flush()
+	require.NoError(t, err, "failed to flush")
+	require.True(t, res)
+	assert.Equal(t, []float64{0, 0}, solver.ReadOutputs())
+	// BIAS signal is preserved
+	assert.Equal(t, 1.0, solver.neuronSignals[2])
+}
+
+func TestNetwork_LayeredNetworkSolver_recurrent(t *testing.T) {
+	net := buildNetwork()
+	// recurrent link
+	net.allNodes[3].ConnectFrom(net.allNodes[6], 1.0).IsRecurrent = true
+	solver, err := net.LayeredNetworkSolver()
+	assert.EqualError(t, err, ErrNetworkIsRecurrent.Error())
+	assert.Nil(t, solver)
+
+	// cycle without recurrent flag
+	net = buildNetwork()
+	net.allNodes[4].ConnectFrom(net.allNodes[5], 1.0)
+	solver, err = net.LayeredNetworkSolver()
+	assert.EqualError(t, err, ErrNetworkIsRecurrent.Error())
+	assert.Nil(t, solver)
+}
+
+func TestNetwork_LayeredNetworkSolver_disconnected(t *testing.T) {
+	net := buildDisconnectedNetwork()
+	solver, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+	assert.Equal(t, 0, solver.Depth())
+
+	err = solver.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+	res, err := solver.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate")
+	assert.True(t, res)
+	assert.Equal(t, []float64{0, 0}, solver.ReadOutputs())
+}
+
+func BenchmarkLayeredNetworkSolver(b *testing.B) {
+	solver, err := buildNetwork().LayeredNetworkSolver()
+	require.NoError(b, err, "failed to create layered network solver")
+	data := []float64{0.5, 1.1}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = solver.LoadSensors(data)
+		if _, err = solver.ForwardSteps(1); err != nil {
+			b.Fatal(err)
+		}
+		_ = solver.ReadOutputs()
+	}
+}
+
+func BenchmarkFastModularNetworkSolver(b *testing.B) {
+	net := buildNetwork()
+	solver, err := net.FastNetworkSolver()
+	require.NoError(b, err, "failed to create fast network solver")
+	depth, err := net.MaxActivationDepth()
+	require.NoError(b, err, "failed to calculate max depth")
+	data := []float64{0.5, 1.1}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = solver.Flush()
+		_ = solver.LoadSensors(data)
+		if _, err = solver.ForwardSteps(depth); err != nil {
+			b.Fatal(err)
+		}
+		_ = solver.ReadOutputs()
+	}
+}
@@ -18,6 +18,8 @@ type Link struct {
 	IsRecurrent bool
 	// If TRUE the link is time delayed
 	IsTimeDelayed bool
+	// The number of activation steps the signal is delayed by this link, zero means no delay
+	Delay int
 
 	// Points to a trait of parameters for genetic creation
 	Trait *neat.Trait
@@ -26,6 +28,13 @@ type Link struct {
 	// The following parameters are for use in neurons that learn through habituation,
 	// sensitization, or Hebbian-type processes
 	Params []float64
+
+	// The ring buffer of signals relayed by the link with Delay, created on first activation
+	delayLine *delayLine
+	// The weight of the link before it was changed by the modulated plasticity, valid if plastic is set
+	initialWeight float64
+	// The flag to indicate that weight of the link was changed by the modulated plasticity since the last flush
+	plastic bool
 }
 
 // NewLink Creates new link with specified weight, input and output neurons connected recurrently or not.
@@ -56,6 +65,7 @@ func NewLinkCopy(l *Link, inputNode, outNode *NNode) *Link {
 	link.Trait = l.Trait
 	link.deriveTrait(l.Trait)
 	link.IsRecurrent = l.IsRecurrent
+	link.Delay = l.Delay
 	return link
 }
 
@@ -78,8 +88,8 @@ func (l *Link) IsEqualGenetically(ol *Link) bool {
 
 // The Link methods implementation
 func (l *Link) String() string {
-	return fmt.Sprintf("[Link: (%s <-> %s), weight: %.3f, recurrent: %t, time delayed: %t]",
-		l.InNode, l.OutNode, l.ConnectionWeight, l.IsRecurrent, l.IsTimeDelayed)
+	return fmt.Sprintf("[Link: (%s <-> %s), weight: %.3f, recurrent: %t, time delayed: %t, delay: %d]",
+		l.InNode, l.OutNode, l.ConnectionWeight, l.IsRecurrent, l.IsTimeDelayed, l.Delay)
 }
 
 // IDString is to get synthetic ID of this link composed of IDs of connected nodes.
@@ -87,6 +97,46 @@ func (l *Link) IDString() string {
 	return fmt.Sprintf("%d-%d", l.InNode.Id, l.OutNode.Id)
 }
 
+// delayedSignal Pushes the provided output of the input node into the delay line of this link and returns the output
+// pushed Delay activation steps ago
+func (l *Link) delayedSignal(signal float64) float64 {
+	return l.getDelayLine().shift(signal)
+}
+
+// getDelayLine Returns the delay line of this link matching its Delay. The new delay line is created if not yet
+// allocated or if the Delay was changed.
+func (l *Link) getDelayLine() *delayLine {
+	if l.delayLine == nil || len(l.delayLine.signals) != l.Delay {
+		l.delayLine = newDelayLine(l.Delay)
+	}
+	return l.delayLine
+}
+
+// flushDelay Resets the signals in the delay line of this link
+func (l *Link) flushDelay() {
+	if l.delayLine != nil {
+		l.delayLine.flush()
+	}
+}
+
+// setPlasticWeight Sets the weight of this link changed by the modulated plasticity. The initial weight of the link is
+// kept to be restored by flushPlasticity.
+func (l *Link) setPlasticWeight(weight float64) {
+	if !l.plastic {
+		l.initialWeight = l.ConnectionWeight
+		l.plastic = true
+	}
+	l.ConnectionWeight = weight
+}
+
+// flushPlasticity Restores the weight of this link as it was before the changes by the modulated plasticity
+func (l *Link) flushPlasticity() {
+	if l.plastic {
+		l.ConnectionWeight = l.initialWeight
+		l.plastic = false
+	}
+}
+
 // Copy trait parameters into this link's parameters
 func (l *Link) deriveTrait(t *neat.Trait) {
 	if t != nil {
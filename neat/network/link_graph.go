@@ -46,6 +46,13 @@ func (l *Link) Attributes() []encoding.Attribute {
 		},
 	}
 
+	if l.Delay > 0 {
+		attrs = append(attrs, encoding.Attribute{
+			Key:   "delay",
+			Value: fmt.Sprintf("%d", l.Delay),
+		})
+	}
+
 	if len(l.Params) > 0 {
 		attrs = append(attrs, encoding.Attribute{
 			Key:   "parameters",
@@ -69,4 +69,10 @@ func TestLink_Attributes(t *testing.T) {
 	assert.Equal(t, "parameters", attrs[2].Key)
 	expected := fmt.Sprintf("%v", trait.Params)
 	assert.Equal(t, expected, attrs[2].Value)
+
+	l.Delay = 2
+	attrs = l.Attributes()
+	require.Len(t, attrs, 4, "wrong number of attributes")
+	assert.Equal(t, "delay", attrs[2].Key)
+	assert.Equal(t, "2", attrs[2].Value)
 }
@@ -35,6 +35,7 @@ func TestNewLinkCopy(t *testing.T) {
 
 	trait := &neat.Trait{Id: 1, Params: []float64{1.1, 2.3, 3.4, 4.2, 5.5, 6.7}}
 	link := NewLinkWithTrait(trait, 1.0, in, out, false)
+	link.Delay = 3
 
 	inCopy := NewNNode(3, InputNeuron)
 	outCopy := NewNNode(4, HiddenNeuron)
@@ -43,6 +44,7 @@ func TestNewLinkCopy(t *testing.T) {
 	assert.Equal(t, link.ConnectionWeight, linkCopy.ConnectionWeight, "wrong weight")
 	assert.Equal(t, link.Params, linkCopy.Params, "wrong parameters")
 	assert.Equal(t, link.IsRecurrent, linkCopy.IsRecurrent, "wrong recurrent")
+	assert.Equal(t, link.Delay, linkCopy.Delay, "wrong delay")
 	assert.Equal(t, inCopy, linkCopy.InNode, "wrong input node")
 	assert.Equal(t, outCopy, linkCopy.OutNode, "wrong output node")
 }
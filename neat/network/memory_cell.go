@@ -0,0 +1,68 @@
+package network
+
+import (
+	"fmt"
+	neatmath "github.com/yaricom/goNEAT/v3/neat/math"
+	"math"
+)
+
+// MemoryCellGateWeightsCount the number of gate weights of the memory cell neuron
+const MemoryCellGateWeightsCount = 9
+
+// The indexes of the gate weights of the memory cell neuron. Each gate has the weight of the net input of the neuron,
+// the weight of the previous output of the neuron, and the bias.
+const (
+	updateGateInputWeight = iota
+	updateGateStateWeight
+	updateGateBias
+	resetGateInputWeight
+	resetGateStateWeight
+	resetGateBias
+	candidateInputWeight
+	candidateStateWeight
+	candidateBias
+)
+
+// ActivateMemoryCell Calculates the new output of the gated memory cell neuron (GRU-like cell) from its net input x,
+// the previous output h, and the gate weights. The output is calculated as following:
+//
+//	z = sigmoid(Wzx * x + Wzh * h + bz)       - update gate
+//	r = sigmoid(Wrx * x + Wrh * h + br)       - reset gate
+//	c = activation(Wcx * x + Wch * r * h + bc) - candidate output
+//	h' = (1 - z) * h + z * c
+//
+// where the candidate output uses the activation function of the neuron. The gate weights must be in the order:
+// Wzx, Wzh, bz, Wrx, Wrh, br, Wcx, Wch, bc. When update gate is closed the cell keeps its previous output, which allows
+// memorizing the signals over long time horizons.
+func ActivateMemoryCell(input, previous float64, gateWeights []float64, activationType neatmath.NodeActivationType,
+	a *neatmath.NodeActivatorsFactory) (float64, error) {
+	if len(gateWeights) != MemoryCellGateWeightsCount {
+		return 0, fmt.Errorf("memory cell must have %d gate weights, but found: %d",
+			MemoryCellGateWeightsCount, len(gateWeights))
+	}
+	update := logistic(gateWeights[updateGateInputWeight]*input + gateWeights[updateGateStateWeight]*previous +
+		gateWeights[updateGateBias])
+	reset := logistic(gateWeights[resetGateInputWeight]*input + gateWeights[resetGateStateWeight]*previous +
+		gateWeights[resetGateBias])
+	candidate, err := a.ActivateByType(gateWeights[candidateInputWeight]*input+
+		gateWeights[candidateStateWeight]*reset*previous+gateWeights[candidateBias], nil, activationType)
+	if err != nil {
+		return 0, err
+	}
+	return (1-update)*previous + update*candidate, nil
+}
+
+// ActivateMemoryNode Method to calculate activation of the memory cell neuron node using its current net input (see
+// NNode.NetInput) and its current activation as the previous output. See ActivateMemoryCell for details.
+func ActivateMemoryNode(node *NNode, a *neatmath.NodeActivatorsFactory) error {
+	out, err := ActivateMemoryCell(node.NetInput(), node.GetActiveOut(), node.GateWeights, node.ActivationType, a)
+	if err == nil {
+		node.setActivation(out)
+	}
+	return err
+}
+
+// logistic is the standard logistic sigmoid used by gates of the memory cell
+func logistic(x float64) float64 {
+	return 1.0 / (1.0 + math.Exp(-x))
+}
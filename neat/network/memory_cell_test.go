@@ -0,0 +1,128 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"testing"
+)
+
+// latchGateWeights the gate weights of the memory cell which stores the positive input and keeps it when input is zero
+var latchGateWeights = []float64{10, 0, -5, 0, 0, 100, 1, 1, 0}
+
+// buildMemoryNetwork returns network with single input connected to the linear output through the memory cell neuron
+func buildMemoryNetwork() *Network {
+	input := NewNNode(1, InputNeuron)
+	memory := NewNNode(2, MemoryNeuron)
+	memory.ActivationType = math.LinearActivation
+	memory.GateWeights = append([]float64(nil), latchGateWeights...)
+	output := NewNNode(3, OutputNeuron)
+	output.ActivationType = math.LinearActivation
+
+	memory.ConnectFrom(input, 1.0)
+	output.ConnectFrom(memory, 1.0)
+	return NewNetwork([]*NNode{input}, []*NNode{output}, []*NNode{input, memory, output}, 1)
+}
+
+func TestActivateMemoryCell(t *testing.T) {
+	// all gates are half-open and candidate is zero
+	out, err := ActivateMemoryCell(1.0, 0.8, make([]float64, MemoryCellGateWeightsCount),
+		math.LinearActivation, math.NodeActivators)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, out, 1e-12)
+
+	// closed update gate keeps the previous output
+	gates := []float64{0, 0, -100, 0, 0, 0, 1, 0, 0}
+	out, err = ActivateMemoryCell(1.0, 0.8, gates, math.LinearActivation, math.NodeActivators)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.8, out, 1e-12)
+
+	// open update gate replaces output with candidate
+	gates = []float64{0, 0, 100, 0, 0, 0, 2, 0, 0.5}
+	out, err = ActivateMemoryCell(1.0, 0.8, gates, math.LinearActivation, math.NodeActivators)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.5, out, 1e-12)
+}
+
+func TestActivateMemoryCell_errors(t *testing.T) {
+	_, err := ActivateMemoryCell(1.0, 0.8, make([]float64, 3), math.LinearActivation, math.NodeActivators)
+	assert.Error(t, err, "wrong number of gate weights")
+
+	_, err = ActivateMemoryCell(1.0, 0.8, make([]float64, MemoryCellGateWeightsCount),
+		math.MinModuleActivation+1, math.NodeActivators)
+	assert.Error(t, err, "unsupported activation type")
+}
+
+func TestActivateMemoryNode(t *testing.T) {
+	node := NewNNode(1, MemoryNeuron)
+	node.ActivationType = math.LinearActivation
+	node.GateWeights = append([]float64(nil), latchGateWeights...)
+	node.ActivationSum = 1.0
+
+	err := ActivateMemoryNode(node, math.NodeActivators)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, node.ActivationsCount)
+	stored := node.Activation
+	assert.InDelta(t, 1.0, stored, 0.01)
+
+	// the stored value is kept without input
+	node.ActivationSum = 0
+	err = ActivateMemoryNode(node, math.NodeActivators)
+	require.NoError(t, err)
+	assert.InDelta(t, stored, node.Activation, 1e-12)
+
+	node.GateWeights = nil
+	err = ActivateMemoryNode(node, math.NodeActivators)
+	assert.Error(t, err)
+}
+
+func TestMemoryNeuron_solvers(t *testing.T) {
+	// the input pulse followed by the long delay
+	inputs := [][]float64{{1.0}}
+	for i := 0; i < 50; i++ {
+		inputs = append(inputs, []float64{0.0})
+	}
+
+	net := buildMemoryNetwork()
+	expected := runEpisode(t, net, inputs, 1)
+	// the pulse must be remembered until the end of the episode
+	assert.InDelta(t, 1.0, expected[len(expected)-1][0], 0.01)
+
+	solver, err := buildMemoryNetwork().FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	actual := runEpisode(t, solver, inputs, 1)
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.InDeltaSlice(t, expected[i], actual[i], 1e-12, "at: %d", i)
+	}
+
+	// flush resets the memory
+	_, err = solver.Flush()
+	require.NoError(t, err)
+	outputs := runEpisode(t, solver, inputs[1:3], 1)
+	assert.Zero(t, outputs[1][0])
+}
+
+func TestMemoryNeuron_RecursiveSteps(t *testing.T) {
+	solver, err := buildMemoryNetwork().FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+
+	require.NoError(t, solver.LoadSensors([]float64{1.0}))
+	_, err = solver.RecursiveSteps()
+	require.NoError(t, err)
+	stored := solver.ReadOutputs()[0]
+	assert.InDelta(t, 1.0, stored, 0.01)
+
+	require.NoError(t, solver.LoadSensors([]float64{0.0}))
+	_, err = solver.RecursiveSteps()
+	require.NoError(t, err)
+	assert.InDelta(t, stored, solver.ReadOutputs()[0], 1e-12)
+}
+
+func TestMemoryNeuron_unsupportedSolvers(t *testing.T) {
+	_, err := buildMemoryNetwork().LayeredNetworkSolver()
+	assert.ErrorIs(t, err, ErrNetworkIsRecurrent)
+
+	_, err = buildMemoryNetwork().CTRNNSolver(EulerIntegration)
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package network
+
+import "math"
+
+// The indexes of the parameters of the modulated Hebbian plasticity rule within the link parameters (see Link.Params),
+// which are derived from the link's trait.
+const (
+	// plasticityLearningRate the learning rate of the rule
+	plasticityLearningRate = iota
+	// plasticityCorrelation the weight of the correlation term of pre- and postsynaptic activations
+	plasticityCorrelation
+	// plasticityPresynaptic the weight of the presynaptic activation term
+	plasticityPresynaptic
+	// plasticityPostsynaptic the weight of the postsynaptic activation term
+	plasticityPostsynaptic
+	// plasticityConstant the constant term
+	plasticityConstant
+	// plasticityParamsCount the number of parameters of the rule
+	plasticityParamsCount
+)
+
+// ModulatedWeightChange Calculates the change of the weight of the link which plasticity is gated by the modulatory
+// neurons. The weight change is calculated following the neuromodulation literature (Soltoggio et al., 2008):
+//
+//	dw = eta * tanh(m) * (A * pre * post + B * pre + C * post + D)
+//
+// where m is the sum of the weighted outputs of the modulatory neurons connected to the target neuron of the link, pre
+// and post are the outputs of the source and target neurons, and the rule parameters eta, A, B, C, D are the first
+// five parameters of the link (see Link.Params). If link has fewer parameters or there is no modulation, the weight
+// is not changed.
+func ModulatedWeightChange(params []float64, modulation, pre, post float64) float64 {
+	if modulation == 0 || len(params) < plasticityParamsCount {
+		return 0
+	}
+	return params[plasticityLearningRate] * math.Tanh(modulation) * (params[plasticityCorrelation]*pre*post +
+		params[plasticityPresynaptic]*pre + params[plasticityPostsynaptic]*post + params[plasticityConstant])
+}
+
+// modulatedLearningStep Applies the modulated plasticity rule to the incoming links of all neurons of this network
+// which received modulatory signal during the last activation step. The links from the bias and modulatory neurons
+// are not plastic. The initial weights of the links are restored by Network.Flush.
+func (n *Network) modulatedLearningStep() {
+	for _, np := range n.allNodes {
+		if np.modulation == 0 {
+			continue
+		}
+		for _, link := range np.Incoming {
+			if link.InNode.NeuronType == BiasNeuron || link.InNode.NeuronType == ModulatoryNeuron {
+				continue
+			}
+			link.setPlasticWeight(link.ConnectionWeight + ModulatedWeightChange(link.Params, np.modulation,
+				link.InNode.GetActiveOut(), np.GetActiveOut()))
+		}
+	}
+}
+
+// modulatedLearningStep Applies the modulated plasticity rule to the connections of this solver which target neurons
+// received modulatory signal during the last activation step, and resets the modulation.
+func (s *FastModularNetworkSolver) modulatedLearningStep() {
+	for i, conn := range s.connections {
+		modulation := s.modulation[conn.TargetIndex]
+		if modulation == 0 || conn.SourceIndex < s.biasNeuronCount || s.modulatory[conn.SourceIndex] {
+			// the links from bias and modulatory neurons are not plastic
+			continue
+		}
+		s.weights[i] += ModulatedWeightChange(conn.Params, modulation,
+			s.neuronSignals[conn.SourceIndex], s.neuronSignals[conn.TargetIndex])
+	}
+	for i := range s.modulation {
+		s.modulation[i] = 0
+	}
+}
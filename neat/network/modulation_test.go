@@ -0,0 +1,179 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	gomath "math"
+	"testing"
+)
+
+// buildModulatedNetwork returns network where the plasticity of the link from input to output is gated by the modulatory
+// neuron driven by the same input
+func buildModulatedNetwork() *Network {
+	input := NewNNode(1, InputNeuron)
+	modulatory := NewNNode(2, ModulatoryNeuron)
+	modulatory.ActivationType = math.LinearActivation
+	output := NewNNode(3, OutputNeuron)
+	output.ActivationType = math.LinearActivation
+
+	// the plain Hebbian rule with learning rate 0.1
+	output.ConnectFrom(input, 0.5).Params = []float64{0.1, 1.0, 0, 0, 0}
+	modulatory.ConnectFrom(input, 1.0)
+	output.ConnectFrom(modulatory, 1.0)
+	return NewNetwork([]*NNode{input}, []*NNode{output}, []*NNode{input, modulatory, output}, 1)
+}
+
+var modulatedEpisodeInputs = [][]float64{{1.0}, {1.0}, {1.0}, {0.0}, {-1.0}, {1.0}}
+
+func TestModulatedWeightChange(t *testing.T) {
+	params := []float64{0.5, 1.0, 0.2, 0.3, 0.4}
+	delta := ModulatedWeightChange(params, 1.0, 2.0, 3.0)
+	assert.InDelta(t, 0.5*gomath.Tanh(1.0)*(6.0+0.4+0.9+0.4), delta, 1e-12)
+
+	// no modulation
+	assert.Zero(t, ModulatedWeightChange(params, 0, 2.0, 3.0))
+	// no rule parameters
+	assert.Zero(t, ModulatedWeightChange(params[:3], 1.0, 2.0, 3.0))
+	assert.Zero(t, ModulatedWeightChange(nil, 1.0, 2.0, 3.0))
+}
+
+func TestNetwork_modulatedPlasticity(t *testing.T) {
+	net := buildModulatedNetwork()
+	outputs := runEpisode(t, net, modulatedEpisodeInputs[:3], 1)
+
+	// the weight grows for the correlated positive activations
+	link := net.Outputs[0].Incoming[0]
+	assert.True(t, link.ConnectionWeight > 0.5, "weight must be changed by plasticity: %f", link.ConnectionWeight)
+	assert.True(t, outputs[2][0] > outputs[1][0], "output must grow with the weight")
+
+	// the modulatory link is not plastic and its signal is not summed
+	assert.Equal(t, 1.0, net.Outputs[0].Incoming[1].ConnectionWeight)
+	assert.Equal(t, 0.5, outputs[0][0])
+
+	// without modulatory neuron the weight is not changed
+	net = buildModulatedNetwork()
+	net.Outputs[0].Incoming = net.Outputs[0].Incoming[:1]
+	runEpisode(t, net, modulatedEpisodeInputs, 1)
+	assert.Equal(t, 0.5, net.Outputs[0].Incoming[0].ConnectionWeight)
+}
+
+func TestFastModularNetworkSolver_modulatedPlasticity(t *testing.T) {
+	expected := runEpisode(t, buildModulatedNetwork(), modulatedEpisodeInputs, 1)
+
+	solver, err := buildModulatedNetwork().FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	actual := runEpisode(t, solver, modulatedEpisodeInputs, 1)
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.InDeltaSlice(t, expected[i], actual[i], 1e-12, "at: %d", i)
+	}
+
+	// the learned weights are kept by the solver state only
+	model, err := buildModulatedNetwork().Model()
+	require.NoError(t, err, "failed to create model")
+	state1, state2 := model.NewState(), model.NewState()
+	runEpisode(t, state1, modulatedEpisodeInputs, 1)
+	actual = runEpisode(t, state2, modulatedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	// recursive activation is not supported
+	_, err = solver.RecursiveSteps()
+	assert.Error(t, err)
+}
+
+func TestFastModularNetworkSolver_modulatedPlasticity_biasLink(t *testing.T) {
+	// the delayed link from bias neuron is kept as connection by the fast solver, but it is not plastic
+	build := func() *Network {
+		net := buildModulatedNetwork()
+		bias := NewNNode(4, BiasNeuron)
+		link := net.Outputs[0].ConnectFrom(bias, 0.2)
+		link.Params = []float64{0.1, 1.0, 0, 0, 0}
+		link.Delay = 1
+		return NewNetwork([]*NNode{net.inputs[0], bias}, net.Outputs, append(net.allNodes, bias), 1)
+	}
+	net := build()
+	expected := runEpisode(t, net, modulatedEpisodeInputs, 1)
+	assert.Equal(t, 0.2, net.Outputs[0].Incoming[2].ConnectionWeight)
+
+	solver, err := build().FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	actual := runEpisode(t, solver, modulatedEpisodeInputs, 1)
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.InDeltaSlice(t, expected[i], actual[i], 1e-12, "at: %d", i)
+	}
+}
+
+func TestModulatoryNeuron_Flush(t *testing.T) {
+	net := buildModulatedNetwork()
+	expected := runEpisode(t, net, modulatedEpisodeInputs, 1)
+	link := net.Outputs[0].Incoming[0]
+	require.NotEqual(t, 0.5, link.ConnectionWeight, "weight must be changed by plasticity")
+
+	// the initial weights are restored by flush
+	res, err := net.Flush()
+	require.NoError(t, err, "failed to flush")
+	require.True(t, res)
+	assert.Equal(t, 0.5, link.ConnectionWeight)
+	assert.Equal(t, 1.0, net.Outputs[0].Incoming[1].ConnectionWeight)
+	actual := runEpisode(t, net, modulatedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	solver, err := buildModulatedNetwork().FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	fastSolver := solver.(*FastModularNetworkSolver)
+	initialWeights := make([]float64, len(fastSolver.connections))
+	for i, conn := range fastSolver.connections {
+		initialWeights[i] = conn.Weight
+	}
+	expected = runEpisode(t, fastSolver, modulatedEpisodeInputs, 1)
+	require.NotEqual(t, initialWeights, fastSolver.weights, "weights must be changed by plasticity")
+
+	res, err = fastSolver.Flush()
+	require.NoError(t, err, "failed to flush")
+	require.True(t, res)
+	assert.Equal(t, initialWeights, fastSolver.weights)
+	actual = runEpisode(t, fastSolver, modulatedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+}
+
+func TestModulatoryNeuron_Snapshot_Restore(t *testing.T) {
+	net := buildModulatedNetwork()
+	runEpisode(t, net, modulatedEpisodeInputs[:2], 1)
+	state := net.Snapshot()
+	require.Len(t, state.LinkWeights, 3)
+	expected := runEpisode(t, net, modulatedEpisodeInputs, 1)
+
+	err := net.Restore(state)
+	require.NoError(t, err, "failed to restore")
+	actual := runEpisode(t, net, modulatedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	state.LinkWeights = state.LinkWeights[:1]
+	assert.Error(t, net.Restore(state))
+
+	solver, err := buildModulatedNetwork().FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast solver")
+	fastSolver := solver.(*FastModularNetworkSolver)
+	runEpisode(t, fastSolver, modulatedEpisodeInputs[:2], 1)
+	solverState := fastSolver.Snapshot()
+	require.Len(t, solverState.Weights, 3)
+	expected = runEpisode(t, fastSolver, modulatedEpisodeInputs, 1)
+
+	err = fastSolver.Restore(solverState)
+	require.NoError(t, err, "failed to restore")
+	actual = runEpisode(t, fastSolver, modulatedEpisodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	solverState.Weights = nil
+	assert.Error(t, fastSolver.Restore(solverState))
+}
+
+func TestModulatoryNeuron_unsupportedSolvers(t *testing.T) {
+	_, err := buildModulatedNetwork().LayeredNetworkSolver()
+	assert.Error(t, err)
+
+	_, err = buildModulatedNetwork().CTRNNSolver(EulerIntegration)
+	assert.Error(t, err)
+}
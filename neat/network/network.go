@@ -31,6 +31,11 @@ type Network struct {
 
 	// allNodesMIMO a list of all nodes in the network including MIMO control ones
 	allNodesMIMO []*NNode
+
+	// The optional recorder of the nodes' activations
+	recorder *ActivationRecorder
+	// The factory of activation functions of this network, if nil the default math.NodeActivators is used
+	activators *math.NodeActivatorsFactory
 }
 
 // NewNetwork Creates new network
@@ -71,7 +76,7 @@ func (n *Network) FastNetworkSolver() (Solver, error) {
 			biasList = append(biasList, ne)
 		case InputNeuron:
 			inList = append(inList, ne)
-		case HiddenNeuron:
+		case HiddenNeuron, MemoryNeuron, ModulatoryNeuron:
 			hiddenList = append(hiddenList, ne)
 		}
 	}
@@ -135,8 +140,53 @@ func (n *Network) FastNetworkSolver() (Solver, error) {
 		modules[i] = &FastControlNode{InputIndexes: inputs, OutputIndexes: outputs, ActivationType: cn.ActivationType}
 	}
 
-	return NewFastModularNetworkSolver(biasNeuronCount, inputNeuronCount, outputNeuronCount, totalNeuronCount,
-		activations, connections, biases, modules), nil
+	solver := NewFastModularNetworkSolverWithActivators(biasNeuronCount, inputNeuronCount, outputNeuronCount,
+		totalNeuronCount, activations, connections, biases, modules, n.Activators())
+	solver.neuronIds = make([]int, totalNeuronCount)
+	for id, index := range neuronLookup {
+		solver.neuronIds[index] = id
+	}
+	for _, ne := range hiddenList {
+		if ne.NeuronType == MemoryNeuron {
+			if solver.gateWeights == nil {
+				solver.gateWeights = make([][]float64, totalNeuronCount)
+			}
+			solver.gateWeights[neuronLookup[ne.Id]] = ne.GateWeights
+		} else if ne.NeuronType == ModulatoryNeuron {
+			if solver.modulatory == nil {
+				solver.modulatory = make([]bool, totalNeuronCount)
+			}
+			solver.modulatory[neuronLookup[ne.Id]] = true
+		}
+	}
+	// apply the bias and response genes and the activation parameters of the neurons, the response scales the bias
+	// from the bias neurons as well
+	for _, list := range [][]*NNode{n.Outputs, hiddenList} {
+		for _, ne := range list {
+			index := neuronLookup[ne.Id]
+			if response := ne.ActivationResponse(); response != DefaultNodeResponse {
+				if solver.responses == nil {
+					solver.responses = make([]float64, totalNeuronCount)
+					for i := range solver.responses {
+						solver.responses[i] = DefaultNodeResponse
+					}
+				}
+				solver.responses[index] = response
+				biases[index] *= response
+			}
+			biases[index] += ne.Bias
+			if len(ne.Params) > 0 {
+				if solver.activationParams == nil {
+					solver.activationParams = make([][]float64, totalNeuronCount)
+				}
+				solver.activationParams[index] = ne.Params
+			}
+		}
+	}
+	if solver.modulatory != nil {
+		solver.initPlasticity()
+	}
+	return solver, nil
 }
 
 func processList(startIndex int, nList []*NNode, activations []math.NodeActivationType, neuronLookup map[int]int) int {
@@ -154,7 +204,7 @@ func (n *Network) processIncomingConnections(nList []*NNode, biases []float64, n
 		if targetIndex, ok := neuronLookup[ne.Id]; ok {
 			for _, in := range ne.Incoming {
 				if sourceIndex, ok := neuronLookup[in.InNode.Id]; ok {
-					if in.InNode.NeuronType == BiasNeuron {
+					if in.InNode.NeuronType == BiasNeuron && in.Delay == 0 {
 						// store bias for target neuron
 						biases[targetIndex] += in.ConnectionWeight
 					} else {
@@ -163,6 +213,8 @@ func (n *Network) processIncomingConnections(nList []*NNode, biases []float64, n
 							SourceIndex: sourceIndex,
 							TargetIndex: targetIndex,
 							Weight:      in.ConnectionWeight,
+							Params:      in.Params,
+							Delay:       in.Delay,
 						}
 						connections = append(connections, &conn)
 					}
@@ -192,6 +244,10 @@ func (n *Network) Flush() (res bool, err error) {
 	// Flush back recursively
 	for _, node := range n.allNodes {
 		node.Flushback()
+		for _, link := range node.Incoming {
+			link.flushDelay()
+			link.flushPlasticity()
+		}
 		err = node.FlushbackCheck()
 		if err != nil {
 			// failed - no need to continue
@@ -256,14 +312,28 @@ func (n *Network) ActivateSteps(maxSteps int) (bool, error) {
 		}
 
 		// For each neuron node, compute the sum of its incoming activation
+		modulated := false
 		for _, np := range n.allNodes {
 			if np.IsNeuron() {
 				np.ActivationSum = 0.0 // reset activation value
+				np.modulation = 0.0
 
 				// For each node's incoming connection, add the activity from the connection to the activesum
 				for _, link := range np.Incoming {
+					if link.InNode.NeuronType == ModulatoryNeuron {
+						// the modulatory signal gates the plasticity and is not summed with regular signals
+						np.modulation += link.ConnectionWeight * link.InNode.GetActiveOut()
+						modulated = true
+						continue
+					}
 					// Handle possible time delays
-					if !link.IsTimeDelayed {
+					if link.Delay > 0 {
+						// the signal is received through the delay line of the link
+						addAmount = link.ConnectionWeight * link.delayedSignal(link.InNode.GetActiveOut())
+						if link.InNode.isActive || link.InNode.IsSensor() {
+							np.isActive = true
+						}
+					} else if !link.IsTimeDelayed {
 						addAmount = link.ConnectionWeight * link.InNode.GetActiveOut()
 						if link.InNode.isActive || link.InNode.IsSensor() {
 							np.isActive = true
@@ -281,8 +351,13 @@ func (n *Network) ActivateSteps(maxSteps int) (bool, error) {
 			if np.IsNeuron() {
 				// Only activate if some active input came in
 				if np.isActive {
-					// Now run the net activation through an activation function
-					err := ActivateNode(np, math.NodeActivators)
+					// Now run the net activation through an activation function or memory cell
+					var err error
+					if np.NeuronType == MemoryNeuron {
+						err = ActivateMemoryNode(np, n.Activators())
+					} else {
+						err = ActivateNode(np, n.Activators())
+					}
 					if err != nil {
 						return false, err
 					}
@@ -294,7 +369,7 @@ func (n *Network) ActivateSteps(maxSteps int) (bool, error) {
 		for _, cn := range n.controlNodes {
 			cn.isActive = false
 			// Activate control MIMO node as control module
-			err := ActivateModule(cn, math.NodeActivators)
+			err := ActivateModule(cn, n.Activators())
 			if err != nil {
 				return false, err
 			}
@@ -302,12 +377,64 @@ func (n *Network) ActivateSteps(maxSteps int) (bool, error) {
 			cn.isActive = true
 		}
 
+		// Apply the modulated plasticity to the links of the neurons received the modulatory signal
+		if modulated {
+			n.modulatedLearningStep()
+		}
+
+		if n.recorder != nil {
+			n.recordActivations()
+		}
+
 		oneTime = true
 		abortCount += 1
 	}
 	return true, nil
 }
 
+// SetActivationRecorder Sets the recorder to capture activations of all nodes at every activation step. Use nil
+// to stop recording.
+func (n *Network) SetActivationRecorder(recorder *ActivationRecorder) {
+	n.recorder = recorder
+}
+
+// SetActivators Sets the factory of activation functions to activate the nodes of this network and of the solvers
+// created from it. The factory is also set to all nodes of this network. Use nil to reset to the default
+// math.NodeActivators.
+func (n *Network) SetActivators(activators *math.NodeActivatorsFactory) {
+	n.activators = activators
+	for _, node := range n.allNodes {
+		node.SetActivators(activators)
+	}
+	for _, node := range n.controlNodes {
+		node.SetActivators(activators)
+	}
+}
+
+// Activators Returns the factory of activation functions of this network
+func (n *Network) Activators() *math.NodeActivatorsFactory {
+	if n.activators != nil {
+		return n.activators
+	}
+	return math.NodeActivators
+}
+
+// ActivationRecorder Returns the recorder of the nodes' activations or nil if not set
+func (n *Network) ActivationRecorder() *ActivationRecorder {
+	return n.recorder
+}
+
+// recordActivations Records current activations of all nodes except control ones
+func (n *Network) recordActivations() {
+	ids := make([]int, len(n.allNodes))
+	activations := make([]float64, len(n.allNodes))
+	for i, np := range n.allNodes {
+		ids[i] = np.Id
+		activations[i] = np.Activation
+	}
+	n.recorder.record(ids, activations)
+}
+
 // Activate is to activate the network such that all outputs are active
 func (n *Network) Activate() (bool, error) {
 	return n.ActivateSteps(20)
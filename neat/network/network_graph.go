@@ -173,33 +173,18 @@ func (n *Network) edgeBetween(uid, vid int64, directed bool) *Link {
 			if cn.ID() != cid {
 				continue
 			}
-			// check connections
+			// check connections, the same node can be both input and output of the control node, e.g., when module
+			// decodes the group of outputs in place
 			for _, incoming := range cn.Incoming {
-				if incoming.InNode.ID() == oid {
-					if !directed {
-						return incoming
-					} else {
-						// make sure that control node is on the outgoing side
-						if uNode != nil {
-							return incoming
-						} else {
-							return nil
-						}
-					}
+				// for directed check that control node is on the outgoing side
+				if incoming.InNode.ID() == oid && (!directed || uNode != nil) {
+					return incoming
 				}
 			}
 			for _, outgoing := range cn.Outgoing {
-				if outgoing.OutNode.ID() == oid {
-					if !directed {
-						return outgoing
-					} else {
-						// make sure that control node if on the incoming side
-						if vNode != nil {
-							return outgoing
-						} else {
-							return nil
-						}
-					}
+				// for directed check that control node is on the incoming side
+				if outgoing.OutNode.ID() == oid && (!directed || vNode != nil) {
+					return outgoing
 				}
 			}
 		}
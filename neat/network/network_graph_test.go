@@ -3,6 +3,7 @@ package network
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
 	"gonum.org/v1/gonum/graph"
 	"testing"
 )
@@ -98,6 +99,19 @@ func TestNetwork_Edge_Modular(t *testing.T) {
 	}
 }
 
+func TestNetwork_Edge_outputModule(t *testing.T) {
+	net := buildOutputModuleNetwork(math.SoftmaxModuleActivation)
+
+	// the output nodes are both inputs and outputs of the control node
+	for _, id := range []int64{3, 4} {
+		require.NotNilf(t, net.Edge(id, 5), "edge expected from: %d to: 5", id)
+		require.NotNilf(t, net.Edge(5, id), "edge expected from: 5 to: %d", id)
+		assert.True(t, net.HasEdgeFromTo(5, id))
+	}
+	require.Nil(t, net.Edge(1, 5), "edge not expected from: 1 to: 5")
+	require.Nil(t, net.Edge(5, 1), "edge not expected from: 5 to: 1")
+}
+
 func TestNetwork_Node(t *testing.T) {
 	net := buildNetwork()
 	for _, n := range net.allNodesMIMO {
@@ -0,0 +1,41 @@
+package network
+
+// NetworkModel is the immutable compiled model of the network, which can be safely shared between goroutines.
+// The activation state is kept separately by the lightweight solvers created with NewState, which allows
+// to evaluate the same network concurrently, e.g. to run parallel rollouts of the champion organism.
+type NetworkModel struct {
+	// A network id
+	Id int
+	// Is a name of this network
+	Name string
+
+	// The compiled network structure shared by all states
+	model *fastNetworkModel
+}
+
+// Model Creates the immutable model of this network. Later changes of this network are not reflected by the model.
+func (n *Network) Model() (*NetworkModel, error) {
+	solver, err := n.FastNetworkSolver()
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkModel{
+		Id:    n.Id,
+		Name:  n.Name,
+		model: solver.(*FastModularNetworkSolver).fastNetworkModel,
+	}, nil
+}
+
+// NewState Creates the new network solver with fresh activation state sharing this model. The returned solver is
+// not safe for concurrent use, thus each goroutine should create its own state.
+func (m *NetworkModel) NewState() *FastModularNetworkSolver {
+	solver := newFastModularNetworkSolver(m.model)
+	solver.Id = m.Id
+	solver.Name = m.Name
+	return solver
+}
+
+// NodeCount Returns the total number of neural units in the network
+func (m *NetworkModel) NodeCount() int {
+	return m.model.totalNeuronCount + len(m.model.modules)
+}
@@ -0,0 +1,85 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestNetwork_Model(t *testing.T) {
+	net := buildModularNetwork()
+	net.Id, net.Name = 42, "modular"
+
+	model, err := net.Model()
+	require.NoError(t, err, "failed to create network model")
+	assert.Equal(t, 42, model.Id)
+	assert.Equal(t, "modular", model.Name)
+	assert.Equal(t, net.NodeCount(), model.NodeCount())
+
+	state := model.NewState()
+	assert.Equal(t, 42, state.Id)
+	assert.Equal(t, "modular", state.Name)
+	assert.Equal(t, net.NodeCount(), state.NodeCount())
+	assert.Equal(t, net.LinkCount(), state.LinkCount())
+}
+
+func TestNetworkModel_NewState(t *testing.T) {
+	model, err := buildNetwork().Model()
+	require.NoError(t, err, "failed to create network model")
+
+	first, second := model.NewState(), model.NewState()
+	err = first.LoadSensors([]float64{0.5, 1.1})
+	require.NoError(t, err, "failed to load sensors")
+	_, err = first.ForwardSteps(3)
+	require.NoError(t, err, "failed to activate")
+
+	// the states are independent
+	assert.NotEqual(t, []float64{0, 0}, first.ReadOutputs())
+	assert.Equal(t, []float64{0, 0}, second.ReadOutputs())
+}
+
+func TestNetworkModel_concurrent(t *testing.T) {
+	model, err := buildNetwork().Model()
+	require.NoError(t, err, "failed to create network model")
+
+	samples := [][]float64{{0.5, 1.1}, {-0.3, 2.0}, {0.0, 0.0}, {1.0, -1.0}}
+	expected := make([][]float64, len(samples))
+	for i, sample := range samples {
+		state := model.NewState()
+		require.NoError(t, state.LoadSensors(sample))
+		_, err = state.ForwardSteps(3)
+		require.NoError(t, err, "failed to activate")
+		expected[i] = state.ReadOutputs()
+	}
+
+	const workers = 8
+	results := make([][][]float64, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			state := model.NewState()
+			for i := 0; i < 100; i++ {
+				sample := samples[(w+i)%len(samples)]
+				_, _ = state.Flush()
+				if err := state.LoadSensors(sample); err != nil {
+					return
+				}
+				if _, err := state.ForwardSteps(3); err != nil {
+					return
+				}
+				results[w] = append(results[w], state.ReadOutputs())
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for w, outputs := range results {
+		require.Len(t, outputs, 100, "worker %d failed", w)
+		for i, out := range outputs {
+			assert.Equal(t, expected[(w+i)%len(samples)], out, "wrong outputs of worker %d at %d", w, i)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package network
+
+import (
+	"errors"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+)
+
+// Prune creates the minimal equivalent copy of this network by removing hidden nodes which either not reachable from
+// the sensors or has no path to the outputs together with all their links. The input and output nodes are always
+// preserved, and the control nodes (MIMO modules) are preserved along with all their IO nodes if they are alive.
+//
+// If foldPassThrough is true, the network is additionally simplified as following:
+//   - the hidden nodes with LinearActivation having either single incoming or single outgoing link and no bias or
+//     response genes are folded, i.e. replaced by direct links between their sources and targets with weights multiplied,
+//   - the outgoing links of hidden nodes with NullActivation are removed if their targets have other inputs,
+//     because such nodes always produce zero output.
+//
+// Only non-recurrent and not time delayed links are folded. The resulting network produces the same outputs as
+// the original one after the activation settles, but it may need fewer activation steps to do so.
+// The original network remains unchanged.
+func (n *Network) Prune(foldPassThrough bool) (*Network, error) {
+	if len(n.Outputs) == 0 {
+		return nil, errors.New("network has no outputs")
+	}
+	copies := n.copyLiveNodes()
+
+	// build the lists of the new network
+	in := make([]*NNode, 0, len(n.inputs))
+	for _, node := range n.inputs {
+		in = append(in, copies[node])
+	}
+	out := make([]*NNode, 0, len(n.Outputs))
+	for _, node := range n.Outputs {
+		out = append(out, copies[node])
+	}
+	all := make([]*NNode, 0, len(copies))
+	for _, node := range n.allNodes {
+		if c, ok := copies[node]; ok {
+			all = append(all, c)
+		}
+	}
+	control := make([]*NNode, 0, len(n.controlNodes))
+	for _, node := range n.controlNodes {
+		if c, ok := copies[node]; ok {
+			control = append(control, c)
+		}
+	}
+
+	if foldPassThrough {
+		all = foldPassThroughNodes(all, control)
+	}
+
+	var pruned *Network
+	if len(n.controlNodes) == 0 {
+		pruned = NewNetwork(in, out, all, n.Id)
+	} else {
+		pruned = NewModularNetwork(in, out, all, control, n.Id)
+	}
+	pruned.Name = n.Name
+	pruned.SetActivators(n.activators)
+	return pruned, nil
+}
+
+// copyLiveNodes makes copies of all alive nodes of this network and connects them with copies of the links between
+// alive nodes. Returns map of original nodes to their copies.
+func (n *Network) copyLiveNodes() map[*NNode]*NNode {
+	forward, backward := make(map[*NNode][]*NNode), make(map[*NNode][]*NNode)
+	addEdge := func(from, to *NNode) {
+		forward[from] = append(forward[from], to)
+		backward[to] = append(backward[to], from)
+	}
+	for _, node := range n.allNodes {
+		for _, l := range node.Incoming {
+			addEdge(l.InNode, l.OutNode)
+		}
+	}
+	for _, cn := range n.controlNodes {
+		for _, l := range cn.Incoming {
+			addEdge(l.InNode, cn)
+		}
+		for _, l := range cn.Outgoing {
+			addEdge(cn, l.OutNode)
+		}
+	}
+
+	fromSensors := reachable(forward, n.inputs)
+	toOutputs := reachable(backward, n.Outputs)
+	isLive := func(node *NNode) bool {
+		return fromSensors[node] && toOutputs[node]
+	}
+
+	copies := make(map[*NNode]*NNode)
+	copyNode := func(node *NNode) {
+		if _, ok := copies[node]; !ok {
+			c := NewNNodeCopy(node, node.Trait)
+			if node.Params != nil {
+				c.Params = append([]float64{}, node.Params...)
+			}
+			copies[node] = c
+		}
+	}
+	for _, node := range n.inputs {
+		copyNode(node)
+	}
+	for _, node := range n.Outputs {
+		copyNode(node)
+	}
+	for _, node := range n.allNodes {
+		if isLive(node) {
+			copyNode(node)
+		}
+	}
+	// the alive control nodes keep all their IO nodes to preserve the module arity
+	for _, cn := range n.controlNodes {
+		if !isLive(cn) {
+			continue
+		}
+		copyNode(cn)
+		for _, l := range cn.Incoming {
+			copyNode(l.InNode)
+		}
+		for _, l := range cn.Outgoing {
+			copyNode(l.OutNode)
+		}
+	}
+
+	// copy links between the alive nodes
+	for _, node := range n.allNodes {
+		outNode, ok := copies[node]
+		if !ok {
+			continue
+		}
+		for _, l := range node.Incoming {
+			if inNode, ok := copies[l.InNode]; ok {
+				link := NewLinkCopy(l, inNode, outNode)
+				link.IsTimeDelayed = l.IsTimeDelayed
+				outNode.Incoming = append(outNode.Incoming, link)
+				inNode.Outgoing = append(inNode.Outgoing, link)
+			}
+		}
+	}
+	for _, cn := range n.controlNodes {
+		c, ok := copies[cn]
+		if !ok {
+			continue
+		}
+		// similar to the genesis, links of control node are only stored in the control node itself
+		for _, l := range cn.Incoming {
+			c.Incoming = append(c.Incoming, NewLinkCopy(l, copies[l.InNode], c))
+		}
+		for _, l := range cn.Outgoing {
+			c.Outgoing = append(c.Outgoing, NewLinkCopy(l, c, copies[l.OutNode]))
+		}
+	}
+	return copies
+}
+
+// foldPassThroughNodes folds the pass-through hidden nodes until no more nodes can be folded. Returns the updated
+// list of all nodes.
+func foldPassThroughNodes(all, control []*NNode) []*NNode {
+	pinned := make(map[*NNode]bool)
+	for _, cn := range control {
+		for _, l := range cn.Incoming {
+			pinned[l.InNode] = true
+		}
+		for _, l := range cn.Outgoing {
+			pinned[l.OutNode] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, node := range all {
+			if node.NeuronType != HiddenNeuron || pinned[node] || !hasOnlyForwardLinks(node) {
+				continue
+			}
+			switch node.ActivationType {
+			case math.LinearActivation:
+				if node.Bias != 0 || node.ActivationResponse() != DefaultNodeResponse {
+					// the node with bias or response is not the pass-through node
+					continue
+				}
+				changed = foldLinearNode(node) || changed
+			case math.NullActivation:
+				changed = cutNullNode(node) || changed
+			}
+		}
+		// remove the hidden nodes left without outgoing links
+		live := make([]*NNode, 0, len(all))
+		for _, node := range all {
+			if node.NeuronType == HiddenNeuron && !pinned[node] && len(node.Outgoing) == 0 {
+				for _, l := range node.Incoming {
+					l.InNode.Outgoing = removeLink(l.InNode.Outgoing, l)
+				}
+				changed = true
+				continue
+			}
+			live = append(live, node)
+		}
+		all = live
+	}
+	return all
+}
+
+// foldLinearNode replaces the linear pass-through node with direct links between its sources and targets if it
+// doesn't increase the number of links. Returns true if node was folded.
+func foldLinearNode(node *NNode) bool {
+	if len(node.Incoming) == 0 || len(node.Outgoing) == 0 ||
+		(len(node.Incoming) > 1 && len(node.Outgoing) > 1) {
+		return false
+	}
+	for _, in := range node.Incoming {
+		for _, out := range node.Outgoing {
+			if in.InNode == out.OutNode {
+				// folding will create loop
+				return false
+			}
+		}
+	}
+	for _, in := range node.Incoming {
+		for _, out := range node.Outgoing {
+			weight := in.ConnectionWeight * out.ConnectionWeight
+			if existing := forwardLink(in.InNode, out.OutNode); existing != nil {
+				existing.ConnectionWeight += weight
+			} else {
+				link := NewLink(weight, in.InNode, out.OutNode, false)
+				in.InNode.Outgoing = append(in.InNode.Outgoing, link)
+				out.OutNode.Incoming = append(out.OutNode.Incoming, link)
+			}
+		}
+	}
+	for _, in := range node.Incoming {
+		in.InNode.Outgoing = removeLink(in.InNode.Outgoing, in)
+	}
+	for _, out := range node.Outgoing {
+		out.OutNode.Incoming = removeLink(out.OutNode.Incoming, out)
+	}
+	node.Incoming, node.Outgoing = nil, nil
+	return true
+}
+
+// cutNullNode removes outgoing links of the node with NullActivation which always produces zero output. The link is
+// only removed if its target has other inputs, otherwise the target will never be activated. Returns true if any
+// link was removed.
+func cutNullNode(node *NNode) bool {
+	if len(node.Incoming) == 0 {
+		// not activated by the network, e.g. the output of the module
+		return false
+	}
+	removed := false
+	outgoing := make([]*Link, 0, len(node.Outgoing))
+	for _, out := range node.Outgoing {
+		if len(out.OutNode.Incoming) > 1 {
+			out.OutNode.Incoming = removeLink(out.OutNode.Incoming, out)
+			removed = true
+		} else {
+			outgoing = append(outgoing, out)
+		}
+	}
+	node.Outgoing = outgoing
+	return removed
+}
+
+// hasOnlyForwardLinks checks that node has neither recurrent nor time delayed links and is not connected to itself
+func hasOnlyForwardLinks(node *NNode) bool {
+	for _, links := range [][]*Link{node.Incoming, node.Outgoing} {
+		for _, l := range links {
+			if l.IsRecurrent || l.IsTimeDelayed || l.Delay > 0 || l.InNode == l.OutNode {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// forwardLink returns the forward link between provided nodes if any
+func forwardLink(from, to *NNode) *Link {
+	for _, l := range from.Outgoing {
+		if l.OutNode == to && !l.IsRecurrent && !l.IsTimeDelayed && l.Delay == 0 {
+			return l
+		}
+	}
+	return nil
+}
+
+// removeLink removes the link from the list preserving the order of the remaining links
+func removeLink(links []*Link, link *Link) []*Link {
+	for i, l := range links {
+		if l == link {
+			return append(links[:i], links[i+1:]...)
+		}
+	}
+	return links
+}
+
+// reachable returns the set of nodes reachable from the start nodes using provided adjacency lists
+func reachable(adjacency map[*NNode][]*NNode, start []*NNode) map[*NNode]bool {
+	visited := make(map[*NNode]bool)
+	stack := append([]*NNode{}, start...)
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[node] {
+			continue
+		}
+		visited[node] = true
+		stack = append(stack, adjacency[node]...)
+	}
+	return visited
+}
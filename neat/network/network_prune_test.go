@@ -0,0 +1,154 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat/math"
+	"testing"
+)
+
+// activateForOutputs loads sensors and activates network given number of steps to settle the outputs
+func activateForOutputs(t *testing.T, net *Network, sensors []float64, steps int) []float64 {
+	_, err := net.Flush()
+	require.NoError(t, err, "failed to flush")
+	err = net.LoadSensors(sensors)
+	require.NoError(t, err, "failed to load sensors")
+	res, err := net.ForwardSteps(steps)
+	require.NoError(t, err, "failed to activate")
+	require.True(t, res, "failed to activate")
+	return net.ReadOutputs()
+}
+
+func TestNetwork_Prune_deadNodes(t *testing.T) {
+	net := buildNetwork()
+	// add dead-end hidden node
+	deadEnd := NewNNode(9, HiddenNeuron)
+	deadEnd.ConnectFrom(net.allNodes[0], 1.0)
+	// add hidden node not reachable from inputs
+	unreachable := NewNNode(10, HiddenNeuron)
+	net.Outputs[0].ConnectFrom(unreachable, 2.0)
+	net.allNodes = append(net.allNodes, deadEnd, unreachable)
+
+	pruned, err := net.Prune(false)
+	require.NoError(t, err, "failed to prune")
+	assert.Equal(t, 8, pruned.NodeCount())
+	assert.Equal(t, 8, pruned.LinkCount())
+	assert.Equal(t, 10, net.NodeCount(), "original network should remain unchanged")
+	assert.Len(t, pruned.inputs, 3)
+	assert.Len(t, pruned.Outputs, 2)
+
+	sensors := []float64{0.5, 1.0, 1.0}
+	expected := activateForOutputs(t, net, sensors, 5)
+	assert.Equal(t, expected, activateForOutputs(t, pruned, sensors, 5))
+}
+
+func TestNetwork_Prune_foldLinear(t *testing.T) {
+	allNodes := []*NNode{
+		NewNNode(1, InputNeuron),
+		NewNNode(2, InputNeuron),
+		NewNNode(3, HiddenNeuron),
+		NewNNode(4, HiddenNeuron),
+		NewNNode(5, OutputNeuron),
+	}
+	allNodes[2].ActivationType = math.LinearActivation
+	allNodes[3].ActivationType = math.LinearActivation
+	// linear chain: 1 -> 3 -> 4 -> 5
+	allNodes[2].ConnectFrom(allNodes[0], 2.0)
+	allNodes[3].ConnectFrom(allNodes[2], 3.0)
+	allNodes[4].ConnectFrom(allNodes[3], 0.5)
+	// parallel link: 1 -> 5
+	allNodes[4].ConnectFrom(allNodes[0], 1.0)
+	// direct link: 2 -> 5
+	allNodes[4].ConnectFrom(allNodes[1], -1.5)
+	net := NewNetwork(allNodes[0:2], allNodes[4:5], allNodes, 1)
+
+	pruned, err := net.Prune(true)
+	require.NoError(t, err, "failed to prune")
+	assert.Equal(t, 3, pruned.NodeCount())
+	assert.Equal(t, 2, pruned.LinkCount())
+
+	// the chain folded into the existing link
+	out := pruned.Outputs[0]
+	require.Len(t, out.Incoming, 2)
+	assert.Equal(t, 1, out.Incoming[0].InNode.Id)
+	assert.Equal(t, 4.0, out.Incoming[0].ConnectionWeight)
+	assert.Equal(t, 2, out.Incoming[1].InNode.Id)
+
+	sensors := []float64{0.3, 0.7}
+	expected := activateForOutputs(t, net, sensors, 5)
+	assert.InDeltaSlice(t, expected, activateForOutputs(t, pruned, sensors, 5), 1e-12)
+
+	// without folding only dead nodes are removed
+	pruned, err = net.Prune(false)
+	require.NoError(t, err, "failed to prune")
+	assert.Equal(t, net.NodeCount(), pruned.NodeCount())
+	assert.Equal(t, net.LinkCount(), pruned.LinkCount())
+}
+
+func TestNetwork_Prune_cutNull(t *testing.T) {
+	allNodes := []*NNode{
+		NewNNode(1, InputNeuron),
+		NewNNode(2, InputNeuron),
+		NewNNode(3, HiddenNeuron),
+		NewNNode(4, HiddenNeuron),
+		NewNNode(5, OutputNeuron),
+	}
+	allNodes[2].ActivationType = math.NullActivation
+	allNodes[2].ConnectFrom(allNodes[0], 2.0)
+	allNodes[3].ConnectFrom(allNodes[2], 3.0)
+	allNodes[3].ConnectFrom(allNodes[1], 1.0)
+	allNodes[4].ConnectFrom(allNodes[3], 0.5)
+	net := NewNetwork(allNodes[0:2], allNodes[4:5], allNodes, 1)
+
+	pruned, err := net.Prune(true)
+	require.NoError(t, err, "failed to prune")
+	assert.Equal(t, 4, pruned.NodeCount())
+	assert.Equal(t, 2, pruned.LinkCount())
+	for _, node := range pruned.allNodes {
+		assert.NotEqual(t, 3, node.Id, "null node should be removed")
+	}
+
+	sensors := []float64{0.3, 0.7}
+	expected := activateForOutputs(t, net, sensors, 5)
+	assert.InDeltaSlice(t, expected, activateForOutputs(t, pruned, sensors, 5), 1e-12)
+}
+
+func TestNetwork_Prune_recurrentNotFolded(t *testing.T) {
+	allNodes := []*NNode{
+		NewNNode(1, InputNeuron),
+		NewNNode(2, HiddenNeuron),
+		NewNNode(3, OutputNeuron),
+	}
+	allNodes[1].ActivationType = math.LinearActivation
+	allNodes[1].ConnectFrom(allNodes[0], 2.0)
+	allNodes[1].ConnectFrom(allNodes[1], 0.5).IsRecurrent = true
+	allNodes[2].ConnectFrom(allNodes[1], 1.0)
+	net := NewNetwork(allNodes[0:1], allNodes[2:3], allNodes, 1)
+
+	pruned, err := net.Prune(true)
+	require.NoError(t, err, "failed to prune")
+	assert.Equal(t, net.NodeCount(), pruned.NodeCount())
+	assert.Equal(t, net.LinkCount(), pruned.LinkCount())
+}
+
+func TestNetwork_Prune_modular(t *testing.T) {
+	net := buildModularNetwork()
+
+	pruned, err := net.Prune(true)
+	require.NoError(t, err, "failed to prune")
+	// linear IO nodes of the module must be preserved
+	assert.Equal(t, net.NodeCount(), pruned.NodeCount())
+	assert.Equal(t, net.LinkCount(), pruned.LinkCount())
+	assert.Len(t, pruned.ControlNodes(), 1)
+
+	sensors := []float64{1.0, 2.0, 1.0}
+	expected := activateForOutputs(t, net, sensors, 5)
+	assert.Equal(t, expected, activateForOutputs(t, pruned, sensors, 5))
+}
+
+func TestNetwork_Prune_noOutputs(t *testing.T) {
+	net := NewNetwork([]*NNode{NewNNode(1, InputNeuron)}, nil, []*NNode{NewNNode(1, InputNeuron)}, 1)
+	pruned, err := net.Prune(true)
+	assert.EqualError(t, err, "network has no outputs")
+	assert.Nil(t, pruned)
+}
@@ -0,0 +1,223 @@
+package network
+
+import "fmt"
+
+// NodeState is the snapshot of the activation state of the network node
+type NodeState struct {
+	// The ID of the node
+	Id int `json:"id"`
+	// The node's activation value
+	Activation float64 `json:"activation"`
+	// The number of activations of the node
+	ActivationsCount int32 `json:"activations_count"`
+	// The activation sum
+	ActivationSum float64 `json:"activation_sum"`
+	// The activation value of the node at time t-1
+	LastActivation float64 `json:"last_activation"`
+	// The activation value of the node at time t-2
+	LastActivation2 float64 `json:"last_activation2"`
+	// The flag indicating whether the node is active
+	IsActive bool `json:"is_active"`
+}
+
+// NetworkState is the snapshot of the activation state of all nodes of the Network, including the control nodes.
+// It can be used to branch episodes from the same hidden state of the recurrent network or to resume the network
+// activation later. The state can be serialized into JSON.
+type NetworkState struct {
+	// The ID of the network
+	NetworkId int `json:"network_id"`
+	// The states of the network nodes
+	Nodes []NodeState `json:"nodes"`
+	// The weights of the incoming links of the network nodes in order of nodes. It is set only for the network with
+	// modulatory neurons, which weights are changed by the modulated plasticity.
+	LinkWeights []float64 `json:"link_weights,omitempty"`
+	// The signals held by the delay lines of the time-delayed links in order of nodes, each ordered from the oldest to
+	// the newest. It is set only for the network with time-delayed links.
+	LinkDelayLines [][]float64 `json:"link_delay_lines,omitempty"`
+}
+
+// Snapshot Returns the snapshot of the current activation state of this network
+func (n *Network) Snapshot() *NetworkState {
+	state := &NetworkState{
+		NetworkId: n.Id,
+		Nodes:     make([]NodeState, len(n.allNodesMIMO)),
+	}
+	for i, node := range n.allNodesMIMO {
+		state.Nodes[i] = NodeState{
+			Id:               node.Id,
+			Activation:       node.Activation,
+			ActivationsCount: node.ActivationsCount,
+			ActivationSum:    node.ActivationSum,
+			LastActivation:   node.lastActivation,
+			LastActivation2:  node.lastActivation2,
+			IsActive:         node.isActive,
+		}
+	}
+	for _, link := range n.plasticLinks() {
+		state.LinkWeights = append(state.LinkWeights, link.ConnectionWeight)
+	}
+	for _, link := range n.delayedLinks() {
+		state.LinkDelayLines = append(state.LinkDelayLines, link.getDelayLine().snapshot())
+	}
+	return state
+}
+
+// Restore Restores the activation state of this network from the provided snapshot. Returns error if the snapshot
+// doesn't match the nodes of this network, in this case the network state is left unchanged.
+func (n *Network) Restore(state *NetworkState) error {
+	if len(state.Nodes) != len(n.allNodesMIMO) {
+		return fmt.Errorf("the snapshot has %d nodes, but network has %d nodes", len(state.Nodes), len(n.allNodesMIMO))
+	}
+	nodes := make(map[int]*NNode, len(n.allNodesMIMO))
+	for _, node := range n.allNodesMIMO {
+		nodes[node.Id] = node
+	}
+	for _, ns := range state.Nodes {
+		if _, ok := nodes[ns.Id]; !ok {
+			return fmt.Errorf("the node with ID: %d from the snapshot is not found in the network", ns.Id)
+		}
+	}
+	links := n.plasticLinks()
+	if len(state.LinkWeights) != len(links) {
+		return fmt.Errorf("the snapshot has %d link weights, but network has %d plastic links",
+			len(state.LinkWeights), len(links))
+	}
+	delayed := n.delayedLinks()
+	if len(state.LinkDelayLines) != len(delayed) {
+		return fmt.Errorf("the snapshot has %d delay lines, but network has %d time-delayed links",
+			len(state.LinkDelayLines), len(delayed))
+	}
+	for i, link := range delayed {
+		if len(state.LinkDelayLines[i]) != link.Delay {
+			return fmt.Errorf("the snapshot has delay line of size %d, but link %s has delay %d",
+				len(state.LinkDelayLines[i]), link.IDString(), link.Delay)
+		}
+	}
+	for i, link := range links {
+		link.setPlasticWeight(state.LinkWeights[i])
+	}
+	for i, link := range delayed {
+		link.getDelayLine().restore(state.LinkDelayLines[i])
+	}
+	for _, ns := range state.Nodes {
+		node := nodes[ns.Id]
+		node.Activation = ns.Activation
+		node.ActivationsCount = ns.ActivationsCount
+		node.ActivationSum = ns.ActivationSum
+		node.lastActivation = ns.LastActivation
+		node.lastActivation2 = ns.LastActivation2
+		node.isActive = ns.IsActive
+	}
+	return nil
+}
+
+// plasticLinks Returns the incoming links of all network nodes if network has modulatory neurons, which can change
+// the links weights, otherwise returns nil.
+func (n *Network) plasticLinks() []*Link {
+	modulated := false
+	for _, node := range n.allNodes {
+		if node.NeuronType == ModulatoryNeuron {
+			modulated = true
+			break
+		}
+	}
+	if !modulated {
+		return nil
+	}
+	links := make([]*Link, 0)
+	for _, node := range n.allNodes {
+		links = append(links, node.Incoming...)
+	}
+	return links
+}
+
+// delayedLinks Returns the incoming links of all network nodes which have time delay
+func (n *Network) delayedLinks() []*Link {
+	var links []*Link
+	for _, node := range n.allNodes {
+		for _, link := range node.Incoming {
+			if link.Delay > 0 {
+				links = append(links, link)
+			}
+		}
+	}
+	return links
+}
+
+// SolverState is the snapshot of the activation state of the FastModularNetworkSolver. The state can be serialized
+// into JSON.
+type SolverState struct {
+	// The activation values per each neuron
+	NeuronSignals []float64 `json:"neuron_signals"`
+	// The accumulated signals of neurons being processed
+	NeuronSignalsBeingProcessed []float64 `json:"neuron_signals_being_processed"`
+	// The previous activation values of neurons used by the recursive activation
+	LastActivation []float64 `json:"last_activation"`
+	// The weights of the connections changed by the modulated plasticity. It is set only for the network with
+	// modulatory neurons.
+	Weights []float64 `json:"weights,omitempty"`
+	// The signals held by the delay lines of the time-delayed connections in order of connections, each ordered from
+	// the oldest to the newest. It is set only for the network with time-delayed connections.
+	DelayLines [][]float64 `json:"delay_lines,omitempty"`
+}
+
+// Snapshot Returns the snapshot of the current activation state of this solver
+func (s *FastModularNetworkSolver) Snapshot() *SolverState {
+	return &SolverState{
+		NeuronSignals:               append([]float64(nil), s.neuronSignals...),
+		NeuronSignalsBeingProcessed: append([]float64(nil), s.neuronSignalsBeingProcessed...),
+		LastActivation:              append([]float64(nil), s.lastActivation...),
+		Weights:                     append([]float64(nil), s.weights...),
+		DelayLines:                  s.delayLinesSnapshot(),
+	}
+}
+
+// Restore Restores the activation state of this solver from the provided snapshot. The snapshot can be taken from
+// any solver of the same network, e.g. from another state of the same NetworkModel. Returns error if the snapshot
+// size doesn't match the number of neurons, in this case the solver state is left unchanged.
+func (s *FastModularNetworkSolver) Restore(state *SolverState) error {
+	if len(state.NeuronSignals) != s.totalNeuronCount ||
+		len(state.NeuronSignalsBeingProcessed) != s.totalNeuronCount ||
+		len(state.LastActivation) != s.totalNeuronCount {
+		return fmt.Errorf("the snapshot size doesn't match the number of neurons: %d", s.totalNeuronCount)
+	}
+	if len(state.Weights) != len(s.weights) {
+		return fmt.Errorf("the snapshot has %d weights, but solver has %d plastic connections",
+			len(state.Weights), len(s.weights))
+	}
+	delayed := make([]*delayLine, 0)
+	for _, line := range s.delayLines {
+		if line != nil {
+			delayed = append(delayed, line)
+		}
+	}
+	if len(state.DelayLines) != len(delayed) {
+		return fmt.Errorf("the snapshot has %d delay lines, but solver has %d time-delayed connections",
+			len(state.DelayLines), len(delayed))
+	}
+	for i, line := range delayed {
+		if len(state.DelayLines[i]) != len(line.signals) {
+			return fmt.Errorf("the snapshot has delay line of size %d, but connection has delay %d",
+				len(state.DelayLines[i]), len(line.signals))
+		}
+	}
+	for i, line := range delayed {
+		line.restore(state.DelayLines[i])
+	}
+	copy(s.neuronSignals, state.NeuronSignals)
+	copy(s.weights, state.Weights)
+	copy(s.neuronSignalsBeingProcessed, state.NeuronSignalsBeingProcessed)
+	copy(s.lastActivation, state.LastActivation)
+	return nil
+}
+
+// delayLinesSnapshot Returns the signals of the delay lines of the time-delayed connections or nil if there are none
+func (s *FastModularNetworkSolver) delayLinesSnapshot() [][]float64 {
+	var lines [][]float64
+	for _, line := range s.delayLines {
+		if line != nil {
+			lines = append(lines, line.snapshot())
+		}
+	}
+	return lines
+}
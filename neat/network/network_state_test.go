@@ -0,0 +1,150 @@
+package network
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// buildRecurrentNetwork returns network with recurrent links
+func buildRecurrentNetwork() *Network {
+	net := buildNetwork()
+	allNodes := net.BaseNodes()
+	// recurrent link from OUTPUT 7 to HIDDEN 4
+	allNodes[3].ConnectFrom(allNodes[6], -0.5).IsRecurrent = true
+	// self-recurrent link of HIDDEN 6
+	allNodes[5].ConnectFrom(allNodes[5], 0.25).IsRecurrent = true
+	return net
+}
+
+// runEpisode runs the episode with given inputs activating solver given number of steps per input and returns
+// collected outputs
+func runEpisode(t *testing.T, solver Solver, inputs [][]float64, steps int) [][]float64 {
+	outputs := make([][]float64, len(inputs))
+	for i, in := range inputs {
+		require.NoError(t, solver.LoadSensors(in), "failed to load sensors")
+		_, err := solver.ForwardSteps(steps)
+		require.NoError(t, err, "failed to activate")
+		outputs[i] = solver.ReadOutputs()
+	}
+	return outputs
+}
+
+var episodeInputs = [][]float64{{0.5, 1.1}, {-0.3, 2.0}, {1.0, -1.0}, {0.1, 0.1}}
+
+func TestNetwork_Snapshot_Restore(t *testing.T) {
+	net := buildRecurrentNetwork()
+	runEpisode(t, net, episodeInputs[:2], 1)
+
+	state := net.Snapshot()
+	assert.Equal(t, net.Id, state.NetworkId)
+	require.Len(t, state.Nodes, net.NodeCount())
+
+	expected := runEpisode(t, net, episodeInputs, 1)
+
+	// restore and branch the episode from the same state
+	err := net.Restore(state)
+	require.NoError(t, err, "failed to restore")
+	actual := runEpisode(t, net, episodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	// restore into another network instance after serialization
+	data, err := json.Marshal(state)
+	require.NoError(t, err, "failed to marshal")
+	restoredState := &NetworkState{}
+	err = json.Unmarshal(data, restoredState)
+	require.NoError(t, err, "failed to unmarshal")
+	assert.Equal(t, state, restoredState)
+
+	other := buildRecurrentNetwork()
+	err = other.Restore(restoredState)
+	require.NoError(t, err, "failed to restore")
+	actual = runEpisode(t, other, episodeInputs, 1)
+	assert.Equal(t, expected, actual)
+}
+
+func TestNetwork_Snapshot_Restore_Modular(t *testing.T) {
+	net := buildModularNetwork()
+	runEpisode(t, net, episodeInputs[:1], 3)
+	state := net.Snapshot()
+	require.Len(t, state.Nodes, len(net.AllNodes()))
+
+	expected := runEpisode(t, net, episodeInputs, 3)
+	err := net.Restore(state)
+	require.NoError(t, err, "failed to restore")
+	actual := runEpisode(t, net, episodeInputs, 3)
+	assert.Equal(t, expected, actual)
+}
+
+func TestNetwork_Restore_mismatch(t *testing.T) {
+	net := buildNetwork()
+	state := net.Snapshot()
+
+	other := buildModularNetwork()
+	err := other.Restore(state)
+	assert.Error(t, err, "nodes count mismatch")
+
+	state.Nodes[0].Id = 100
+	state.Nodes[1].Activation = 10
+	err = net.Restore(state)
+	assert.Error(t, err, "unknown node")
+	assert.Equal(t, 0.0, net.BaseNodes()[1].Activation, "state must be unchanged")
+}
+
+func TestFastModularNetworkSolver_Snapshot_Restore(t *testing.T) {
+	net := buildRecurrentNetwork()
+	solver, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create solver")
+	fmm := solver.(*FastModularNetworkSolver)
+	runEpisode(t, fmm, episodeInputs[:2], 1)
+
+	state := fmm.Snapshot()
+	require.Len(t, state.NeuronSignals, fmm.NodeCount())
+	expected := runEpisode(t, fmm, episodeInputs, 1)
+
+	err = fmm.Restore(state)
+	require.NoError(t, err, "failed to restore")
+	actual := runEpisode(t, fmm, episodeInputs, 1)
+	assert.Equal(t, expected, actual)
+
+	// restore into another state of the same model after serialization
+	data, err := json.Marshal(state)
+	require.NoError(t, err, "failed to marshal")
+	restoredState := &SolverState{}
+	err = json.Unmarshal(data, restoredState)
+	require.NoError(t, err, "failed to unmarshal")
+
+	model, err := net.Model()
+	require.NoError(t, err, "failed to create model")
+	other := model.NewState()
+	err = other.Restore(restoredState)
+	require.NoError(t, err, "failed to restore")
+	actual = runEpisode(t, other, episodeInputs, 1)
+	assert.Equal(t, expected, actual)
+}
+
+func TestFastModularNetworkSolver_Snapshot_copy(t *testing.T) {
+	net := buildRecurrentNetwork()
+	solver, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create solver")
+	fmm := solver.(*FastModularNetworkSolver)
+
+	state := fmm.Snapshot()
+	runEpisode(t, fmm, episodeInputs, 1)
+	assert.Equal(t, 1.0, state.NeuronSignals[0], "wrong bias signal")
+	for _, signal := range state.NeuronSignals[1:] {
+		assert.Equal(t, 0.0, signal, "snapshot must not be changed by activation")
+	}
+}
+
+func TestFastModularNetworkSolver_Restore_mismatch(t *testing.T) {
+	solver, err := buildNetwork().FastNetworkSolver()
+	require.NoError(t, err, "failed to create solver")
+	fmm := solver.(*FastModularNetworkSolver)
+	state := fmm.Snapshot()
+	state.LastActivation = state.LastActivation[1:]
+
+	err = fmm.Restore(state)
+	assert.Error(t, err)
+}
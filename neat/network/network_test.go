@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/yaricom/goNEAT/v3/neat/math"
+	"gonum.org/v1/gonum/graph/encoding"
 	"testing"
 )
 
@@ -126,6 +127,60 @@ func TestModularNetwork_Activate(t *testing.T) {
 	assert.Equal(t, 3575.0, net.Outputs[1].Activation)
 }
 
+// buildOutputModuleNetwork returns network with the group of two linear outputs decoded in place by the module with
+// given activation type
+func buildOutputModuleNetwork(aType math.NodeActivationType) *Network {
+	allNodes := []*NNode{
+		NewNNode(1, InputNeuron),
+		NewNNode(2, InputNeuron),
+		NewNNode(3, OutputNeuron),
+		NewNNode(4, OutputNeuron),
+	}
+	controlNodes := []*NNode{
+		NewNNode(5, HiddenNeuron),
+	}
+	controlNodes[0].ActivationType = aType
+	for _, output := range allNodes[2:] {
+		output.ActivationType = math.LinearActivation
+		controlNodes[0].AddIncoming(output, 1.0)
+		controlNodes[0].AddOutgoing(output, 1.0)
+	}
+	// OUTPUT 3
+	allNodes[2].ConnectFrom(allNodes[0], 1.0)
+	// OUTPUT 4
+	allNodes[3].ConnectFrom(allNodes[1], 1.0)
+
+	return NewModularNetwork(allNodes[0:2], allNodes[2:4], allNodes, controlNodes, 0)
+}
+
+func TestModularNetwork_Activate_outputModule(t *testing.T) {
+	data := []float64{1.0, 2.0}
+	testCases := []struct {
+		aType    math.NodeActivationType
+		expected []float64
+	}{
+		{aType: math.SoftmaxModuleActivation, expected: []float64{0.2689414213699951, 0.7310585786300049}},
+		{aType: math.LogSoftmaxModuleActivation, expected: []float64{-1.3132616875182228, -0.3132616875182228}},
+		{aType: math.NormalizeModuleActivation, expected: []float64{1.0 / 3.0, 2.0 / 3.0}},
+		{aType: math.WinnerTakeAllModuleActivation, expected: []float64{0, 1}},
+	}
+	for _, tc := range testCases {
+		net := buildOutputModuleNetwork(tc.aType)
+		require.NoError(t, net.LoadSensors(data), "failed to load sensors")
+		res, err := net.ForwardSteps(2)
+		require.NoError(t, err, "failed to activate: %d", tc.aType)
+		require.True(t, res)
+		assert.InDeltaSlice(t, tc.expected, net.ReadOutputs(), 1e-12, "wrong outputs: %d", tc.aType)
+
+		solver, err := buildOutputModuleNetwork(tc.aType).FastNetworkSolver()
+		require.NoError(t, err, "failed to create fast solver")
+		require.NoError(t, solver.LoadSensors(data), "failed to load sensors")
+		_, err = solver.ForwardSteps(2)
+		require.NoError(t, err, "failed to activate: %d", tc.aType)
+		assert.InDeltaSlice(t, tc.expected, solver.ReadOutputs(), 1e-12, "wrong fast solver outputs: %d", tc.aType)
+	}
+}
+
 // Tests MaxActivationDepth for simple network
 func TestNetwork_MaxActivationDepth_Simple(t *testing.T) {
 	net := buildNetwork()
@@ -340,6 +395,65 @@ func TestNetwork_FastNetworkSolver(t *testing.T) {
 	assert.Equal(t, net.LinkCount(), solver.LinkCount(), "wrong number of links")
 }
 
+func TestNetwork_Activators(t *testing.T) {
+	net := buildPlainNetwork()
+	assert.Same(t, math.NodeActivators, net.Activators())
+
+	// the activation function registered only in the factory of the network
+	factory := math.NodeActivators.Clone()
+	cube, err := factory.RegisterExpression("CubeActivation", "x^3")
+	require.NoError(t, err)
+	for _, node := range net.Outputs {
+		node.ActivationType = cube
+	}
+	sensors := []float64{0.5, -0.2}
+	_, err = net.ForwardSteps(2)
+	assert.Error(t, err, "activation function is unknown to the default factory")
+
+	net.SetActivators(factory)
+	assert.Same(t, factory, net.Activators())
+	// the nodes resolve the name of activation function with the factory of network
+	output := net.Outputs[0]
+	assert.Same(t, factory, output.Activators())
+	assert.Contains(t, output.String(), "CubeActivation")
+	assert.Contains(t, output.PrintDebug(), "CubeActivation")
+	assert.Contains(t, output.Attributes(), encoding.Attribute{Key: "activation_type", Value: "CubeActivation"})
+	require.NoError(t, net.LoadSensors(append(sensors, 1.0)))
+	_, err = net.ForwardSteps(2)
+	require.NoError(t, err, "failed to activate network")
+	expected := net.ReadOutputs()
+	cubed := func(x float64) float64 { return x * x * x }
+	output7 := cubed(-0.2*7.0 + 4.5)
+	assert.InEpsilonSlice(t, []float64{output7, cubed(output7 * 13.0)}, expected, 1e-12)
+
+	fmm, err := net.FastNetworkSolver()
+	require.NoError(t, err, "failed to create fast network solver")
+	require.NoError(t, fmm.LoadSensors(sensors))
+	_, err = fmm.ForwardSteps(2)
+	require.NoError(t, err, "failed to activate fast network solver")
+	assert.InEpsilonSlice(t, expected, fmm.ReadOutputs(), 1e-12)
+
+	layered, err := net.LayeredNetworkSolver()
+	require.NoError(t, err, "failed to create layered network solver")
+	require.NoError(t, layered.LoadSensors(sensors))
+	_, err = layered.ForwardSteps(1)
+	require.NoError(t, err, "failed to activate layered network solver")
+	assert.InEpsilonSlice(t, expected, layered.ReadOutputs(), 1e-12)
+
+	ctrnn, err := net.CTRNNSolver(EulerIntegration)
+	require.NoError(t, err, "failed to create CTRNN solver")
+	require.NoError(t, ctrnn.Advance(0.1))
+
+	pruned, err := net.Prune(true)
+	require.NoError(t, err, "failed to prune network")
+	assert.Same(t, factory, pruned.Activators())
+	assert.Same(t, factory, pruned.Outputs[0].Activators())
+
+	net.SetActivators(nil)
+	assert.Same(t, math.NodeActivators, net.Activators())
+	assert.Same(t, math.NodeActivators, output.Activators())
+}
+
 func TestNetwork_ActivateSteps_zero_activation_steps(t *testing.T) {
 	net := buildNetwork()
 
@@ -7,6 +7,9 @@ import (
 	"github.com/yaricom/goNEAT/v3/neat/math"
 )
 
+// DefaultNodeResponse the response (gain) of the neuron used when NNode.Response is not set
+const DefaultNodeResponse = 1.0
+
 // NNode is either a NEURON or a SENSOR.
 //   - If it's a sensor, it can be loaded with a value for output
 //   - If it's a neuron, it has a list of its incoming input signals ([]*Link is used)
@@ -17,7 +20,7 @@ type NNode struct {
 
 	// The type of node activation function (SIGMOID, ...)
 	ActivationType math.NodeActivationType
-	// The neuron type for this node (HIDDEN, INPUT, OUTPUT, BIAS)
+	// The neuron type for this node (HIDDEN, INPUT, OUTPUT, BIAS, MEMORY, MODULATORY)
 	NeuronType NodeNeuronType
 
 	// The node's activation value
@@ -26,6 +29,15 @@ type NNode struct {
 	ActivationsCount int32
 	// The activation sum
 	ActivationSum float64
+	// The time constant of the neuron dynamics used by the CTRNN solver. If not positive, the DefaultTimeConstant is used.
+	TimeConstant float64
+	// The bias added to the net input of the neuron, which allows expressing bias without links from the bias neurons
+	Bias float64
+	// The response (gain) of the neuron, i.e. the multiplier of its activation sum. If nil, the DefaultNodeResponse
+	// is used (see ActivationResponse and SetResponse).
+	Response *float64
+	// The gate weights of the memory cell neuron (see MemoryNeuron and ActivateMemoryCell), nil for other neuron types
+	GateWeights []float64
 
 	// The list of all incoming connections
 	Incoming []*Link
@@ -42,7 +54,8 @@ type NNode struct {
 
 	/* ************ LEARNING PARAMETERS *********** */
 	// The following parameters are for use in neurons that learn through habituation,
-	// sensitization, or Hebbian-type processes  */
+	// sensitization, or Hebbian-type processes, they also define the shape of the parametric activation functions,
+	// e.g., math.PReLUActivation. Derived from the trait of the node.
 	Params []float64
 
 	// Activation value of node at time t-1; Holds the previous step's activation for recurrency
@@ -54,9 +67,16 @@ type NNode struct {
 
 	// If true the node is active - used during node activation
 	isActive bool
+
+	// The sum of weighted outputs of the modulatory neurons connected to this node during the last activation step
+	modulation float64
+
+	// The factory of activation functions to resolve the name of the activation function of this node, if nil
+	// the default math.NodeActivators is used
+	activators *math.NodeActivatorsFactory
 }
 
-// NewNNode Creates new node with specified ID and neuron type associated (INPUT, HIDDEN, OUTPUT, BIAS)
+// NewNNode Creates new node with specified ID and neuron type associated (INPUT, HIDDEN, OUTPUT, BIAS, MEMORY, MODULATORY)
 func NewNNode(nodeId int, neuronType NodeNeuronType) *NNode {
 	n := NewNetworkNode()
 	n.Id = nodeId
@@ -70,7 +90,17 @@ func NewNNodeCopy(n *NNode, t *neat.Trait) *NNode {
 	node.Id = n.Id
 	node.NeuronType = n.NeuronType
 	node.ActivationType = n.ActivationType
+	node.TimeConstant = n.TimeConstant
+	node.Bias = n.Bias
+	if n.Response != nil {
+		node.SetResponse(*n.Response)
+	}
+	if n.GateWeights != nil {
+		node.GateWeights = append([]float64(nil), n.GateWeights...)
+	}
 	node.Trait = t
+	node.deriveTrait(t)
+	node.activators = n.activators
 	return node
 }
 
@@ -84,6 +114,14 @@ func NewNetworkNode() *NNode {
 	}
 }
 
+// Copy trait parameters into this node's parameters
+func (n *NNode) deriveTrait(t *neat.Trait) {
+	if t != nil {
+		n.Params = make([]float64, len(t.Params))
+		copy(n.Params, t.Params)
+	}
+}
+
 // Set new activation value to this node
 func (n *NNode) setActivation(input float64) {
 	// Keep a memory of activations for potential time delayed connections
@@ -125,7 +163,8 @@ func (n *NNode) IsSensor() bool {
 
 // IsNeuron returns true if this node is NEURON
 func (n *NNode) IsNeuron() bool {
-	return n.NeuronType == HiddenNeuron || n.NeuronType == OutputNeuron
+	return n.NeuronType == HiddenNeuron || n.NeuronType == OutputNeuron || n.NeuronType == MemoryNeuron ||
+		n.NeuronType == ModulatoryNeuron
 }
 
 // SensorLoad If the node is a SENSOR, returns TRUE and loads the value
@@ -179,6 +218,7 @@ func (n *NNode) Flushback() {
 	n.lastActivation2 = 0
 	n.isActive = false
 	n.visited = false
+	n.modulation = 0
 }
 
 // FlushbackCheck is to verify flushing for debugging
@@ -230,6 +270,26 @@ func (n *NNode) Depth(d int, maxDepth int) (int, error) {
 
 }
 
+// ActivationResponse Returns the response (gain) of this neuron, which is DefaultNodeResponse if Response is not set
+func (n *NNode) ActivationResponse() float64 {
+	if n.Response == nil {
+		return DefaultNodeResponse
+	}
+	return *n.Response
+}
+
+// SetResponse Sets the response (gain) of this neuron
+func (n *NNode) SetResponse(response float64) {
+	n.Response = &response
+}
+
+// NetInput Returns the net input of this neuron, i.e. its activation sum scaled by the response and shifted by the bias:
+//
+//	bias + response * sum
+func (n *NNode) NetInput() float64 {
+	return n.Bias + n.ActivationResponse()*n.ActivationSum
+}
+
 // NodeType Convenient method to check network's node type (SENSOR, NEURON)
 func (n *NNode) NodeType() NodeType {
 	if n.IsSensor() {
@@ -238,8 +298,22 @@ func (n *NNode) NodeType() NodeType {
 	return NeuronNode
 }
 
+// SetActivators Sets the factory of activation functions to resolve the name of the activation function of this node.
+// Use nil to reset to the default math.NodeActivators.
+func (n *NNode) SetActivators(activators *math.NodeActivatorsFactory) {
+	n.activators = activators
+}
+
+// Activators Returns the factory of activation functions of this node
+func (n *NNode) Activators() *math.NodeActivatorsFactory {
+	if n.activators != nil {
+		return n.activators
+	}
+	return math.NodeActivators
+}
+
 func (n *NNode) String() string {
-	activation, _ := math.NodeActivators.ActivationNameFromType(n.ActivationType)
+	activation, _ := n.Activators().ActivationNameFromType(n.ActivationType)
 	active := "active"
 	if !n.isActive {
 		active = "inactive"
@@ -256,11 +330,14 @@ func (n *NNode) PrintDebug() string {
 	_, _ = fmt.Fprintf(b, "\tId: %d\n", n.Id)
 	_, _ = fmt.Fprintf(b, "\tIsActive: %t\n", n.isActive)
 	_, _ = fmt.Fprintf(b, "\tActivation: %f\n", n.Activation)
-	activation, _ := math.NodeActivators.ActivationNameFromType(n.ActivationType)
+	activation, _ := n.Activators().ActivationNameFromType(n.ActivationType)
 	_, _ = fmt.Fprintf(b, "\tActivation Type: %s\n", activation)
 	_, _ = fmt.Fprintf(b, "\tNeuronType: %d\n", n.NeuronType)
 	_, _ = fmt.Fprintf(b, "\tActivationsCount: %d\n", n.ActivationsCount)
 	_, _ = fmt.Fprintf(b, "\tActivationSum: %f\n", n.ActivationSum)
+	_, _ = fmt.Fprintf(b, "\tBias: %f\n", n.Bias)
+	_, _ = fmt.Fprintf(b, "\tResponse: %f\n", n.ActivationResponse())
+	_, _ = fmt.Fprintf(b, "\tGateWeights: %f\n", n.GateWeights)
 	_, _ = fmt.Fprintf(b, "\tIncoming: %s\n", n.Incoming)
 	_, _ = fmt.Fprintf(b, "\tOutgoing: %s\n", n.Outgoing)
 	_, _ = fmt.Fprintf(b, "\tTrait: %s\n", n.Trait)
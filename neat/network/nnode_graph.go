@@ -2,7 +2,6 @@ package network
 
 import (
 	"fmt"
-	"github.com/yaricom/goNEAT/v3/neat/math"
 	"gonum.org/v1/gonum/graph/encoding"
 )
 
@@ -20,7 +19,7 @@ func (n *NNode) Attributes() []encoding.Attribute {
 		Value: NeuronTypeName(n.NeuronType),
 	}}
 
-	if activationFunc, err := math.NodeActivators.ActivationNameFromType(n.ActivationType); err == nil {
+	if activationFunc, err := n.Activators().ActivationNameFromType(n.ActivationType); err == nil {
 		attrs = append(attrs, encoding.Attribute{
 			Key:   "activation_type",
 			Value: activationFunc,
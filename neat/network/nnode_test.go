@@ -21,6 +21,10 @@ func TestNewNNode(t *testing.T) {
 
 func TestNewNNodeCopy(t *testing.T) {
 	node := NewNNode(1, InputNeuron)
+	node.TimeConstant = 0.5
+	node.Bias = -0.3
+	node.SetResponse(2.5)
+	node.GateWeights = []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}
 	trait := &neat.Trait{Id: 1, Params: []float64{1.1, 2.3, 3.4, 4.2, 5.5, 6.7}}
 
 	nodeCopy := NewNNodeCopy(node, trait)
@@ -29,7 +33,17 @@ func TestNewNNodeCopy(t *testing.T) {
 	assert.Equal(t, node.Id, nodeCopy.Id)
 	assert.Equal(t, node.ActivationType, nodeCopy.ActivationType)
 	assert.Equal(t, node.NeuronType, nodeCopy.NeuronType)
+	assert.Equal(t, node.TimeConstant, nodeCopy.TimeConstant)
+	assert.Equal(t, node.Bias, nodeCopy.Bias)
+	assert.Equal(t, node.Response, nodeCopy.Response)
+	assert.NotSame(t, node.Response, nodeCopy.Response, "response must be copied")
+	assert.Equal(t, node.GateWeights, nodeCopy.GateWeights)
+	nodeCopy.GateWeights[0] = 10
+	assert.Equal(t, 1.0, node.GateWeights[0], "gate weights must be copied")
 	assert.Equal(t, trait, nodeCopy.Trait)
+	assert.Equal(t, trait.Params, nodeCopy.Params)
+	nodeCopy.Params[0] = 10
+	assert.Equal(t, 1.1, trait.Params[0], "trait parameters must be copied")
 	assert.NotNil(t, node.Incoming)
 	assert.NotNil(t, node.Outgoing)
 }
@@ -44,6 +58,23 @@ func TestNewNetworkNode(t *testing.T) {
 	assert.NotNil(t, node.Outgoing)
 }
 
+func TestNNode_NetInput(t *testing.T) {
+	node := NewNNode(1, HiddenNeuron)
+	node.ActivationSum = 2.0
+	assert.Equal(t, DefaultNodeResponse, node.ActivationResponse())
+	assert.Equal(t, 2.0, node.NetInput(), "default bias and response must not change activation sum")
+
+	node.Bias = 0.5
+	node.SetResponse(-1.5)
+	assert.Equal(t, -1.5, node.ActivationResponse())
+	assert.Equal(t, -2.5, node.NetInput())
+
+	// the zero response is not replaced with default
+	node.SetResponse(0)
+	assert.Equal(t, 0.0, node.ActivationResponse())
+	assert.Equal(t, 0.5, node.NetInput())
+}
+
 // Tests NNode SensorLoad
 func TestNNode_SensorLoad(t *testing.T) {
 	node := NewNNode(1, InputNeuron)
@@ -236,6 +267,13 @@ func TestNNode_IsSensor(t *testing.T) {
 			nType:    OutputNeuron,
 			isSensor: false,
 		},
+		{
+			nType:    MemoryNeuron,
+			isSensor: false,
+		}, {
+			nType:    ModulatoryNeuron,
+			isSensor: false,
+		},
 	}
 	for i, tc := range testCases {
 		node := NewNNode(1, tc.nType)
@@ -264,6 +302,13 @@ func TestNNode_IsNeuron(t *testing.T) {
 			nType:    OutputNeuron,
 			isNeuron: true,
 		},
+		{
+			nType:    MemoryNeuron,
+			isNeuron: true,
+		}, {
+			nType:    ModulatoryNeuron,
+			isNeuron: true,
+		},
 	}
 	for i, tc := range testCases {
 		node := NewNNode(1, tc.nType)
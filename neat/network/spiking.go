@@ -0,0 +1,571 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"math"
+)
+
+// DefaultSpikingTimeStep the default simulation time step (in milliseconds) used by the spiking solver for the Solver
+// interface methods
+const DefaultSpikingTimeStep = 1.0
+
+// DefaultSpikingDecodingWindow the default number of the most recent simulation steps over which the spike rates of
+// the output neurons are decoded
+const DefaultSpikingDecodingWindow = 50
+
+// DefaultSpikingEncodingWindow the default duration (in milliseconds) of the window of the temporal input encoding
+const DefaultSpikingEncodingWindow = 20.0
+
+// DefaultMaxFiringRate the default firing rate (spikes per millisecond) of the sensor with maximal input value under
+// the rate input encoding
+const DefaultMaxFiringRate = 0.5
+
+// SpikingNeuronModel defines the model of the spiking neuron dynamics
+type SpikingNeuronModel byte
+
+const (
+	// LeakyIntegrateAndFire the leaky integrate-and-fire neuron model
+	LeakyIntegrateAndFire SpikingNeuronModel = iota
+	// Izhikevich the Izhikevich neuron model (Izhikevich, 2003)
+	Izhikevich
+)
+
+// SpikeEncoding defines the method to encode the sensor values into the spike trains
+type SpikeEncoding byte
+
+const (
+	// RateEncoding the sensor fires regularly with the rate proportional to its value
+	RateEncoding SpikeEncoding = iota
+	// TemporalEncoding the sensor fires once per encoding window with the latency inversely proportional to its value
+	TemporalEncoding
+)
+
+// The parameters of the neuron models. The trait parameters (see neat.Trait) of the neuron are clamped to the [0, 1]
+// range and mapped linearly onto the range of each model parameter, thus the neuron with zero trait parameters is the
+// regular spiking neuron.
+const (
+	// izhikevichMinA, izhikevichMaxA the time scale of the recovery variable, [0.02, 0.1], from regular to fast spiking
+	izhikevichMinA, izhikevichMaxA = 0.02, 0.1
+	// izhikevichMinB, izhikevichMaxB the sensitivity of the recovery variable, [0.2, 0.25], from regular to low-threshold spiking
+	izhikevichMinB, izhikevichMaxB = 0.2, 0.25
+	// izhikevichMinC, izhikevichMaxC the after-spike reset value of the membrane potential, [-65, -50], from regular spiking to chattering
+	izhikevichMinC, izhikevichMaxC = -65.0, -50.0
+	// izhikevichMinD, izhikevichMaxD the after-spike reset of the recovery variable, [8, 2], from regular to fast spiking
+	izhikevichMinD, izhikevichMaxD = 8.0, 2.0
+	// izhikevichThreshold the peak of the spike
+	izhikevichThreshold = 30.0
+
+	// lifMinTimeConstant, lifMaxTimeConstant the range of the membrane time constant (ms)
+	lifMinTimeConstant, lifMaxTimeConstant = 10.0, 50.0
+	// lifMinThreshold, lifMaxThreshold the range of the firing threshold of the membrane potential
+	lifMinThreshold, lifMaxThreshold = 1.0, 2.0
+	// lifMinRefractoryPeriod, lifMaxRefractoryPeriod the range of the refractory period after spike (ms)
+	lifMinRefractoryPeriod, lifMaxRefractoryPeriod = 0.0, 5.0
+
+	// izhikevichCurrentScale the current injected by presynaptic spike per unit of connection weight
+	izhikevichCurrentScale = 30.0
+	// lifCurrentScale the current injected by presynaptic spike per unit of connection weight
+	lifCurrentScale = 10.0
+)
+
+// IzhikevichParams the parameters of the Izhikevich neuron model:
+//
+//	dv/dt = 0.04 * v^2 + 5 * v + 140 - u + I
+//	du/dt = a * (b * v - u)
+//	if v >= 30 then v = c, u = u + d
+type IzhikevichParams struct {
+	A, B, C, D float64
+}
+
+// NewIzhikevichParams Creates the parameters of the Izhikevich neuron from the first four parameters of the given
+// trait. If trait is nil or has fewer parameters, the missing ones are treated as zero.
+func NewIzhikevichParams(trait *neat.Trait) IzhikevichParams {
+	return IzhikevichParams{
+		A: traitParamInRange(trait, 0, izhikevichMinA, izhikevichMaxA),
+		B: traitParamInRange(trait, 1, izhikevichMinB, izhikevichMaxB),
+		C: traitParamInRange(trait, 2, izhikevichMinC, izhikevichMaxC),
+		D: traitParamInRange(trait, 3, izhikevichMinD, izhikevichMaxD),
+	}
+}
+
+// LIFParams the parameters of the leaky integrate-and-fire neuron model:
+//
+//	tau * dv/dt = -v + I
+//	if v >= threshold then v = 0 and neuron is silent during the refractory period
+type LIFParams struct {
+	TimeConstant, Threshold, RefractoryPeriod float64
+}
+
+// NewLIFParams Creates the parameters of the leaky integrate-and-fire neuron from the first three parameters of the
+// given trait. If trait is nil or has fewer parameters, the missing ones are treated as zero.
+func NewLIFParams(trait *neat.Trait) LIFParams {
+	return LIFParams{
+		TimeConstant:     traitParamInRange(trait, 0, lifMinTimeConstant, lifMaxTimeConstant),
+		Threshold:        traitParamInRange(trait, 1, lifMinThreshold, lifMaxThreshold),
+		RefractoryPeriod: traitParamInRange(trait, 2, lifMinRefractoryPeriod, lifMaxRefractoryPeriod),
+	}
+}
+
+// SpikingSolver is the network solver simulating the network of spiking neurons. The hidden and output neurons are
+// the leaky integrate-and-fire or Izhikevich neurons with parameters derived from the neuron's trait. The sensors
+// encode the loaded values into the spike trains with the rate or temporal encoding, and the bias neurons fire at
+// every step. Each presynaptic spike injects the current proportional to the connection weight and the response of
+// the target neuron into the target neuron during the next simulation step, or Link.Delay steps later for the
+// time-delayed links. The bias of the neuron is injected as the constant current.
+//
+// The network is simulated with Step by the given time step in milliseconds. The outputs are decoded as the fraction of
+// the most recent DecodingWindow steps when the output neuron fired, i.e. the value in [0, 1] range. The ForwardSteps
+// and Relax methods of the Solver interface simulate the network by TimeStep per step.
+type SpikingSolver struct {
+	// A network id
+	Id int
+	// Is a name of this network
+	Name string
+	// The model of the spiking neurons
+	Model SpikingNeuronModel
+	// The input encoding
+	Encoding SpikeEncoding
+	// The simulation time step used by ForwardSteps and Relax
+	TimeStep float64
+	// The firing rate (spikes per millisecond) of the sensor with value 1 under the rate encoding
+	MaxFiringRate float64
+	// The duration of the window of the temporal encoding
+	EncodingWindow float64
+
+	// The connections between neurons
+	connections []ctrnnConnection
+	// The current injected by presynaptic spike per unit of connection weight
+	currentScale float64
+	// The constant bias currents per neuron
+	biases []float64
+	// The delay lines of spikes per connection, has nil entries for connections without delay. It is nil if network
+	// has no time-delayed links.
+	delayLines []*delayLine
+
+	// The number of bias neurons. This is also the index of the first input neuron.
+	biasNeuronCount int
+	// The total number of sensors (bias + input). This is also the index of the first output neuron.
+	sensorNeuronCount int
+	// The number of output neurons
+	outputNeuronCount int
+
+	// The parameters of Izhikevich neurons, nil for LIF model
+	izhikevichParams []IzhikevichParams
+	// The parameters of LIF neurons, nil for Izhikevich model
+	lifParams []LIFParams
+
+	// The membrane potential per neuron
+	potentials []float64
+	// The recovery variable of Izhikevich neurons or the remaining refractory time of LIF neurons
+	recovery []float64
+	// The spikes emitted by neurons during the last step
+	spikes []bool
+	// The input currents of neurons for the next step
+	currents []float64
+
+	// The loaded sensor values
+	inputs []float64
+	// The phase accumulators of the rate encoding per sensor
+	ratePhases []float64
+	// The flags indicating which sensors already fired in the current window of the temporal encoding
+	temporalFired []bool
+	// The time elapsed within the current window of the temporal encoding
+	windowTime float64
+
+	// The ring buffer of the output spikes over the decoding window
+	outputSpikes [][]bool
+	// The position of the next step in the decoding ring buffer
+	decodingPosition int
+	// The number of steps recorded in the decoding ring buffer
+	decodingSteps int
+	// The simulation time elapsed since the last flush
+	time float64
+}
+
+// SpikingSolver Creates the spiking neural network solver based on the architecture of this network with provided
+// neuron model. The network modules (control nodes), memory cell and modulatory neurons are not supported.
+func (n *Network) SpikingSolver(model SpikingNeuronModel) (*SpikingSolver, error) {
+	if len(n.controlNodes) > 0 {
+		return nil, errors.New("spiking solver doesn't support network modules")
+	}
+	if model != LeakyIntegrateAndFire && model != Izhikevich {
+		return nil, fmt.Errorf("unsupported spiking neuron model: %d", model)
+	}
+	biasList, inList, hiddenList := make([]*NNode, 0), make([]*NNode, 0), make([]*NNode, 0)
+	for _, ne := range n.allNodes {
+		switch ne.NeuronType {
+		case BiasNeuron:
+			biasList = append(biasList, ne)
+		case InputNeuron:
+			inList = append(inList, ne)
+		case HiddenNeuron:
+			hiddenList = append(hiddenList, ne)
+		case MemoryNeuron:
+			return nil, errors.New("spiking solver doesn't support memory cell neurons")
+		case ModulatoryNeuron:
+			return nil, errors.New("spiking solver doesn't support modulatory neurons")
+		}
+	}
+	totalNeuronCount := len(n.allNodes)
+	s := &SpikingSolver{
+		Id:                n.Id,
+		Name:              n.Name,
+		Model:             model,
+		Encoding:          RateEncoding,
+		TimeStep:          DefaultSpikingTimeStep,
+		MaxFiringRate:     DefaultMaxFiringRate,
+		EncodingWindow:    DefaultSpikingEncodingWindow,
+		biasNeuronCount:   len(biasList),
+		sensorNeuronCount: len(biasList) + len(inList),
+		outputNeuronCount: len(n.Outputs),
+		potentials:        make([]float64, totalNeuronCount),
+		recovery:          make([]float64, totalNeuronCount),
+		spikes:            make([]bool, totalNeuronCount),
+		currents:          make([]float64, totalNeuronCount),
+		biases:            make([]float64, totalNeuronCount),
+		inputs:            make([]float64, len(inList)),
+		ratePhases:        make([]float64, len(inList)),
+		temporalFired:     make([]bool, len(inList)),
+	}
+	switch model {
+	case LeakyIntegrateAndFire:
+		s.currentScale = lifCurrentScale
+		s.lifParams = make([]LIFParams, totalNeuronCount)
+	case Izhikevich:
+		s.currentScale = izhikevichCurrentScale
+		s.izhikevichParams = make([]IzhikevichParams, totalNeuronCount)
+	}
+	if err := s.SetDecodingWindow(DefaultSpikingDecodingWindow); err != nil {
+		return nil, err
+	}
+
+	// neurons are ordered as following: bias, input, output, hidden
+	neuronLookup := make(map[int]int)
+	index := 0
+	for _, list := range [][]*NNode{biasList, inList, n.Outputs, hiddenList} {
+		for _, ne := range list {
+			neuronLookup[ne.Id] = index
+			s.biases[index] = ne.Bias * s.currentScale
+			if s.lifParams != nil {
+				s.lifParams[index] = NewLIFParams(ne.Trait)
+			} else {
+				s.izhikevichParams[index] = NewIzhikevichParams(ne.Trait)
+			}
+			index++
+		}
+	}
+	if index != totalNeuronCount {
+		return nil, fmt.Errorf("the number of ordered neurons: %d doesn't match the total number of neurons: %d",
+			index, totalNeuronCount)
+	}
+	delays := make([]int, 0)
+	for _, ne := range n.allNodes {
+		if ne.IsSensor() {
+			// the sensors spikes are defined by the loaded values only
+			continue
+		}
+		for _, in := range ne.Incoming {
+			source, ok := neuronLookup[in.InNode.Id]
+			if !ok {
+				return nil, fmt.Errorf("failed to lookup for source neuron with id: %d", in.InNode.Id)
+			}
+			// the response of the neuron is folded into the weights of its incoming connections
+			s.connections = append(s.connections, ctrnnConnection{
+				source: source,
+				target: neuronLookup[ne.Id],
+				weight: in.ConnectionWeight * ne.ActivationResponse(),
+			})
+			delays = append(delays, in.Delay)
+		}
+	}
+	s.delayLines = newDelayLines(delays)
+	s.Flush()
+	return s, nil
+}
+
+// SetDecodingWindow Sets the number of the most recent simulation steps over which the spike rates of the output
+// neurons are decoded. The recorded output spikes are discarded.
+func (s *SpikingSolver) SetDecodingWindow(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("the decoding window must be positive: %d", steps)
+	}
+	s.outputSpikes = make([][]bool, steps)
+	for i := range s.outputSpikes {
+		s.outputSpikes[i] = make([]bool, s.outputNeuronCount)
+	}
+	s.decodingPosition, s.decodingSteps = 0, 0
+	return nil
+}
+
+// DecodingWindow Returns the number of the most recent simulation steps over which the spike rates of the output
+// neurons are decoded
+func (s *SpikingSolver) DecodingWindow() int {
+	return len(s.outputSpikes)
+}
+
+// Step Simulates the network over the given time step dt (in milliseconds). During the step the sensors emit spikes
+// according to the input encoding, the neurons integrate the currents injected by the spikes of the previous step and
+// fire when their membrane potential reaches the threshold.
+func (s *SpikingSolver) Step(dt float64) error {
+	if dt <= 0 || math.IsNaN(dt) || math.IsInf(dt, 0) {
+		return fmt.Errorf("the time step must be positive: %f", dt)
+	}
+	// the currents injected by the spikes emitted during the previous step
+	copy(s.currents, s.biases)
+	for i, c := range s.connections {
+		spiked := s.spikes[c.source]
+		if s.delayLines != nil && s.delayLines[i] != nil {
+			// the spike arrives through the delay line of the time-delayed link
+			signal := 0.0
+			if spiked {
+				signal = 1.0
+			}
+			spiked = s.delayLines[i].shift(signal) > 0
+		}
+		if spiked {
+			s.currents[c.target] += c.weight * s.currentScale
+		}
+	}
+
+	for i := 0; i < s.biasNeuronCount; i++ {
+		s.spikes[i] = true
+	}
+	s.encodeInputs(dt)
+	for i := s.sensorNeuronCount; i < len(s.potentials); i++ {
+		if s.lifParams != nil {
+			s.spikes[i] = s.stepLIF(i, dt)
+		} else {
+			s.spikes[i] = s.stepIzhikevich(i, dt)
+		}
+	}
+
+	copy(s.outputSpikes[s.decodingPosition], s.spikes[s.sensorNeuronCount:s.sensorNeuronCount+s.outputNeuronCount])
+	s.decodingPosition = (s.decodingPosition + 1) % len(s.outputSpikes)
+	if s.decodingSteps < len(s.outputSpikes) {
+		s.decodingSteps++
+	}
+	s.time += dt
+	return nil
+}
+
+// ForwardSteps Simulates the network given number of steps by TimeStep each.
+// Returns true if network was simulated successfully.
+func (s *SpikingSolver) ForwardSteps(steps int) (bool, error) {
+	if steps <= 0 {
+		return false, ErrZeroActivationStepsRequested
+	}
+	for i := 0; i < steps; i++ {
+		if err := s.Step(s.TimeStep); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// RecursiveSteps is not supported by the spiking solver, because its dynamics is defined over time
+func (s *SpikingSolver) RecursiveSteps() (bool, error) {
+	return false, errors.New("recursive activation is not supported by spiking solver")
+}
+
+// Relax Simulates the network by TimeStep per step until the absolute change of the decoded spike rate of any output
+// neuron during the step is less than maxAllowedSignalDelta or maxSteps reached. The network is not considered relaxed
+// before the decoding window is filled. Returns true if network is relaxed.
+func (s *SpikingSolver) Relax(maxSteps int, maxAllowedSignalDelta float64) (bool, error) {
+	previous := s.ReadOutputs()
+	for step := 0; step < maxSteps; step++ {
+		if err := s.Step(s.TimeStep); err != nil {
+			return false, err
+		}
+		current := s.ReadOutputs()
+		relaxed := s.decodingSteps == len(s.outputSpikes)
+		for i := 0; i < len(current) && relaxed; i++ {
+			relaxed = math.Abs(current[i]-previous[i]) <= maxAllowedSignalDelta
+		}
+		if relaxed {
+			return true, nil
+		}
+		previous = current
+	}
+	return false, nil
+}
+
+// Flush Resets the state of all neurons to rest, discards the recorded output spikes and restarts the input encoding.
+// The loaded sensor values are preserved.
+func (s *SpikingSolver) Flush() (bool, error) {
+	for i := range s.potentials {
+		s.spikes[i] = false
+		s.currents[i] = 0
+		if s.izhikevichParams != nil {
+			s.potentials[i] = s.izhikevichParams[i].C
+			s.recovery[i] = s.izhikevichParams[i].B * s.potentials[i]
+		} else {
+			s.potentials[i] = 0
+			s.recovery[i] = 0
+		}
+	}
+	for i := range s.ratePhases {
+		s.ratePhases[i] = 0
+		s.temporalFired[i] = false
+	}
+	s.windowTime = 0
+	for _, spikes := range s.outputSpikes {
+		for i := range spikes {
+			spikes[i] = false
+		}
+	}
+	for _, line := range s.delayLines {
+		if line != nil {
+			line.flush()
+		}
+	}
+	s.decodingPosition, s.decodingSteps = 0, 0
+	s.time = 0
+	return true, nil
+}
+
+// LoadSensors Set sensors values to the input nodes of the network. The values are expected to be in [0, 1] range
+// and clamped to it during encoding. Loading of the sensors restarts the window of the temporal encoding.
+func (s *SpikingSolver) LoadSensors(inputs []float64) error {
+	if len(inputs) == len(s.inputs) {
+		copy(s.inputs, inputs)
+	} else {
+		return ErrNetUnsupportedSensorsArraySize
+	}
+	for i := range s.temporalFired {
+		s.temporalFired[i] = false
+	}
+	s.windowTime = 0
+	return nil
+}
+
+// ReadOutputs Read output values from the output nodes of the network, i.e. the fraction of the steps within the
+// decoding window when each output neuron fired. If no steps were simulated yet, all outputs are zero.
+func (s *SpikingSolver) ReadOutputs() []float64 {
+	outputs := make([]float64, s.outputNeuronCount)
+	if s.decodingSteps == 0 {
+		return outputs
+	}
+	for _, spikes := range s.outputSpikes {
+		for i, spike := range spikes {
+			if spike {
+				outputs[i]++
+			}
+		}
+	}
+	for i := range outputs {
+		outputs[i] /= float64(s.decodingSteps)
+	}
+	return outputs
+}
+
+// Spikes Returns the copy of the spikes emitted by all neurons during the last step in order: bias, input, output, hidden
+func (s *SpikingSolver) Spikes() []bool {
+	return append([]bool(nil), s.spikes...)
+}
+
+// Potentials Returns the copy of the current membrane potentials of all neurons in order: bias, input, output, hidden
+func (s *SpikingSolver) Potentials() []float64 {
+	return append([]float64(nil), s.potentials...)
+}
+
+// Time Returns the simulation time elapsed since the last flush
+func (s *SpikingSolver) Time() float64 {
+	return s.time
+}
+
+// NodeCount Returns the total number of neural units in the network
+func (s *SpikingSolver) NodeCount() int {
+	return len(s.potentials)
+}
+
+// LinkCount Returns the total number of links between nodes in the network
+func (s *SpikingSolver) LinkCount() int {
+	return len(s.connections)
+}
+
+func (s *SpikingSolver) String() string {
+	return fmt.Sprintf("Spiking solver, id: %d, name: [%s], neurons: %d, links: %d, model: %d, encoding: %d, time step: %f",
+		s.Id, s.Name, s.NodeCount(), s.LinkCount(), s.Model, s.Encoding, s.TimeStep)
+}
+
+// encodeInputs Emits the spikes of the input neurons during the step of given duration according to the input encoding
+func (s *SpikingSolver) encodeInputs(dt float64) {
+	switch s.Encoding {
+	case RateEncoding:
+		for i, value := range s.inputs {
+			s.ratePhases[i] += clampUnit(value) * s.MaxFiringRate * dt
+			fired := s.ratePhases[i] >= 1
+			if fired {
+				s.ratePhases[i] -= math.Floor(s.ratePhases[i])
+			}
+			s.spikes[s.biasNeuronCount+i] = fired
+		}
+	case TemporalEncoding:
+		s.windowTime += dt
+		if s.windowTime > s.EncodingWindow {
+			s.windowTime = math.Mod(s.windowTime, s.EncodingWindow)
+			for i := range s.temporalFired {
+				s.temporalFired[i] = false
+			}
+		}
+		for i, value := range s.inputs {
+			value = clampUnit(value)
+			fired := !s.temporalFired[i] && value > 0 && s.windowTime >= (1-value)*s.EncodingWindow
+			if fired {
+				s.temporalFired[i] = true
+			}
+			s.spikes[s.biasNeuronCount+i] = fired
+		}
+	}
+}
+
+// stepLIF Integrates the leaky integrate-and-fire neuron at given index over the time step. Returns true if neuron fired.
+func (s *SpikingSolver) stepLIF(i int, dt float64) bool {
+	params := s.lifParams[i]
+	if s.recovery[i] > 0 {
+		// the neuron is in refractory period
+		s.recovery[i] -= dt
+		return false
+	}
+	s.potentials[i] += dt / params.TimeConstant * (-s.potentials[i] + s.currents[i])
+	if s.potentials[i] >= params.Threshold {
+		s.potentials[i] = 0
+		s.recovery[i] = params.RefractoryPeriod
+		return true
+	}
+	return false
+}
+
+// stepIzhikevich Integrates the Izhikevich neuron at given index over the time step. The membrane potential is
+// integrated with two half steps for numerical stability as in the original model. Returns true if neuron fired.
+func (s *SpikingSolver) stepIzhikevich(i int, dt float64) bool {
+	params := s.izhikevichParams[i]
+	v, u, current := s.potentials[i], s.recovery[i], s.currents[i]
+	for h := 0; h < 2 && v < izhikevichThreshold; h++ {
+		v += dt / 2 * (0.04*v*v + 5*v + 140 - u + current)
+	}
+	u += dt * params.A * (params.B*v - u)
+	fired := v >= izhikevichThreshold
+	if fired {
+		v = params.C
+		u += params.D
+	}
+	s.potentials[i], s.recovery[i] = v, u
+	return fired
+}
+
+// traitParamInRange Maps the trait parameter at given index clamped to [0, 1] onto the range [min, max]
+func traitParamInRange(trait *neat.Trait, index int, min, max float64) float64 {
+	value := 0.0
+	if trait != nil && index < len(trait.Params) {
+		value = clampUnit(trait.Params[index])
+	}
+	return min + value*(max-min)
+}
+
+// clampUnit Clamps the value to [0, 1] range
+func clampUnit(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
@@ -0,0 +1,197 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yaricom/goNEAT/v3/neat"
+	"testing"
+)
+
+// buildSpikingRelayNetwork returns network with single input connected to the output with given weight
+func buildSpikingRelayNetwork(weight float64) *Network {
+	input := NewNNode(1, InputNeuron)
+	output := NewNNode(2, OutputNeuron)
+	output.ConnectFrom(input, weight)
+	return NewNetwork([]*NNode{input}, []*NNode{output}, []*NNode{input, output}, 1)
+}
+
+func TestNetwork_SpikingSolver(t *testing.T) {
+	net := buildNetwork()
+	solver, err := net.SpikingSolver(Izhikevich)
+	require.NoError(t, err, "failed to create solver")
+	require.NotNil(t, solver)
+
+	assert.Equal(t, net.Id, solver.Id)
+	assert.Equal(t, net.Name, solver.Name)
+	assert.Equal(t, Izhikevich, solver.Model)
+	assert.Equal(t, RateEncoding, solver.Encoding)
+	assert.Equal(t, DefaultSpikingTimeStep, solver.TimeStep)
+	assert.Equal(t, DefaultSpikingDecodingWindow, solver.DecodingWindow())
+	assert.Equal(t, 8, solver.NodeCount())
+	assert.Equal(t, 8, solver.LinkCount())
+	assert.NotEmpty(t, solver.String())
+
+	// check that neurons are at rest
+	for _, v := range solver.Potentials()[solver.sensorNeuronCount:] {
+		assert.Equal(t, izhikevichMinC, v)
+	}
+	assert.Equal(t, []float64{0, 0}, solver.ReadOutputs())
+}
+
+func TestNetwork_SpikingSolver_errors(t *testing.T) {
+	net := buildModularNetwork()
+	solver, err := net.SpikingSolver(LeakyIntegrateAndFire)
+	assert.Error(t, err, "modular network must not be supported")
+	assert.Nil(t, solver)
+
+	net = buildNetwork()
+	solver, err = net.SpikingSolver(SpikingNeuronModel(10))
+	assert.Error(t, err, "unsupported neuron model")
+	assert.Nil(t, solver)
+}
+
+func TestNewIzhikevichParams(t *testing.T) {
+	params := NewIzhikevichParams(nil)
+	assert.Equal(t, IzhikevichParams{A: 0.02, B: 0.2, C: -65, D: 8}, params, "regular spiking expected")
+
+	trait := neat.NewTrait()
+	trait.Params[0], trait.Params[1], trait.Params[2], trait.Params[3] = 1, 0.5, 2, 1
+	params = NewIzhikevichParams(trait)
+	assert.InDelta(t, 0.1, params.A, 1e-12)
+	assert.InDelta(t, 0.225, params.B, 1e-12)
+	assert.InDelta(t, -50, params.C, 1e-12, "the parameter must be clamped")
+	assert.InDelta(t, 2, params.D, 1e-12)
+}
+
+func TestNewLIFParams(t *testing.T) {
+	params := NewLIFParams(&neat.Trait{Params: []float64{0.5}})
+	assert.Equal(t, LIFParams{TimeConstant: 30, Threshold: 1, RefractoryPeriod: 0}, params)
+}
+
+func TestSpikingSolver_Step(t *testing.T) {
+	for _, model := range []SpikingNeuronModel{LeakyIntegrateAndFire, Izhikevich} {
+		solver, err := buildSpikingRelayNetwork(1.0).SpikingSolver(model)
+		require.NoError(t, err, "failed to create solver")
+		err = solver.SetDecodingWindow(200)
+		require.NoError(t, err, "failed to set decoding window")
+
+		previous := -1.0
+		for _, input := range []float64{0, 0.25, 0.5, 0.75, 1} {
+			_, err = solver.Flush()
+			require.NoError(t, err, "failed to flush")
+			err = solver.LoadSensors([]float64{input})
+			require.NoError(t, err, "failed to load sensors")
+			for i := 0; i < 200; i++ {
+				err = solver.Step(1.0)
+				require.NoError(t, err, "failed to step at: %d", i)
+			}
+			assert.Equal(t, 200.0, solver.Time())
+			outputs := solver.ReadOutputs()
+			require.Len(t, outputs, 1)
+			if input == 0 {
+				assert.Zero(t, outputs[0], "model: %d", model)
+			} else {
+				assert.Greater(t, outputs[0], previous, "the spike rate must grow with input, model: %d", model)
+			}
+			previous = outputs[0]
+		}
+	}
+}
+
+func TestSpikingSolver_Step_error(t *testing.T) {
+	solver, err := buildSpikingRelayNetwork(1.0).SpikingSolver(LeakyIntegrateAndFire)
+	require.NoError(t, err, "failed to create solver")
+	assert.Error(t, solver.Step(0))
+	assert.Error(t, solver.Step(-1))
+}
+
+func TestSpikingSolver_TemporalEncoding(t *testing.T) {
+	input1, input2 := NewNNode(1, InputNeuron), NewNNode(2, InputNeuron)
+	output := NewNNode(3, OutputNeuron)
+	output.ConnectFrom(input1, 1.0)
+	output.ConnectFrom(input2, 1.0)
+	net := NewNetwork([]*NNode{input1, input2}, []*NNode{output}, []*NNode{input1, input2, output}, 1)
+	solver, err := net.SpikingSolver(LeakyIntegrateAndFire)
+	require.NoError(t, err, "failed to create solver")
+	solver.Encoding = TemporalEncoding
+
+	err = solver.LoadSensors([]float64{1.0, 0.5})
+	require.NoError(t, err, "failed to load sensors")
+	firingTimes := [][]int{{}, {}}
+	for step := 1; step <= 2*int(DefaultSpikingEncodingWindow); step++ {
+		err = solver.Step(1.0)
+		require.NoError(t, err, "failed to step at: %d", step)
+		for i, spike := range solver.Spikes()[:2] {
+			if spike {
+				firingTimes[i] = append(firingTimes[i], step)
+			}
+		}
+	}
+	// each sensor fires once per encoding window, the larger value - the earlier
+	assert.Equal(t, []int{1, 21}, firingTimes[0])
+	assert.Equal(t, []int{10, 30}, firingTimes[1])
+}
+
+func TestSpikingSolver_LoadSensors(t *testing.T) {
+	solver, err := buildNetwork().SpikingSolver(LeakyIntegrateAndFire)
+	require.NoError(t, err, "failed to create solver")
+	err = solver.LoadSensors([]float64{1, 0.5})
+	assert.NoError(t, err)
+
+	err = solver.LoadSensors([]float64{1})
+	assert.EqualError(t, err, ErrNetUnsupportedSensorsArraySize.Error())
+}
+
+func TestSpikingSolver_ForwardSteps(t *testing.T) {
+	solver, err := buildSpikingRelayNetwork(1.0).SpikingSolver(LeakyIntegrateAndFire)
+	require.NoError(t, err, "failed to create solver")
+	err = solver.LoadSensors([]float64{1.0})
+	require.NoError(t, err, "failed to load sensors")
+
+	res, err := solver.ForwardSteps(DefaultSpikingDecodingWindow)
+	assert.NoError(t, err)
+	assert.True(t, res)
+	assert.Equal(t, float64(DefaultSpikingDecodingWindow)*DefaultSpikingTimeStep, solver.Time())
+	assert.InDelta(t, 0.5, solver.ReadOutputs()[0], 0.05)
+
+	res, err = solver.ForwardSteps(0)
+	assert.ErrorIs(t, err, ErrZeroActivationStepsRequested)
+	assert.False(t, res)
+
+	res, err = solver.RecursiveSteps()
+	assert.Error(t, err)
+	assert.False(t, res)
+}
+
+func TestSpikingSolver_Relax(t *testing.T) {
+	solver, err := buildSpikingRelayNetwork(1.0).SpikingSolver(LeakyIntegrateAndFire)
+	require.NoError(t, err, "failed to create solver")
+	err = solver.LoadSensors([]float64{1.0})
+	require.NoError(t, err, "failed to load sensors")
+
+	relaxed, err := solver.Relax(DefaultSpikingDecodingWindow-1, 0.1)
+	assert.NoError(t, err)
+	assert.False(t, relaxed, "must not relax before decoding window filled")
+
+	relaxed, err = solver.Relax(10, 0.1)
+	assert.NoError(t, err)
+	assert.True(t, relaxed)
+}
+
+func TestSpikingSolver_Flush(t *testing.T) {
+	solver, err := buildSpikingRelayNetwork(1.0).SpikingSolver(Izhikevich)
+	require.NoError(t, err, "failed to create solver")
+	err = solver.LoadSensors([]float64{1.0})
+	require.NoError(t, err, "failed to load sensors")
+	_, err = solver.ForwardSteps(100)
+	require.NoError(t, err, "failed to run forward steps")
+	require.NotZero(t, solver.ReadOutputs()[0])
+
+	res, err := solver.Flush()
+	assert.NoError(t, err)
+	assert.True(t, res)
+	assert.Zero(t, solver.Time())
+	assert.Equal(t, []float64{0}, solver.ReadOutputs())
+	assert.Equal(t, []bool{false, false}, solver.Spikes())
+	assert.Equal(t, izhikevichMinC, solver.Potentials()[1])
+}